@@ -19,23 +19,44 @@ import (
 	"io/ioutil"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/base52"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
 	"github.com/pingcap/errors"
 	"github.com/spf13/cobra"
 )
 
+// auditArchiveDir is the dir under the audit dir to keep the audit records of
+// destroyed clusters
+const auditArchiveDir = "clusters"
+
 func newAuditCmd() *cobra.Command {
+	var clusterName string
 	cmd := &cobra.Command{
 		Use:   "audit [audit-id]",
 		Short: "Show audit log of cluster operation",
+		Long: `Show audit log of cluster operation. With --cluster, the records of the
+mutating operations on the cluster are listed instead, and the record
+with the specified index is shown in detail.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if clusterName != "" {
+				switch len(args) {
+				case 0:
+					return showClusterAuditRecords(clusterName)
+				case 1:
+					return showClusterAuditRecord(clusterName, args[0])
+				default:
+					return cmd.Help()
+				}
+			}
+
 			switch len(args) {
 			case 0:
 				return showAuditList()
@@ -46,9 +67,99 @@ func newAuditCmd() *cobra.Command {
 			}
 		},
 	}
+
+	cmd.Flags().StringVar(&clusterName, "cluster", "", "Show the audit records of mutating operations on the cluster")
+
 	return cmd
 }
 
+// clusterAuditPath returns the audit records file of the cluster, the one
+// archived on destroy is used if the cluster doesn't exist
+func clusterAuditPath(clusterName string) string {
+	if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+		return meta.ProfilePath(meta.TiOpsAuditDir, auditArchiveDir, clusterName+".log")
+	}
+	return meta.ClusterPath(clusterName, meta.AuditFileName)
+}
+
+// auditOperation records the mutating operation on the cluster, err is the
+// result of the operation
+func auditOperation(clusterName, operation string, nodes []string, err error) {
+	if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+		return
+	}
+	log.Audit(meta.ClusterPath(clusterName, meta.AuditFileName), clusterName, operation, nodes, err)
+}
+
+// archiveAuditRecords appends the audit records of the cluster to the archive
+// so that they are kept after the cluster is destroyed
+func archiveAuditRecords(clusterName string) error {
+	records, err := log.ReadAuditRecords(meta.ClusterPath(clusterName, meta.AuditFileName))
+	if err != nil {
+		return errors.Annotatef(err, "failed to read audit records of cluster %s", clusterName)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	archive := meta.ProfilePath(meta.TiOpsAuditDir, auditArchiveDir, clusterName+".log")
+	if err := log.AppendAuditRecords(archive, records...); err != nil {
+		return errors.Annotatef(err, "failed to archive audit records of cluster %s", clusterName)
+	}
+	return nil
+}
+
+func showClusterAuditRecords(clusterName string) error {
+	records, err := log.ReadAuditRecords(clusterAuditPath(clusterName))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Header
+	auditTable := [][]string{{"#", "Time", "User", "Operation", "Nodes", "Result"}}
+	for i, r := range records {
+		result := color.GreenString("success")
+		if r.Error != "" {
+			result = color.RedString("failed")
+		}
+		auditTable = append(auditTable, []string{
+			strconv.Itoa(i + 1),
+			r.Time.Format(time.RFC3339),
+			r.User,
+			r.Operation,
+			strings.Join(r.Nodes, ","),
+			result,
+		})
+	}
+
+	cliutil.PrintTable(auditTable, true)
+	return nil
+}
+
+func showClusterAuditRecord(clusterName, index string) error {
+	records, err := log.ReadAuditRecords(clusterAuditPath(clusterName))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 1 || i > len(records) {
+		return errors.Errorf("cannot find the audit record #%s of cluster %s", index, clusterName)
+	}
+
+	r := records[i-1]
+	fmt.Printf("Time:      %s\n", r.Time.Format(time.RFC3339))
+	fmt.Printf("User:      %s\n", r.User)
+	fmt.Printf("Cluster:   %s\n", r.Cluster)
+	fmt.Printf("Operation: %s\n", r.Operation)
+	fmt.Printf("Nodes:     %s\n", strings.Join(r.Nodes, ","))
+	if r.Error != "" {
+		fmt.Printf("Result:    %s\n", color.RedString(r.Error))
+	} else {
+		fmt.Printf("Result:    %s\n", color.GreenString("success"))
+	}
+	fmt.Printf("Command:   %s\n", color.CyanString(r.Command))
+	return nil
+}
+
 func showAuditList() error {
 	firstLine := func(fileName string) (string, error) {
 		file, err := os.Open(meta.ProfilePath(meta.TiOpsAuditDir, fileName))
@@ -40,6 +40,8 @@ type checkOptions struct {
 	usePassword  bool   // use password instead of identity file for ssh connection
 	opr          *operator.CheckOptions
 	applyFix     bool // try to apply fixes of failed checks
+	portsOnly    bool // only check the port conflicts in the topology, no SSH is made
+	network      bool // only check the peer ports are reachable between the hosts
 }
 
 func newCheckCmd() *cobra.Command {
@@ -50,11 +52,32 @@ func newCheckCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "check <topology.yml>",
 		Short: "Perform preflight checks for the cluster.",
+		Long: `Perform preflight checks on the hosts in the topology file before deploying,
+including the CPU and memory, the filesystem of data dirs, swap, THP, kernel
+parameters, the ports already in use and the clock skew relative to this
+machine. The results are printed as a table
+and the command fails if any check fails.
+
+With --ports, only the port conflicts in the topology and with the other
+clusters are checked without connecting to the hosts.
+
+With --network, only the reachability of the ports of the instances from the
+other hosts is checked, e.g. TiKV to PD and TiDB to TiKV, to find the paths
+blocked by firewall or security group rules. The results are printed as a
+matrix of the hosts.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 1 {
 				return cmd.Help()
 			}
 
+			// setting the minimum implies the check is wanted
+			if cmd.Flags().Changed("min-cpu-threads") {
+				opt.opr.EnableCPU = true
+			}
+			if cmd.Flags().Changed("min-mem") {
+				opt.opr.EnableMem = true
+			}
+
 			logger.EnableAuditLog()
 			var topo meta.TopologySpecification
 			if err := utils.ParseTopologyYaml(args[0], &topo); err != nil {
@@ -62,9 +85,16 @@ func newCheckCmd() *cobra.Command {
 			}
 
 			// use a dummy cluster name, the real cluster name is set during deploy
+			if err := logDuplicateDeployment("nonexist-dummy-tidb-cluster", &topo); err != nil {
+				return err
+			}
 			if err := prepare.CheckClusterPortConflict("nonexist-dummy-tidb-cluster", &topo); err != nil {
 				return err
 			}
+			if opt.portsOnly {
+				log.Infof("No port conflicts found in %s", args[0])
+				return nil
+			}
 			if err := prepare.CheckClusterDirConflict("nonexist-dummy-tidb-cluster", &topo); err != nil {
 				return err
 			}
@@ -74,6 +104,10 @@ func newCheckCmd() *cobra.Command {
 				return err
 			}
 
+			if opt.network {
+				return checkNetwork(sshConnProps, &topo, &opt)
+			}
+
 			if err := checkSystemInfo(sshConnProps, &topo, &opt); err != nil {
 				return err
 			}
@@ -89,7 +123,12 @@ func newCheckCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&opt.opr.EnableCPU, "enable-cpu", false, "Enable CPU thread count check")
 	cmd.Flags().BoolVar(&opt.opr.EnableMem, "enable-mem", false, "Enable memory size check")
 	cmd.Flags().BoolVar(&opt.opr.EnableDisk, "enable-disk", false, "Enable disk IO (fio) check")
+	cmd.Flags().IntVar(&opt.opr.MinCPUThreads, "min-cpu-threads", operator.DefaultMinCPUThreads, "The minimum CPU threads of each host, implies --enable-cpu if set")
+	cmd.Flags().IntVar(&opt.opr.MinMemSize, "min-mem", operator.DefaultMinMemSize, "The minimum memory size in GB of each host, implies --enable-mem if set")
 	cmd.Flags().BoolVar(&opt.applyFix, "apply", false, "Try to fix failed checks")
+	cmd.Flags().DurationVar(&opt.opr.MaxClockSkew, "max-clock-skew", operator.DefaultMaxClockSkew, "The max clock skew of each host relative to this machine")
+	cmd.Flags().BoolVar(&opt.portsOnly, "ports", false, "Only check the port conflicts in the topology, the hosts are not connected")
+	cmd.Flags().BoolVar(&opt.network, "network", false, "Only check the ports of the instances are reachable from the other hosts")
 
 	return cmd
 }
@@ -104,6 +143,20 @@ func checkSystemInfo(s *cliutil.SSHConnectionProps, topo *meta.TopologySpecifica
 	)
 	insightVer := meta.ComponentVersion(meta.ComponentCheckCollector, "")
 
+	// the collector is copied to the hosts of each arch
+	hosts := map[string]int{} // host -> ssh-port
+	topo.IterInstance(func(inst meta.Instance) {
+		hosts[inst.GetHost()] = inst.GetSSHPort()
+	})
+	if err := detectArch(topo, hosts, opt.user, s); err != nil {
+		return err
+	}
+	downloadTasks := map[string]task.Task{} // arch -> task
+	for host := range hosts {
+		arch := topo.HostArch(host)
+		downloadTasks[arch] = task.NewBuilder().Download(meta.ComponentCheckCollector, arch, insightVer).Build()
+	}
+
 	uniqueHosts := map[string]int{} // host -> ssh-port
 	topo.IterInstance(func(inst meta.Instance) {
 		if _, found := uniqueHosts[inst.GetHost()]; !found {
@@ -121,7 +174,7 @@ func checkSystemInfo(s *cliutil.SSHConnectionProps, topo *meta.TopologySpecifica
 					sshTimeout,
 				).
 				Mkdir(opt.user, inst.GetHost(), filepath.Join(task.CheckToolsPathDir, "bin")).
-				CopyComponent(meta.ComponentCheckCollector, insightVer, inst.GetHost(), task.CheckToolsPathDir).
+				CopyComponent(meta.ComponentCheckCollector, topo.HostArch(inst.GetHost()), insightVer, inst.GetHost(), task.CheckToolsPathDir).
 				Shell(
 					inst.GetHost(),
 					filepath.Join(task.CheckToolsPathDir, "bin", "insight"),
@@ -208,6 +261,13 @@ func checkSystemInfo(s *cliutil.SSHConnectionProps, topo *meta.TopologySpecifica
 					topo,
 					opt.opr,
 				).
+				CheckSys(
+					inst.GetHost(),
+					dataDir,
+					task.CheckTypeClock,
+					topo,
+					opt.opr,
+				).
 				BuildAsStep(fmt.Sprintf("  - Checking node %s", inst.GetHost()))
 			checkSysTasks = append(checkSysTasks, t2)
 
@@ -227,8 +287,12 @@ func checkSystemInfo(s *cliutil.SSHConnectionProps, topo *meta.TopologySpecifica
 		}
 	})
 
+	var dlTasks []task.Task
+	for _, t := range downloadTasks {
+		dlTasks = append(dlTasks, t)
+	}
 	t := task.NewBuilder().
-		Download(meta.ComponentCheckCollector, insightVer).
+		Parallel(dlTasks...).
 		ParallelStep("+ Collect basic system information", collectTasks...).
 		ParallelStep("+ Check system requirements", checkSysTasks...).
 		ParallelStep("+ Cleanup check files", cleanTasks...).
@@ -249,7 +313,15 @@ func checkSystemInfo(s *cliutil.SSHConnectionProps, topo *meta.TopologySpecifica
 		// Header
 		{"Node", "Check", "Result", "Message"},
 	}
+	failed := 0
 	for host := range uniqueHosts {
+		results, _ := ctx.GetCheckResults(host)
+		for _, r := range results {
+			if r.Err != nil && !r.IsWarning() {
+				failed++
+			}
+		}
+
 		tf := task.NewBuilder().
 			RootSSH(
 				host,
@@ -285,6 +357,9 @@ func checkSystemInfo(s *cliutil.SSHConnectionProps, topo *meta.TopologySpecifica
 		}
 	}
 
+	if failed > 0 {
+		return errors.Errorf("%d checks failed, the hosts are not ready for deploying", failed)
+	}
 	return nil
 }
 
@@ -367,13 +442,22 @@ func fixFailedChecks(ctx *task.Context, host string, res *operator.CheckResult,
 			),
 			true)
 		msg = fmt.Sprintf("will try to %s, reboot might be needed", color.HiBlueString("disable SELinux"))
+	case operator.CheckNameTHP:
+		t.Shell(host,
+			fmt.Sprintf(
+				"echo never > %[1]s/enabled && echo never > %[1]s/defrag",
+				"/sys/kernel/mm/transparent_hugepage",
+			),
+			true)
+		msg = fmt.Sprintf("will try to %s, it's not kept after reboot", color.HiBlueString("disable THP"))
 	case operator.CheckNameOSVer,
 		operator.CheckNameCPUThreads,
 		operator.CheckNameDisks,
 		operator.CheckNameEpoll,
 		operator.CheckNameMem,
 		operator.CheckNameCommand,
-		operator.CheckNameFio:
+		operator.CheckNameFio,
+		operator.CheckNameClockSkew:
 		// don't show unsupported message for checks that are impossible to fix by us
 		return "", nil
 	default:
@@ -0,0 +1,110 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+)
+
+func newCheckConfigCmd() *cobra.Command {
+	opt := displayOption{}
+	cmd := &cobra.Command{
+		Use:   "check-config <cluster-name>",
+		Short: "Detect config files modified out of tiup-cluster",
+		Long: `Render the config files of each instance from the topology, the same as
+reload does, and compare them with the files on the remote hosts. The diff
+is printed for each drifted file, and the command fails if any drift is found.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			clusterName := args[0]
+			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot check config of non-exists cluster %s", clusterName)
+			}
+
+			metadata, err := meta.ClusterMetadata(clusterName)
+			if err != nil {
+				return err
+			}
+			ctx, err := newDisplayContext(clusterName, metadata, sshTimeout)
+			if err != nil {
+				return err
+			}
+
+			drifted := 0
+			for _, ins := range filterInstances(metadata, &opt) {
+				drifts, err := operator.CheckConfigDrift(ctx, clusterName, metadata, ins)
+				if err != nil {
+					return err
+				}
+				for _, d := range drifts {
+					drifted++
+					if d.Missing {
+						log.Warnf("%s of %s is missing", d.Path, ins.ID())
+					} else {
+						log.Warnf("%s of %s is modified", d.Path, ins.ID())
+					}
+					fmt.Print(colorDiff(d.Diff))
+				}
+			}
+
+			if drifted > 0 {
+				return errors.Errorf("found %d drifted config files in cluster %s, run `%s reload %s` to overwrite them",
+					drifted, clusterName, cliutil.OsArgs0(), clusterName)
+			}
+			log.Infof("Config of cluster %s is consistent with the topology", clusterName)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opt.filterRole, "role", "R", nil, "Only check specified roles")
+	cmd.Flags().StringSliceVarP(&opt.filterNode, "node", "N", nil, "Only check specified nodes")
+
+	return cmd
+}
+
+// colorDiff colors the headers, added and removed lines of the unified diff
+func colorDiff(diff string) string {
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		text := strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(text, "--- "), strings.HasPrefix(text, "+++ "):
+			text = color.New(color.Bold).Sprint(text)
+		case strings.HasPrefix(text, "@@"):
+			text = color.CyanString(text)
+		case strings.HasPrefix(text, "-"):
+			text = color.RedString(text)
+		case strings.HasPrefix(text, "+"):
+			text = color.GreenString(text)
+		}
+		b.WriteString(text)
+		if strings.HasSuffix(line, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,174 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/joomcode/errorx"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
+	"github.com/pingcap/errors"
+)
+
+// the results of probing a port which mean the path is not blocked, a refused
+// connection still gets through, the port is just not listened on yet
+const (
+	probeOpen    = "open"
+	probeRefused = "refused"
+
+	probeTimeoutSeconds = 3
+)
+
+// probeScript connects to each of the host:port targets with the /dev/tcp of
+// bash and prints one line of "host:port result" for each of them, the result
+// is one of open, refused, timeout and unreachable
+const probeScript = `for t in %s; do
+  out=$(timeout %d bash -c "</dev/tcp/${t%%:*}/${t##*:}" 2>&1); rc=$?
+  if [ $rc -eq 0 ]; then r=open; elif [ $rc -eq 124 ]; then r=timeout;
+  elif echo "$out" | grep -q refused; then r=refused; else r=unreachable; fi
+  echo "$t $r"
+done`
+
+// checkNetwork probes the ports of the instances in the topology from the
+// other hosts and prints the results as a host by host matrix, it fails if
+// any of the paths is blocked
+func checkNetwork(s *cliutil.SSHConnectionProps, topo *meta.TopologySpecification, opt *checkOptions) error {
+	sshPorts := map[string]int{}           // host -> ssh-port
+	hostPorts := map[string]map[int]bool{} // host -> ports of the instances
+	topo.IterInstance(func(inst meta.Instance) {
+		host := inst.GetHost()
+		sshPorts[host] = inst.GetSSHPort()
+		if hostPorts[host] == nil {
+			hostPorts[host] = map[int]bool{}
+		}
+		for _, port := range inst.UsedPorts() {
+			hostPorts[host][port] = true
+		}
+	})
+
+	hosts := make([]string, 0, len(sshPorts))
+	for host := range sshPorts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	if len(hosts) < 2 {
+		log.Infof("All instances are on the same host, no network path to check")
+		return nil
+	}
+
+	var probeTasks []*task.StepDisplay
+	for _, src := range hosts {
+		var targets []string
+		for _, dst := range hosts {
+			if dst == src {
+				continue
+			}
+			for _, port := range sortedPorts(hostPorts[dst]) {
+				targets = append(targets, fmt.Sprintf("%s:%d", dst, port))
+			}
+		}
+		t := task.NewBuilder().
+			RootSSH(
+				src,
+				sshPorts[src],
+				opt.user,
+				s.Password,
+				s.IdentityFile,
+				s.IdentityFilePassphrase,
+				sshTimeout,
+			).
+			Shell(src, fmt.Sprintf(probeScript, strings.Join(targets, " "), probeTimeoutSeconds), false).
+			BuildAsStep(fmt.Sprintf("  - Probing the peer ports from %s", src))
+		probeTasks = append(probeTasks, t)
+	}
+
+	ctx := task.NewContext()
+	t := task.NewBuilder().
+		ParallelStep("+ Check the network between the hosts", probeTasks...).
+		Build()
+	if err := t.Execute(ctx); err != nil {
+		if errorx.Cast(err) != nil {
+			return err
+		}
+		return errors.Trace(err)
+	}
+
+	// the matrix of the source hosts in rows and the target ones in columns
+	header := []string{"From \\ To"}
+	header = append(header, hosts...)
+	matrix := [][]string{header}
+	blocked := 0
+	for _, src := range hosts {
+		stdout, _, _ := ctx.GetOutputs(src)
+		failures := map[string][]int{} // target host -> blocked ports
+		scanner := bufio.NewScanner(bytes.NewReader(stdout))
+		for scanner.Scan() {
+			var target, result string
+			if _, err := fmt.Sscan(scanner.Text(), &target, &result); err != nil {
+				continue
+			}
+			switch result {
+			case probeOpen, probeRefused:
+				continue
+			}
+			var host string
+			var port int
+			if idx := strings.LastIndex(target, ":"); idx > 0 {
+				host = target[:idx]
+				_, _ = fmt.Sscan(target[idx+1:], &port)
+			}
+			log.Debugf("%s -> %s: %s", src, target, result)
+			failures[host] = append(failures[host], port)
+			blocked++
+		}
+
+		row := []string{src}
+		for _, dst := range hosts {
+			switch {
+			case dst == src:
+				row = append(row, "-")
+			case len(failures[dst]) > 0:
+				row = append(row, color.HiRedString("blocked: %s", utils.JoinInt(failures[dst], ",")))
+			default:
+				row = append(row, color.GreenString("ok"))
+			}
+		}
+		matrix = append(matrix, row)
+	}
+	cliutil.PrintTable(matrix, true)
+
+	if blocked > 0 {
+		return errors.Errorf("%d peer ports are blocked, please check the firewall and security group rules of the hosts", blocked)
+	}
+	log.Infof("All peer ports are reachable between the hosts")
+	return nil
+}
+
+func sortedPorts(ports map[int]bool) []int {
+	result := make([]int, 0, len(ports))
+	for port := range ports {
+		result = append(result, port)
+	}
+	sort.Ints(result)
+	return result
+}
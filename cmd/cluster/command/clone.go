@@ -0,0 +1,122 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil/prepare"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newCloneCmd() *cobra.Command {
+	var (
+		hostMapFile string
+		output      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "clone <source-cluster-name> <dest-cluster-name>",
+		Short: "Generate the topology file of a new cluster with the layout of an existing one",
+		Long: `Generate the topology file of a new cluster with the same layout as the source
+cluster, i.e. the same instances, directories, ports and config, with every
+host replaced by the one it's mapped to in the host map file, e.g.
+
+  172.16.5.138: 10.0.1.1
+  172.16.5.139: 10.0.1.2
+
+The source cluster is not touched, deploy the new cluster with the generated
+topology file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return cmd.Help()
+			}
+			if hostMapFile == "" {
+				return errors.New("the host map file must be specified by --host-map")
+			}
+
+			source, dest := args[0], args[1]
+			if tiuputils.IsNotExist(meta.ClusterPath(source, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot clone non-exists cluster %s", source)
+			}
+			if err := utils.ValidateClusterNameOrError(dest); err != nil {
+				return err
+			}
+			if tiuputils.IsExist(meta.ClusterPath(dest, meta.MetaFileName)) {
+				return errDeployNameDuplicate.
+					New("Cluster name '%s' is duplicated", dest).
+					WithProperty(cliutil.SuggestionFromFormat("Please specify another cluster name"))
+			}
+
+			metadata, err := meta.ClusterMetadata(source)
+			if err != nil {
+				return err
+			}
+
+			data, err := ioutil.ReadFile(hostMapFile)
+			if err != nil {
+				return errors.AddStack(err)
+			}
+			hosts := map[string]string{}
+			if err := yaml.Unmarshal(data, &hosts); err != nil {
+				return errors.Annotatef(err, "failed to parse host map file %s", hostMapFile)
+			}
+
+			topo := metadata.Topology.Exported(metadata.User)
+			if err := topo.RemapHosts(hosts); err != nil {
+				return err
+			}
+			// the instances of different hosts may conflict on the same one
+			if err := topo.Validate(); err != nil {
+				return err
+			}
+			if err := prepare.CheckClusterPortConflict(dest, topo); err != nil {
+				return err
+			}
+			if err := prepare.CheckClusterDirConflict(dest, topo); err != nil {
+				return err
+			}
+
+			data, err = yaml.Marshal(topo)
+			if err != nil {
+				return errors.AddStack(err)
+			}
+			if output == "" {
+				_, err = os.Stdout.Write(data)
+				return err
+			}
+			if err := ioutil.WriteFile(output, data, 0644); err != nil {
+				return errors.AddStack(err)
+			}
+
+			hint := color.New(color.Bold).Sprintf("%s deploy %s %s %s", cliutil.OsArgs0(), dest, metadata.Version, output)
+			log.Infof("Generated topology of cluster %s cloned from %s to %s, you can deploy it via `%s`", dest, source, output, hint)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&hostMapFile, "host-map", "", "The YAML file mapping each host of the source cluster to the one of the new cluster")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the topology to the file instead of stdout")
+
+	return cmd
+}
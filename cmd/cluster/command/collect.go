@@ -0,0 +1,187 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/clusterutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type collectOptions struct {
+	output      string
+	since       time.Duration
+	roles       []string
+	nodes       []string
+	concurrency int
+}
+
+// collectSummary is written to summary.yaml of the bundle
+type collectSummary struct {
+	Name        string            `yaml:"name"`
+	Version     string            `yaml:"version"`
+	CollectedAt time.Time         `yaml:"collected_at"`
+	Since       string            `yaml:"since,omitempty"`
+	Instances   []*instanceDetail `yaml:"instances"`
+	// the instances whose files failed to be collected
+	Failures map[string]string `yaml:"failures,omitempty"`
+}
+
+func newCollectCmd() *cobra.Command {
+	opt := collectOptions{}
+	cmd := &cobra.Command{
+		Use:   "collect <cluster-name>",
+		Short: "Collect the logs and config of the instances for diagnosis",
+		Long: `Collect the log files and the config of the instances into a local directory,
+which is organized as <output>/<host>/<role>/<role>-<port>.tar.gz. The status
+of the instances is written to <output>/summary.yaml.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			if err := validRoles(opt.roles); err != nil {
+				return err
+			}
+
+			clusterName := args[0]
+			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot collect from non-exists cluster %s", clusterName)
+			}
+
+			logger.EnableAuditLog()
+			metadata, err := meta.ClusterMetadata(clusterName)
+			if err != nil {
+				return err
+			}
+
+			if opt.output == "" {
+				opt.output = fmt.Sprintf("%s-diag-%s", clusterName, time.Now().Format("20060102150405"))
+			}
+			return collectDiagnosis(clusterName, metadata, &opt)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opt.output, "output", "o", "", "The local directory to save the collected files (default <cluster-name>-diag-<time>)")
+	cmd.Flags().DurationVar(&opt.since, "since", 0, "Only collect the log files modified in the duration, e.g. 2h (default all)")
+	cmd.Flags().StringSliceVarP(&opt.roles, "role", "R", nil, "Only collect from specified roles")
+	cmd.Flags().StringSliceVarP(&opt.nodes, "node", "N", nil, "Only collect from specified nodes")
+	cmd.Flags().IntVar(&opt.concurrency, "concurrency", 8, "The max number of instances to collect concurrently")
+
+	return cmd
+}
+
+// collectDiagnosis collects the files of the instances in parallel, it's
+// best effort and the failures are recorded in the summary
+func collectDiagnosis(clusterName string, metadata *meta.ClusterMeta, opt *collectOptions) error {
+	instances := filterInstances(metadata, &displayOption{filterRole: opt.roles, filterNode: opt.nodes})
+	if len(instances) == 0 {
+		return errors.Errorf("no instance of cluster %s matches the roles and nodes", clusterName)
+	}
+
+	if err := os.MkdirAll(opt.output, 0755); err != nil {
+		return errors.AddStack(err)
+	}
+
+	ctx, err := newDisplayContext(clusterName, metadata, sshTimeout)
+	if err != nil {
+		return err
+	}
+
+	pdList := metadata.Topology.GetPDList()
+	summary := &collectSummary{
+		Name:        clusterName,
+		Version:     metadata.Version,
+		CollectedAt: time.Now(),
+		Instances:   make([]*instanceDetail, len(instances)),
+	}
+	if opt.since > 0 {
+		summary.Since = opt.since.String()
+	}
+
+	errs := make([]error, len(instances))
+	parallelDo(len(instances), opt.concurrency, func(i int) {
+		ins := instances[i]
+		summary.Instances[i] = collectInstanceDetail(ctx, metadata, ins, pdList)
+		errs[i] = collectInstanceFiles(ctx, metadata, ins, opt)
+	})
+
+	failed := 0
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		failed++
+		if summary.Failures == nil {
+			summary.Failures = map[string]string{}
+		}
+		summary.Failures[instances[i].ID()] = err.Error()
+		log.Warnf("Failed to collect the files of %s: %s", instances[i].ID(), err)
+	}
+
+	data, err := yaml.Marshal(summary)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(opt.output, "summary.yaml"), data, 0644); err != nil {
+		return errors.AddStack(err)
+	}
+
+	log.Infof("Collected the files of %d instances to %s", len(instances)-failed, opt.output)
+	if failed > 0 {
+		return errors.Errorf("failed to collect the files of %d instances, see %s for details",
+			failed, filepath.Join(opt.output, "summary.yaml"))
+	}
+	return nil
+}
+
+// collectInstanceFiles tars the log files and the config of the instance on
+// its host, then downloads the tarball to <output>/<host>/<role>/
+func collectInstanceFiles(ctx *task.Context, metadata *meta.ClusterMeta, ins meta.Instance, opt *collectOptions) error {
+	localDir := filepath.Join(opt.output, ins.GetHost(), ins.Role())
+	if err := os.MkdirAll(localDir, 0755); err != nil {
+		return errors.AddStack(err)
+	}
+	name := fmt.Sprintf("%s-%d.tar.gz", ins.Role(), ins.GetPort())
+
+	deployDir := clusterutil.Abs(metadata.User, ins.DeployDir())
+	logDir := clusterutil.Abs(metadata.User, ins.LogDir())
+	mmin := ""
+	if opt.since > 0 {
+		mmin = fmt.Sprintf(" -mmin -%d", int(math.Ceil(opt.since.Minutes())))
+	}
+	remote := fmt.Sprintf("/tmp/tiup-collect-%s.tar.gz", ins.ServiceName())
+	tarCmd := fmt.Sprintf("{ find %s -type f%s; ls -d %s/conf %s/scripts; } 2>/dev/null | tar czPf %s --ignore-failed-read -T -",
+		logDir, mmin, deployDir, deployDir, remote)
+
+	t := task.NewBuilder().
+		Shell(ins.GetHost(), tarCmd, false).
+		CopyFile(remote, filepath.Join(localDir, name), ins.GetHost(), true).
+		Shell(ins.GetHost(), fmt.Sprintf("rm -f %s", remote), false).
+		Build()
+	return t.Execute(ctx)
+}
@@ -18,6 +18,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
@@ -29,6 +30,7 @@ import (
 	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
 	"github.com/pingcap-incubator/tiup/pkg/repository"
@@ -45,15 +47,23 @@ var (
 
 type componentInfo struct {
 	component string
+	arch      string
 	version   repository.Version
 }
 
 type deployOptions struct {
-	user         string // username to login to the SSH server
-	identityFile string // path to the private key file
-	usePassword  bool   // use password instead of identity file for ssh connection
+	user         string   // username to login to the SSH server
+	identityFile string   // path to the private key file
+	usePassword  bool     // use password instead of identity file for ssh connection
+	compVersions []string // per role version overrides in the form of role=version
+	resume       bool     // skip the tasks finished by the last failed deploy
+	concurrency  int      // the max number of instances to copy files and push the config to concurrently
 }
 
+// defaultDeployConcurrency is the default max number of instances to copy
+// files and push the config to at the same time
+const defaultDeployConcurrency = 8
+
 func newDeploy() *cobra.Command {
 	opt := deployOptions{
 		identityFile: path.Join(utils.UserHome(), ".ssh", "id_rsa"),
@@ -80,6 +90,9 @@ func newDeploy() *cobra.Command {
 	cmd.Flags().StringVar(&opt.user, "user", utils.CurrentUser(), "The user name to login via SSH. The user must has root (or sudo) privilege.")
 	cmd.Flags().StringVarP(&opt.identityFile, "identity_file", "i", opt.identityFile, "The path of the SSH identity file. If specified, public key authentication will be used.")
 	cmd.Flags().BoolVarP(&opt.usePassword, "password", "p", false, "Use password of target hosts. If specified, password authentication will be used.")
+	cmd.Flags().StringSliceVar(&opt.compVersions, "component-version", nil, "Override the version of specified roles, in the form of role=version, e.g. tikv=v4.0.0-patch")
+	cmd.Flags().BoolVar(&opt.resume, "resume", false, "Resume the last failed deploy of the cluster, skipping the tasks already finished")
+	cmd.Flags().IntVar(&opt.concurrency, "concurrency", defaultDeployConcurrency, "The max number of instances to copy files and push the config to concurrently")
 
 	return cmd
 }
@@ -132,11 +145,22 @@ func deploy(clusterName, clusterVersion, topoFile string, opt deployOptions) err
 			WithProperty(cliutil.SuggestionFromFormat("Please specify another cluster name"))
 	}
 
+	compVersions, err := parseComponentVersions(nil, opt.compVersions)
+	if err != nil {
+		return err
+	}
+
 	var topo meta.TopologySpecification
 	if err := utils.ParseTopologyYaml(topoFile, &topo); err != nil {
 		return err
 	}
+	if err := validateTopology(&topo); err != nil {
+		return err
+	}
 
+	if err := logDuplicateDeployment(clusterName, &topo); err != nil {
+		return err
+	}
 	if err := prepare.CheckClusterPortConflict(clusterName, &topo); err != nil {
 		return err
 	}
@@ -161,6 +185,14 @@ func deploy(clusterName, clusterVersion, topoFile string, opt deployOptions) err
 			WithProperty(cliutil.SuggestionFromString("Please check file system permissions and try again."))
 	}
 
+	hosts := map[string]int{} // host -> ssh-port
+	topo.IterInstance(func(inst meta.Instance) {
+		hosts[inst.GetHost()] = inst.GetSSHPort()
+	})
+	if err := detectArch(&topo, hosts, opt.user, sshConnProps); err != nil {
+		return err
+	}
+
 	var (
 		envInitTasks      []*task.StepDisplay // tasks which are used to initialize environment
 		downloadCompTasks []*task.StepDisplay // tasks which are used to download components
@@ -202,11 +234,12 @@ func deploy(clusterName, clusterVersion, topoFile string, opt deployOptions) err
 	})
 
 	// Download missing component
-	downloadCompTasks = prepare.BuildDownloadCompTasks(clusterVersion, &topo)
+	downloadCompTasks = prepare.BuildDownloadCompTasks(clusterVersion, compVersions, &topo)
 
 	// Deploy components to remote
 	topo.IterInstance(func(inst meta.Instance) {
-		version := meta.ComponentVersion(inst.ComponentName(), clusterVersion)
+		roleVersion := meta.RoleVersion(inst.ComponentName(), clusterVersion, compVersions)
+		version := meta.ComponentVersion(inst.ComponentName(), roleVersion)
 		deployDir := clusterutil.Abs(globalOptions.User, inst.DeployDir())
 		// data dir would be empty for components which don't need it
 		dataDir := clusterutil.Abs(globalOptions.User, inst.DataDir())
@@ -220,10 +253,10 @@ func deploy(clusterName, clusterVersion, topoFile string, opt deployOptions) err
 				filepath.Join(deployDir, "bin"),
 				filepath.Join(deployDir, "conf"),
 				filepath.Join(deployDir, "scripts")).
-			CopyComponent(inst.ComponentName(), version, inst.GetHost(), deployDir).
+			CopyComponent(inst.ComponentName(), meta.InstanceArch(inst), version, inst.GetHost(), deployDir).
 			InitConfig(
 				clusterName,
-				clusterVersion,
+				roleVersion,
 				inst,
 				globalOptions.User,
 				meta.DirPaths{
@@ -241,6 +274,7 @@ func deploy(clusterName, clusterVersion, topoFile string, opt deployOptions) err
 	dlTasks, dpTasks := buildMonitoredDeployTask(
 		clusterName,
 		uniqueHosts,
+		&topo,
 		globalOptions,
 		topo.MonitoredOptions,
 		clusterVersion,
@@ -253,10 +287,16 @@ func deploy(clusterName, clusterVersion, topoFile string, opt deployOptions) err
 			task.NewBuilder().SSHKeyGen(meta.ClusterPath(clusterName, "ssh", "id_rsa")).Build()).
 		ParallelStep("+ Download TiDB components", downloadCompTasks...).
 		ParallelStep("+ Initialize target host environments", envInitTasks...).
-		ParallelStep("+ Copy files", deployCompTasks...).
+		ParallelStepWithConcurrency("+ Copy files", opt.concurrency, deployCompTasks...).
 		Build()
 
-	if err := t.Execute(task.NewContext()); err != nil {
+	cp, err := newCheckpoint(clusterName, "deploy", opt.resume)
+	if err != nil {
+		return err
+	}
+	ctx := task.NewContext()
+	ctx.SetCheckpoint(cp)
+	if err := t.Execute(ctx); err != nil {
 		if errorx.Cast(err) != nil {
 			// FIXME: Map possible task errors and give suggestions.
 			return err
@@ -264,32 +304,205 @@ func deploy(clusterName, clusterVersion, topoFile string, opt deployOptions) err
 		return errors.Trace(err)
 	}
 
-	err = meta.SaveClusterMeta(clusterName, &meta.ClusterMeta{
-		User:     globalOptions.User,
-		Version:  clusterVersion,
-		Topology: &topo,
-	})
+	metadata := &meta.ClusterMeta{
+		User:              globalOptions.User,
+		Version:           clusterVersion,
+		ComponentVersions: compVersions,
+		Topology:          &topo,
+	}
+	metadata.StampInstances(&topo, meta.InstanceOriginManual)
+	err = meta.SaveClusterMeta(clusterName, metadata)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := cp.Clear(); err != nil {
+		return err
+	}
 
 	hint := color.New(color.Bold).Sprintf("%s start %s", cliutil.OsArgs0(), clusterName)
 	log.Infof("Deployed cluster `%s` successfully, you can start the cluster via `%s`", clusterName, hint)
 	return nil
 }
 
+// detectArch detects the arch of the hosts via SSH as the user initializing
+// them, and sets it to the instances on the hosts in the topology
+func detectArch(topo *meta.TopologySpecification, hosts map[string]int, user string, sshConnProps *cliutil.SSHConnectionProps) error {
+	var detectTasks []*task.StepDisplay
+	for host, sshPort := range hosts {
+		t := task.NewBuilder().
+			RootSSH(
+				host,
+				sshPort,
+				user,
+				sshConnProps.Password,
+				sshConnProps.IdentityFile,
+				sshConnProps.IdentityFilePassphrase,
+				sshTimeout,
+			).
+			DetectArch(host, topo).
+			BuildAsStep(fmt.Sprintf("  - Detect arch of %s:%d", host, sshPort))
+		detectTasks = append(detectTasks, t)
+	}
+
+	t := task.NewBuilder().
+		ParallelStep("+ Detect CPU architecture", detectTasks...).
+		Build()
+	if err := t.Execute(task.NewContext()); err != nil {
+		if errorx.Cast(err) != nil {
+			return err
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// newCheckpoint returns the checkpoint of the operation on the cluster, the
+// tasks finished by the last failed operation are skipped if resume is true
+func newCheckpoint(clusterName, operation string, resume bool) (*task.Checkpoint, error) {
+	cp, err := task.NewCheckpoint(meta.ClusterPath(clusterName, operation+".checkpoint"), resume)
+	if err != nil {
+		return nil, err
+	}
+	if resume {
+		log.Infof("Resuming the %s of cluster %s, %d finished tasks will be skipped", operation, clusterName, cp.Len())
+	}
+	return cp, nil
+}
+
+// validateTopology checks the topology in memory before any work is done on
+// the hosts, all problems found are reported together
+func validateTopology(topo *meta.TopologySpecification) error {
+	var problems []string
+	if err := topo.Validate(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if len(topo.PDServers) == 0 {
+		problems = append(problems, "at least one PD server is required")
+	}
+
+	type instanceDir struct {
+		id  string
+		dir string
+	}
+	roles := map[string]string{}           // instance ID -> role
+	hostDirs := map[string][]instanceDir{} // host -> dirs of the instances
+	topo.IterInstance(func(ins meta.Instance) {
+		if role, ok := roles[ins.ID()]; ok {
+			problems = append(problems, fmt.Sprintf("instance %s is defined as both %s and %s", ins.ID(), role, ins.Role()))
+		}
+		roles[ins.ID()] = ins.Role()
+
+		for _, port := range ins.UsedPorts() {
+			if port < 1 || port > 65535 {
+				problems = append(problems, fmt.Sprintf("port %d of %s %s is out of range 1-65535", port, ins.Role(), ins.ID()))
+			}
+		}
+
+		// the data dir is the second of the used dirs if the role has one
+		usedDirs := ins.UsedDirs()
+		if ins.DeployDir() == "" {
+			problems = append(problems, fmt.Sprintf("deploy_dir of %s %s is not specified", ins.Role(), ins.ID()))
+		}
+		if len(usedDirs) > 1 && usedDirs[1] == "" {
+			problems = append(problems, fmt.Sprintf("data_dir of %s %s is not specified", ins.Role(), ins.ID()))
+		}
+		if ins.IsImported() {
+			return
+		}
+		for _, dir := range []string{ins.DeployDir(), ins.DataDir(), ins.LogDir()} {
+			if dir != "" {
+				dir = clusterutil.Abs(topo.GlobalOptions.User, dir)
+				hostDirs[ins.GetHost()] = append(hostDirs[ins.GetHost()], instanceDir{ins.ID(), dir})
+			}
+		}
+	})
+
+	// the dirs of different instances on the same host must not overlap
+	hosts := make([]string, 0, len(hostDirs))
+	for host := range hostDirs {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		dirs := hostDirs[host]
+		for i := 0; i < len(dirs); i++ {
+			for j := i + 1; j < len(dirs); j++ {
+				a, b := dirs[i], dirs[j]
+				if a.id == b.id {
+					continue
+				}
+				if a.dir == b.dir || strings.HasPrefix(a.dir, b.dir+"/") || strings.HasPrefix(b.dir, a.dir+"/") {
+					problems = append(problems, fmt.Sprintf("directory %s of %s overlaps %s of %s on host %s", a.dir, a.id, b.dir, b.id, host))
+				}
+			}
+		}
+	}
+
+	switch len(problems) {
+	case 0:
+		return nil
+	case 1:
+		return errors.Errorf("invalid topology: %s", problems[0])
+	default:
+		return errors.Errorf("found %d problems in the topology:\n  %s", len(problems), strings.Join(problems, "\n  "))
+	}
+}
+
+// parseComponentVersions applies the role=version pairs to the existing
+// overrides, an empty version removes the override of the role
+func parseComponentVersions(current map[string]string, pairs []string) (map[string]string, error) {
+	overrides := map[string]string{}
+	for role, version := range current {
+		overrides[role] = version
+	}
+
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Errorf("invalid component version '%s', should be in the form of role=version", pair)
+		}
+		role, version := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		if err := validRoles([]string{role}); err != nil {
+			return nil, err
+		}
+		if version == "" {
+			delete(overrides, role)
+			continue
+		}
+		overrides[role] = version
+	}
+
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+	return overrides, nil
+}
+
 func buildMonitoredDeployTask(
 	clusterName string,
 	uniqueHosts map[string]int, // host -> ssh-port
+	topo *meta.TopologySpecification, // the arch of the hosts
 	globalOptions meta.GlobalOptions,
 	monitoredOptions meta.MonitoredOptions,
 	version string) (downloadCompTasks []*task.StepDisplay, deployCompTasks []*task.StepDisplay) {
+	hostArchs := set.NewStringSet()
+	var archs []string
+	for host := range uniqueHosts {
+		if arch := topo.HostArch(host); !hostArchs.Exist(arch) {
+			hostArchs.Insert(arch)
+			archs = append(archs, arch)
+		}
+	}
+	sort.Strings(archs)
+
 	for _, comp := range []string{meta.ComponentNodeExporter, meta.ComponentBlackboxExporter} {
 		version := meta.ComponentVersion(comp, version)
-		t := task.NewBuilder().
-			Download(comp, version).
-			BuildAsStep(fmt.Sprintf("  - Download %s:%s", comp, version))
-		downloadCompTasks = append(downloadCompTasks, t)
+		for _, arch := range archs {
+			t := task.NewBuilder().
+				Download(comp, arch, version).
+				BuildAsStep(fmt.Sprintf("  - Download %s:%s (%s)", comp, version, arch))
+			downloadCompTasks = append(downloadCompTasks, t)
+		}
 
 		for host, sshPort := range uniqueHosts {
 			deployDir := clusterutil.Abs(globalOptions.User, monitoredOptions.DeployDir)
@@ -310,12 +523,12 @@ func buildMonitoredDeployTask(
 					filepath.Join(deployDir, "bin"),
 					filepath.Join(deployDir, "conf"),
 					filepath.Join(deployDir, "scripts")).
-				CopyComponent(comp, version, host, deployDir).
+				CopyComponent(comp, topo.HostArch(host), version, host, deployDir).
 				MonitoredConfig(
 					clusterName,
 					comp,
 					host,
-					globalOptions.ResourceControl,
+					globalOptions,
 					monitoredOptions,
 					globalOptions.User,
 					meta.DirPaths{
@@ -331,3 +544,17 @@ func buildMonitoredDeployTask(
 	}
 	return
 }
+
+// logDuplicateDeployment logs all ports and directories of topo which are
+// also used by other clusters, so that they are all listed before aborting
+// with the first one by the conflict checks
+func logDuplicateDeployment(clusterName string, topo *meta.TopologySpecification) error {
+	conflicts, err := operator.FindDuplicateDeployment(clusterName, topo)
+	if err != nil {
+		return err
+	}
+	for _, c := range conflicts {
+		log.Errorf("Conflict: %s", c)
+	}
+	return nil
+}
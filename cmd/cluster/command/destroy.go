@@ -40,7 +40,7 @@ func newDestroyCmd() *cobra.Command {
 
 			clusterName := args[0]
 			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
-				return errors.Errorf("cannot destroy non-exists cluster %s", clusterName)
+				return errClusterNotFound.New("cannot destroy non-exists cluster %s", clusterName)
 			}
 
 			logger.EnableAuditLog()
@@ -68,7 +68,13 @@ func newDestroyCmd() *cobra.Command {
 				ClusterOperate(metadata.Topology, operator.DestroyOperation, operator.Options{}).
 				Build()
 
-			if err := t.Execute(task.NewContext()); err != nil {
+			var nodes []string
+			metadata.Topology.IterInstance(func(inst meta.Instance) {
+				nodes = append(nodes, inst.ID())
+			})
+			err = t.Execute(task.NewContext())
+			auditOperation(clusterName, "destroy", nodes, err)
+			if err != nil {
 				if errorx.Cast(err) != nil {
 					// FIXME: Map possible task errors and give suggestions.
 					return err
@@ -76,6 +82,10 @@ func newDestroyCmd() *cobra.Command {
 				return errors.Trace(err)
 			}
 
+			// keep the audit records after the meta dir is removed
+			if err := archiveAuditRecords(clusterName); err != nil {
+				return err
+			}
 			if err := os.RemoveAll(meta.ClusterPath(clusterName)); err != nil {
 				return errors.Trace(err)
 			}
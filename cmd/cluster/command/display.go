@@ -14,14 +14,31 @@
 package command
 
 import (
+	"crypto/tls"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"io/ioutil"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/joomcode/errorx"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/api"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/clusterutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/module"
 	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
@@ -29,16 +46,86 @@ import (
 	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
 	"github.com/pingcap/errors"
 	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v2"
 )
 
 type displayOption struct {
 	clusterName string
 	filterRole  []string
 	filterNode  []string
+	resolve     bool   // show both the hostname and IP of hosts
+	execStart   bool   // show the ExecStart of the systemd unit
+	waitHealthy bool   // wait until all instances are healthy
+	countOnly   bool   // only show the count of instances per role and status
+	placement   bool   // show the placement rules of PD
+	sshLatency  bool   // show the SSH round-trip latency of hosts
+	verifyID    bool   // verify all instances belong to the same cluster
+	pdOperators bool   // show the pending operators of PD
+	pdSchedule  bool   // show the scheduling config of PD
+	legend      bool   // explain the colors of status after the table
+	usage       bool   // show the memory and CPU usage of instances
+	pdConns     bool   // show the client connections of PD instances
+	insecure    bool   // skip the certificate verification of status probes
+	ddl         bool   // show the schema versions and pending DDL jobs
+	dbUser      string // the user to query the DDL jobs from TiDB as
+	uptime      bool   // show how long the instances have been running
+	disk        bool   // show the free space of the data dirs
+	nofile      bool   // show the open files limit of the services
+	store       bool   // show the store ID, leader and region counts of PD
+	changefeeds bool   // show the state and replication lag of TiCDC changefeeds
+	waitTimeout time.Duration
+	concurrency int // the max number of instances to query status concurrently
+	// the status of an instance is Unknown if the query takes longer than it
+	statusTimeout time.Duration
+	// write the details of each instance to files in the dir
+	outputDir string
+	// the output format of the cluster topology, table, json or yaml
+	format string
+	// the percent of free space below which the data dir is highlighted
+	diskWarn float64
+	// only show the instances in the status, matched case-insensitively
+	filterStatus []string
+	// only show instances deployed earlier than this duration ago
+	deployedBefore time.Duration
+	// destroy the tombstone instances, they are only reported by default
+	destroyTombstone bool
+	// show the clock skew of the hosts relative to this machine
+	clockSkew bool
+	// show if the hosts are reachable via SSH, to tell a down service from
+	// an unreachable host
+	sshStatus bool
+	// reuse the statuses queried by the previous display within the ttl
+	cacheTTL time.Duration
+	noCache  bool
+	// refresh the cluster topology on the interval until interrupted
+	watch time.Duration
+	// group the instances in the table by the field, only host for now
+	groupBy string
+	// the meta of the cluster, loaded once and shared by all sections
+	metadata *meta.ClusterMeta
+}
+
+// tableOnlyFlags are the flags of display which only work with the table
+// format, they print extra sections or progress after the topology
+var tableOnlyFlags = []string{
+	"output-dir", "destroy-tombstone", "show-changefeeds", "ssh-latency", "verify-cluster-id",
+	"pd-operators", "pd-schedule", "pd-connections", "ddl", "placement-rules",
+}
+
+// tlsConfig returns the TLS config to talk to the components of the cluster,
+// it's nil if TLS is not enabled for the cluster
+func (opt *displayOption) tlsConfig() (*tls.Config, error) {
+	if !opt.metadata.Topology.GlobalOptions.EnableTLS {
+		return nil, nil
+	}
+	return meta.ClusterTLSConfig(opt.clusterName)
 }
 
 func newDisplayCmd() *cobra.Command {
 	opt := displayOption{}
+	deployedBefore := ""
+	diskWarn := ""
 
 	cmd := &cobra.Command{
 		Use:   "display <cluster-name>",
@@ -49,46 +136,201 @@ func newDisplayCmd() *cobra.Command {
 			}
 
 			opt.clusterName = args[0]
+			switch opt.format {
+			case displayFormatTable, displayFormatJSON, displayFormatYAML:
+			default:
+				return errors.Errorf("unsupported format %s, must be one of %s, %s and %s",
+					opt.format, displayFormatTable, displayFormatJSON, displayFormatYAML)
+			}
+			if opt.groupBy != "" && opt.groupBy != displayGroupByHost {
+				return errors.Errorf("unsupported --group-by %s, only %s is supported", opt.groupBy, displayGroupByHost)
+			}
+			if opt.insecure {
+				log.Warnf("Certificate verification of the status probes is disabled, the status may come from untrusted servers")
+				meta.SetStatusInsecureSkipVerify(true)
+			}
+			if !cmd.Flags().Changed("legend") {
+				// color is disabled if the output is not a terminal
+				opt.legend = !color.NoColor
+			}
+			if diskWarn != "" {
+				v, err := strconv.ParseFloat(strings.TrimSuffix(diskWarn, "%"), 64)
+				if err != nil || v < 0 || v > 100 {
+					return errors.Errorf("invalid --disk-warn %s, must be a percentage like 10%%", diskWarn)
+				}
+				opt.diskWarn = v
+			}
+			if deployedBefore != "" {
+				d, err := utils.ParseDuration(deployedBefore)
+				if err != nil {
+					return errors.Annotatef(err, "invalid --deployed-before")
+				}
+				opt.deployedBefore = d
+			}
+			if opt.watch > 0 && opt.format != displayFormatTable {
+				return errors.Errorf("--watch only supports the table format")
+			}
+			if opt.format != displayFormatTable {
+				// they print to stdout after the topology, which breaks the
+				// single document of json and yaml
+				for _, flag := range tableOnlyFlags {
+					if cmd.Flags().Changed(flag) {
+						return errors.Errorf("--%s only supports the table format", flag)
+					}
+				}
+			}
+			if tiuputils.IsNotExist(meta.ClusterPath(opt.clusterName, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot display non-exists cluster %s", opt.clusterName)
+			}
+			if opt.watch > 0 {
+				return watchClusterTopology(&opt)
+			}
+
+			metadata, err := meta.ClusterMetadata(opt.clusterName)
+			if err != nil {
+				return err
+			}
+			opt.metadata = metadata
 			if err := displayClusterMeta(&opt); err != nil {
 				return err
 			}
+			if opt.waitHealthy {
+				if err := waitClusterHealthy(&opt); err != nil {
+					return err
+				}
+			}
+			if opt.countOnly {
+				return displayStatusCounts(&opt)
+			}
 			if err := displayClusterTopology(&opt); err != nil {
 				return err
 			}
-
-			metadata, err := meta.ClusterMetadata(opt.clusterName)
-			if err != nil {
-				return errors.AddStack(err)
+			if opt.format != displayFormatTable {
+				// only the tombstone instances are reported, on stderr
+				return destroyTombstoneIfNeed(opt.clusterName, metadata, false)
+			}
+			if opt.outputDir != "" {
+				if err := writeInstanceDetails(&opt); err != nil {
+					return err
+				}
 			}
-			return destroyTombstoneIfNeed(opt.clusterName, metadata)
+			if err := warnDuplicateDeployment(&opt); err != nil {
+				return err
+			}
+			if opt.changefeeds {
+				if err := displayCDCChangefeeds(&opt); err != nil {
+					return err
+				}
+			}
+			if opt.sshLatency {
+				if err := displaySSHLatency(&opt); err != nil {
+					return err
+				}
+			}
+			if opt.verifyID {
+				if err := verifyClusterID(&opt); err != nil {
+					return err
+				}
+			}
+			if opt.pdOperators {
+				if err := displayPDOperators(&opt); err != nil {
+					return err
+				}
+			}
+			if opt.pdSchedule {
+				if err := displayPDSchedule(&opt); err != nil {
+					return err
+				}
+			}
+			if opt.pdConns {
+				if err := displayPDConnections(&opt); err != nil {
+					return err
+				}
+			}
+			if opt.ddl {
+				if err := displayDDL(&opt); err != nil {
+					return err
+				}
+			}
+			if opt.placement {
+				if err := displayPlacementRules(&opt); err != nil {
+					return err
+				}
+			}
+
+			return destroyTombstoneIfNeed(opt.clusterName, metadata, opt.destroyTombstone)
 		},
 	}
 
 	cmd.Flags().StringSliceVarP(&opt.filterRole, "role", "R", nil, "Only display specified roles")
 	cmd.Flags().StringSliceVarP(&opt.filterNode, "node", "N", nil, "Only display specified nodes")
+	cmd.Flags().StringSliceVar(&opt.filterStatus, "status", nil, "Only display instances in specified status, e.g. Down")
+	cmd.Flags().BoolVar(&opt.resolve, "resolve", false, "Resolve hostnames to IPs and IPs to hostnames in the Host column")
+	cmd.Flags().BoolVar(&opt.execStart, "exec-start", false, "Display the ExecStart command of the systemd unit of instances, it is truncated in the table but not in the json and yaml formats")
+	cmd.Flags().BoolVar(&opt.waitHealthy, "wait-healthy", false, "Wait until all instances are healthy before display, fail if not after the timeout")
+	cmd.Flags().BoolVar(&opt.countOnly, "count-only", false, "Only display the count of instances per role and status, instances without status API are not probed")
+	cmd.Flags().BoolVar(&opt.placement, "placement-rules", false, "Display the placement rules of the cluster")
+	cmd.Flags().BoolVar(&opt.sshLatency, "ssh-latency", false, "Display the SSH round-trip latency of hosts")
+	cmd.Flags().BoolVar(&opt.verifyID, "verify-cluster-id", false, "Verify all PD and TiKV instances belong to the same cluster")
+	cmd.Flags().BoolVar(&opt.pdOperators, "pd-operators", false, "Display the count of pending PD operators by type")
+	cmd.Flags().BoolVar(&opt.pdSchedule, "pd-schedule", false, "Display the active scheduling config of PD")
+	cmd.Flags().BoolVar(&opt.legend, "legend", false, "Explain the colors of status after the table, enabled by default if the output is a terminal")
+	cmd.Flags().BoolVar(&opt.usage, "usage", false, "Display the current memory and CPU usage of instances along with their limits")
+	cmd.Flags().BoolVar(&opt.pdConns, "pd-connections", false, "Display the client connections of each PD instance by the role of clients")
+	cmd.Flags().BoolVar(&opt.insecure, "insecure-skip-verify", false, "Skip the certificate verification of the HTTPS status probes, the TLS of the cluster itself is not affected")
+	cmd.Flags().BoolVar(&opt.uptime, "show-uptime", false, "Display how long each instance has been running in the Since column, or how long ago it last logged if unknown")
+	cmd.Flags().BoolVar(&opt.disk, "show-disk", false, "Display the free space of the data dir of each instance in the Data Free column")
+	cmd.Flags().BoolVar(&opt.nofile, "show-nofile", false, "Display the open files limit in effect of each instance in the NOFILE column")
+	cmd.Flags().BoolVar(&opt.store, "show-store", false, "Display the store ID, leader and region counts of each TiKV and TiFlash instance from PD")
+	cmd.Flags().BoolVar(&opt.changefeeds, "show-changefeeds", false, "Display the state and replication lag of the TiCDC changefeeds if TiCDC is deployed")
+	cmd.Flags().StringVar(&diskWarn, "disk-warn", "10%", "Highlight the data dirs with free space below the percentage, used with --show-disk")
+	cmd.Flags().BoolVar(&opt.ddl, "ddl", false, "Display the schema version of TiDB servers and the count of pending DDL jobs")
+	cmd.Flags().StringVar(&opt.dbUser, "db-user", "root", fmt.Sprintf("The user of TiDB to count the pending DDL jobs as, the password is read from $%s", envNameDBPassword))
+	cmd.Flags().StringVar(&opt.format, "format", displayFormatTable, "The output format of the cluster topology, one of table, json and yaml, the flags of other sections are rejected if it's not table")
+	cmd.Flags().StringVar(&opt.groupBy, "group-by", "", "Group the instances in the table by host, with the instances, ports and dirs used on each host")
+	cmd.Flags().StringVar(&opt.outputDir, "output-dir", "", "Write all collected details of each instance to a file named by the instance ID in the dir")
+	cmd.Flags().StringVar(&deployedBefore, "deployed-before", "", "Only display instances deployed more than the duration ago, e.g. 30d or 12h")
+	cmd.Flags().DurationVar(&opt.statusTimeout, "status-timeout", 5*time.Second, "Timeout of querying the status of each instance, the status is shown as Unknown if exceeded")
+	cmd.Flags().IntVar(&opt.concurrency, "concurrency", 8, "The max number of instances to query status concurrently")
+	cmd.Flags().DurationVar(&opt.waitTimeout, "timeout", 5*time.Minute, "Timeout of --wait-healthy")
+	cmd.Flags().BoolVar(&opt.clockSkew, "show-clock-skew", false, "Display the clock skew of the host of each instance relative to this machine in the Clock Skew column")
+	cmd.Flags().BoolVar(&opt.sshStatus, "show-ssh", false, "Display if the host of each instance is reachable via SSH in the SSH column")
+	cmd.Flags().DurationVar(&opt.cacheTTL, "cache-ttl", 0, "Reuse the status of instances queried by the previous display within the duration, e.g. 3s when running display in a loop, the cache is dropped by the commands changing the cluster")
+	cmd.Flags().DurationVar(&opt.watch, "watch", 0, "Refresh the cluster topology on the interval until Ctrl+C, e.g. 2s, the other sections are not shown")
+	cmd.Flags().BoolVar(&opt.noCache, "no-cache", false, "Query the status of all instances freshly instead of reusing the cached ones")
+	cmd.Flags().BoolVar(&opt.destroyTombstone, "destroy-tombstone", false, "Destroy the tombstone instances and remove them from the topology, they are only reported by default")
 
 	return cmd
 }
 
 func displayClusterMeta(opt *displayOption) error {
-	if tiuputils.IsNotExist(meta.ClusterPath(opt.clusterName, meta.MetaFileName)) {
-		return errors.Errorf("cannot display non-exists cluster %s", opt.clusterName)
-	}
+	clsMeta := opt.metadata
 
-	clsMeta, err := meta.ClusterMetadata(opt.clusterName)
-	if err != nil {
-		return err
+	// the cluster meta is included in the document of other formats
+	if opt.format != displayFormatTable {
+		return nil
 	}
 
 	cyan := color.New(color.FgCyan, color.Bold)
 
 	fmt.Printf("TiDB Cluster: %s\n", cyan.Sprint(opt.clusterName))
 	fmt.Printf("TiDB Version: %s\n", cyan.Sprint(clsMeta.Version))
+	if len(clsMeta.ComponentVersions) > 0 {
+		var overrides []string
+		for role, version := range clsMeta.ComponentVersions {
+			overrides = append(overrides, fmt.Sprintf("%s=%s", role, version))
+		}
+		sort.Strings(overrides)
+		fmt.Printf("Role Versions: %s\n", cyan.Sprint(strings.Join(overrides, ", ")))
+	}
 
 	return nil
 }
 
-func destroyTombstoneIfNeed(clusterName string, metadata *meta.ClusterMeta) error {
+// destroyTombstoneIfNeed reports the tombstone instances of the cluster, they
+// are destroyed only if destroy is true so that display has no side effect
+// by default
+func destroyTombstoneIfNeed(clusterName string, metadata *meta.ClusterMeta, destroy bool) error {
 	topo := metadata.Topology
 
 	if !operator.NeedCheckTomebsome(topo) {
@@ -109,17 +351,38 @@ func destroyTombstoneIfNeed(clusterName string, metadata *meta.ClusterMeta) erro
 
 	nodes, err := operator.DestroyTombstone(ctx, topo, true /* returnNodesOnly */)
 	if err != nil {
+		if !destroy {
+			log.Warnf("Failed to check tombstone nodes of cluster %s: %s", clusterName, err)
+			return nil
+		}
+		if stderrors.Is(err, operator.ErrPDUnreachable) {
+			return errors.Errorf("cannot check tombstone nodes of cluster %s as PD is unreachable, please make sure the cluster is started", clusterName)
+		}
 		return errors.AddStack(err)
 	}
 
 	if len(nodes) == 0 {
 		return nil
 	}
+	if !destroy {
+		log.Warnf("Found tombstone nodes %v, use `%s display %s --destroy-tombstone` to destroy them",
+			nodes, cliutil.OsArgs0(), clusterName)
+		return nil
+	}
 
+	logger.EnableAuditLog()
 	log.Infof("Start destroy Tombstone nodes: %v ...", nodes)
 
 	_, err = operator.DestroyTombstone(ctx, topo, false /* returnNodesOnly */)
+	auditOperation(clusterName, "destroy-tombstone", nodes, err)
+	// the destroyed nodes are removed from the topology, like UpdateMeta of
+	// scale-in, their records are removed as well
+	metadata.PruneInstances()
 	if err != nil {
+		// the destroyed nodes are removed from the topology even if others failed
+		if serr := meta.SaveClusterMeta(clusterName, metadata); serr != nil {
+			log.Errorf("Failed to save meta of cluster %s: %s", clusterName, serr)
+		}
 		return errors.AddStack(err)
 	}
 
@@ -128,34 +391,31 @@ func destroyTombstoneIfNeed(clusterName string, metadata *meta.ClusterMeta) erro
 	return meta.SaveClusterMeta(clusterName, metadata)
 }
 
-func displayClusterTopology(opt *displayOption) error {
-	metadata, err := meta.ClusterMetadata(opt.clusterName)
-	if err != nil {
-		return err
-	}
-
-	topo := metadata.Topology
-
-	clusterTable := [][]string{
-		// Header
-		{"ID", "Role", "Host", "Ports", "Status", "Data Dir", "Deploy Dir"},
-	}
-
+// newDisplayContext builds a task context with SSH executors to all hosts of
+// the cluster, timeout is the SSH timeout in seconds
+func newDisplayContext(clusterName string, metadata *meta.ClusterMeta, timeout int64) (*task.Context, error) {
 	ctx := task.NewContext()
-	err = ctx.SetSSHKeySet(meta.ClusterPath(opt.clusterName, "ssh", "id_rsa"),
-		meta.ClusterPath(opt.clusterName, "ssh", "id_rsa.pub"))
+	err := ctx.SetSSHKeySet(meta.ClusterPath(clusterName, "ssh", "id_rsa"),
+		meta.ClusterPath(clusterName, "ssh", "id_rsa.pub"))
 	if err != nil {
-		return errors.AddStack(err)
+		return nil, errors.AddStack(err)
 	}
 
-	err = ctx.SetClusterSSH(topo, metadata.User, sshTimeout)
+	err = ctx.SetClusterSSH(metadata.Topology, metadata.User, timeout)
 	if err != nil {
-		return errors.AddStack(err)
+		return nil, errors.AddStack(err)
 	}
+	return ctx, nil
+}
 
+// filterInstances returns the instances in start order which match the role,
+// node and deploy time filters of the option
+func filterInstances(metadata *meta.ClusterMeta, opt *displayOption) []meta.Instance {
+	topo := metadata.Topology
 	filterRoles := set.NewStringSet(opt.filterRole...)
 	filterNodes := set.NewStringSet(opt.filterNode...)
-	pdList := topo.GetPDList()
+
+	var instances []meta.Instance
 	for _, comp := range topo.ComponentsByStartOrder() {
 		for _, ins := range comp.Instances() {
 			// apply role filter
@@ -166,70 +426,1758 @@ func displayClusterTopology(opt *displayOption) error {
 			if len(filterNodes) > 0 && !filterNodes.Exist(ins.ID()) {
 				continue
 			}
-
-			dataDir := "-"
-			insDirs := ins.UsedDirs()
-			deployDir := insDirs[0]
-			if len(insDirs) > 1 {
-				dataDir = insDirs[1]
-			}
-
-			status := ins.Status(pdList...)
-			// Query the service status
-			if status == "-" {
-				e, found := ctx.GetExecutor(ins.GetHost())
-				if found {
-					active, _ := operator.GetServiceStatus(e, ins.ServiceName())
-					if parts := strings.Split(strings.TrimSpace(active), " "); len(parts) > 2 {
-						if parts[1] == "active" {
-							status = "Up"
-						} else {
-							status = parts[1]
-						}
-					}
+			// apply deploy time filter, the instance with unknown deploy time is skipped
+			if opt.deployedBefore > 0 {
+				deployedAt, ok := metadata.InstanceDeployedAt(ins.ID())
+				if !ok || time.Since(deployedAt) < opt.deployedBefore {
+					continue
 				}
 			}
-			clusterTable = append(clusterTable, []string{
-				color.CyanString(ins.ID()),
-				ins.Role(),
-				ins.GetHost(),
-				utils.JoinInt(ins.UsedPorts(), "/"),
-				formatInstanceStatus(status),
-				dataDir,
-				deployDir,
-			})
-
+			instances = append(instances, ins)
 		}
 	}
+	return instances
+}
 
-	// Sort by role,host,ports
-	sort.Slice(clusterTable[1:], func(i, j int) bool {
-		lhs, rhs := clusterTable[i+1], clusterTable[j+1]
-		// column: 1 => role, 2 => host, 3 => ports
-		for _, col := range []int{1, 2} {
-			if lhs[col] != rhs[col] {
-				return lhs[col] < rhs[col]
+// waitClusterHealthy probes the instances repeatedly until all of them are
+// healthy, the instances still not ready are printed on timeout
+func waitClusterHealthy(opt *displayOption) error {
+	metadata := opt.metadata
+
+	ctx, err := newDisplayContext(opt.clusterName, metadata, sshTimeout)
+	if err != nil {
+		return err
+	}
+
+	statusMapping, err := meta.LoadStatusMapping()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	log.Infof("Waiting for all instances to be healthy, timeout %s", opt.waitTimeout)
+	instances := filterInstances(metadata, opt)
+	pdList := metadata.Topology.GetPDList()
+	var notReady []string
+	err = utils.Retry(func() error {
+		notReady = notReady[:0]
+		for _, ins := range instances {
+			status := instanceStatus(ctx, ins, pdList)
+			switch statusCategory(ins.ComponentName(), status, statusMapping) {
+			case "up", "leader":
+				continue
+			}
+			// the tombstone instance is waiting to be cleaned up
+			if strings.ToLower(status) == "tombstone" {
+				continue
 			}
+			notReady = append(notReady, fmt.Sprintf("%s %s: %s", ins.Role(), ins.ID(), status))
 		}
-		return lhs[3] < rhs[3]
+		if len(notReady) > 0 {
+			return errors.Errorf("%d instances are not ready", len(notReady))
+		}
+		return nil
+	}, utils.RetryOption{
+		Timeout: opt.waitTimeout,
+		Delay:   5 * time.Second,
 	})
 
-	cliutil.PrintTable(clusterTable, true)
+	if err != nil {
+		log.Errorf("Instances not ready after %s:", opt.waitTimeout)
+		for _, ins := range notReady {
+			log.Errorf("\t%s", ins)
+		}
+		return errClusterUnhealthy.New("cluster %s is not healthy: %d instances not ready", opt.clusterName, len(notReady))
+	}
 
+	log.Infof("All instances are healthy")
 	return nil
 }
 
-func formatInstanceStatus(status string) string {
-	switch strings.ToLower(status) {
-	case "up", "healthy":
-		return color.GreenString(status)
-	case "healthy|l": // PD leader
-		return color.HiGreenString(status)
-	case "offline", "tombstone", "disconnected":
-		return color.YellowString(status)
-	case "down", "unhealthy", "err":
-		return color.RedString(status)
+// instanceStatus queries the status of the instance, the systemd service
+// status is used for the instance which has no status API
+func instanceStatus(ctx *task.Context, ins meta.Instance, pdList []string) string {
+	status := ins.Status(pdList...)
+	// Query the service status
+	if status == "-" {
+		e, found := ctx.GetExecutor(ins.GetHost())
+		if found {
+			s, err := operator.GetInstanceStatus(e, ins)
+			switch {
+			case err == nil:
+				status = serviceDisplayStatus(s)
+			case errorx.IsOfType(errors.Cause(err), executor.ErrSSHExecuteTimedout):
+				log.Debugf("Query status of %s timed out: %s", ins.ID(), err)
+				status = statusUnknown
+			}
+		}
+	}
+	return status
+}
+
+// serviceDisplayStatus maps the state of the systemd service to the status
+// displayed, the raw state is used for the ones not known
+func serviceDisplayStatus(s *module.ServiceStatus) string {
+	if s.LoadState == "not-found" {
+		return "Not Installed"
+	}
+	switch s.ActiveState {
+	case "active":
+		if s.Running() {
+			return "Up"
+		}
+		return "Up (" + s.SubState + ")"
+	case "activating":
+		// the service is waiting to be restarted after its process exited
+		if s.SubState == "auto-restart" {
+			return "Restarting"
+		}
+		return "Starting"
+	case "deactivating":
+		return "Stopping"
+	case "failed":
+		return "Failed"
+	case "inactive":
+		return "Down"
+	}
+	return s.String()
+}
+
+// statusUnknown is the status of the instance whose status query timed out
+const statusUnknown = "Unknown"
+
+// parallelDo calls fn with the indexes from 0 to n-1 with at most concurrency
+// workers, and waits for all of them to finish
+func parallelDo(n, concurrency int, fn func(i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// instancesStatus queries the status of the instances with at most
+// concurrency workers, the result is in the same order as instances
+func instancesStatus(ctx *task.Context, instances []meta.Instance, pdList []string, concurrency int) []string {
+	statuses := make([]string, len(instances))
+	parallelDo(len(instances), concurrency, func(i int) {
+		statuses[i] = instanceStatus(ctx, instances[i], pdList)
+	})
+	return statuses
+}
+
+// filterInstancesByStatus returns the instances and their statuses which
+// match one of the filters case-insensitively
+func filterInstancesByStatus(instances []meta.Instance, statuses []string, filters []string) ([]meta.Instance, []string) {
+	var filteredInstances []meta.Instance
+	var filteredStatuses []string
+	for i, ins := range instances {
+		for _, f := range filters {
+			if strings.EqualFold(statuses[i], f) {
+				filteredInstances = append(filteredInstances, ins)
+				filteredStatuses = append(filteredStatuses, statuses[i])
+				break
+			}
+		}
+	}
+	return filteredInstances, filteredStatuses
+}
+
+// instancesUptime queries how long the services of the instances have been
+// running, "-" is returned for the instances which are not running. If the
+// uptime is not available from systemd, e.g. the instance is managed by
+// supervisord, how long ago the newest log file was written is shown instead
+// with a "log" label, which also tells a running but stuck instance.
+func instancesUptime(ctx *task.Context, user string, instances []meta.Instance, concurrency int) []string {
+	uptimes := make([]string, len(instances))
+	parallelDo(len(instances), concurrency, func(i int) {
+		uptimes[i] = "-"
+		e, found := ctx.GetExecutor(instances[i].GetHost())
+		if !found {
+			return
+		}
+		uptime, err := operator.GetServiceUptime(e, instances[i].ServiceName())
+		if err == nil {
+			uptimes[i] = formatUptime(uptime)
+			return
+		}
+		log.Debugf("Failed to get uptime of %s: %s", instances[i].ID(), err)
+
+		if instances[i].LogDir() == "" {
+			return
+		}
+		idle, err := operator.GetLogIdleTime(e, clusterutil.Abs(user, instances[i].LogDir()))
+		if err != nil {
+			log.Debugf("Failed to get the log time of %s: %s", instances[i].ID(), err)
+			return
+		}
+		uptimes[i] = fmt.Sprintf("log %s ago", formatUptime(idle))
+	})
+	return uptimes
+}
+
+// instancesDataFree queries the free space of the data dirs of the instances,
+// the ones with free space below warnPercent are colored red. "-" is returned
+// for the instances without data dir.
+func instancesDataFree(ctx *task.Context, deployUser string, instances []meta.Instance, concurrency int, warnPercent float64) []string {
+	disks := make([]string, len(instances))
+	parallelDo(len(instances), concurrency, func(i int) {
+		disks[i] = "-"
+		ins := instances[i]
+		e, found := ctx.GetExecutor(ins.GetHost())
+		if !found || ins.DataDir() == "" {
+			return
+		}
+		usage, err := operator.GetDiskUsage(e, clusterutil.Abs(deployUser, ins.DataDir()))
+		if err != nil || usage.Total == 0 {
+			log.Debugf("Failed to get disk usage of %s: %v", ins.ID(), err)
+			return
+		}
+		percent := float64(usage.Free) * 100 / float64(usage.Total)
+		disks[i] = fmt.Sprintf("%s/%s (%.0f%%)", formatBytes(usage.Free), formatBytes(usage.Total), percent)
+		if percent < warnPercent {
+			disks[i] = color.RedString(disks[i])
+		}
+	})
+	return disks
+}
+
+// instancesLimitNOFILE queries the open files limit in effect of the services
+// of the instances, the ones differing from the limit_nofile configured are
+// colored yellow with the configured one, i.e. they need to be reloaded
+func instancesLimitNOFILE(ctx *task.Context, topo *meta.TopologySpecification, instances []meta.Instance, concurrency int) []string {
+	nofiles := make([]string, len(instances))
+	parallelDo(len(instances), concurrency, func(i int) {
+		nofiles[i] = "-"
+		e, found := ctx.GetExecutor(instances[i].GetHost())
+		if !found {
+			return
+		}
+		nofile, err := operator.GetServiceLimitNOFILE(e, instances[i].ServiceName())
+		if err != nil {
+			log.Debugf("Failed to get LimitNOFILE of %s: %s", instances[i].ID(), err)
+			return
+		}
+		nofiles[i] = nofile
+		if configured := topo.InstanceResourceControl(instances[i]).LimitNOFILE; configured != "" && configured != nofile {
+			nofiles[i] = color.YellowString("%s (configured %s)", nofile, configured)
+		}
+	})
+	return nofiles
+}
+
+// instancesStore queries the stores from PD and returns the ID, leader count
+// and region count of the store of each instance by the instance ID, i.e.
+// TiKV and TiFlash, the ones which are not stores are absent
+func instancesStore(clusterName string, topo *meta.TopologySpecification, instances []meta.Instance, timeout time.Duration) (map[string][]string, error) {
+	var tlsCfg *tls.Config
+	if topo.GlobalOptions.EnableTLS {
+		var err error
+		if tlsCfg, err = meta.ClusterTLSConfig(clusterName); err != nil {
+			return nil, err
+		}
+	}
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	storesInfo, err := api.NewPDClient(topo.GetPDList(), timeout, tlsCfg).GetStores()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string][]string{}
+	for _, ins := range instances {
+		var latestID uint64
+		for _, store := range storesInfo.Stores {
+			// the tombstone stores of the same address have smaller IDs
+			for _, port := range ins.UsedPorts() {
+				if store.Store.Address != fmt.Sprintf("%s:%d", ins.GetHost(), port) || store.Store.Id < latestID {
+					continue
+				}
+				latestID = store.Store.Id
+				result[ins.ID()] = []string{
+					strconv.FormatUint(store.Store.Id, 10),
+					strconv.Itoa(store.Status.LeaderCount),
+					strconv.Itoa(store.Status.RegionCount),
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// printClusterURLs prints the URLs of the PD dashboard, Grafana and
+// Prometheus
+func printClusterURLs(topo *meta.ClusterSpecification, instances []meta.Instance, statuses []string, mapping meta.StatusMapping) {
+	for _, url := range clusterURLs(topo, instances, statuses, mapping) {
+		fmt.Printf("%s URL: %s\n", url[0], color.CyanString(url[1]))
+	}
+}
+
+// clusterURLs returns the names and URLs of the PD dashboard, Grafana and
+// Prometheus, only the instances whose status is up or leader are considered
+func clusterURLs(topo *meta.ClusterSpecification, instances []meta.Instance, statuses []string, mapping meta.StatusMapping) [][2]string {
+	up := map[string]bool{}
+	for i, ins := range instances {
+		switch statusCategory(ins.ComponentName(), statuses[i], mapping) {
+		case "up", "leader":
+			up[ins.ID()] = true
+		}
+	}
+
+	scheme := "http"
+	if topo.GlobalOptions.EnableTLS {
+		scheme = "https"
+	}
+	var urls [][2]string
+	for _, pd := range topo.GetPDList() {
+		if up[pd] {
+			urls = append(urls, [2]string{"Dashboard", fmt.Sprintf("%s://%s/dashboard", scheme, pd)})
+			break
+		}
+	}
+	for _, ins := range instances {
+		if !up[ins.ID()] {
+			continue
+		}
+		switch ins.ComponentName() {
+		case meta.ComponentGrafana:
+			urls = append(urls, [2]string{"Grafana", fmt.Sprintf("http://%s:%d", ins.GetHost(), ins.GetPort())})
+		case meta.ComponentPrometheus:
+			urls = append(urls, [2]string{"Prometheus", fmt.Sprintf("http://%s:%d", ins.GetHost(), ins.GetPort())})
+		}
+	}
+	return urls
+}
+
+// hostsClockSkew queries the clock skew of the hosts of the instances
+// relative to this machine, the hosts skewing more than the default threshold
+// are highlighted and returned in order
+func hostsClockSkew(ctx *task.Context, instances []meta.Instance, concurrency int) (map[string]string, []string) {
+	var hosts []string
+	skews := map[string]string{}
+	for _, ins := range instances {
+		if _, ok := skews[ins.GetHost()]; !ok {
+			skews[ins.GetHost()] = "-"
+			hosts = append(hosts, ins.GetHost())
+		}
+	}
+	sort.Strings(hosts)
+
+	results := make([]string, len(hosts))
+	skewed := make([]bool, len(hosts))
+	parallelDo(len(hosts), concurrency, func(i int) {
+		results[i] = "-"
+		e, found := ctx.GetExecutor(hosts[i])
+		if !found {
+			return
+		}
+		skew, err := operator.GetClockSkew(e)
+		if err != nil {
+			log.Debugf("Failed to get clock skew of %s: %v", hosts[i], err)
+			return
+		}
+		results[i] = skew.Round(time.Millisecond).String()
+		if skew > operator.DefaultMaxClockSkew || skew < -operator.DefaultMaxClockSkew {
+			results[i] = color.RedString(results[i])
+			skewed[i] = true
+		}
+	})
+
+	var skewedHosts []string
+	for i, host := range hosts {
+		skews[host] = results[i]
+		if skewed[i] {
+			skewedHosts = append(skewedHosts, host)
+		}
+	}
+	return skews, skewedHosts
+}
+
+// hostsReachable returns if the hosts of the instances can be logged in and
+// run a command via SSH
+func hostsReachable(ctx *task.Context, instances []meta.Instance, concurrency int) map[string]bool {
+	var hosts []string
+	reachable := map[string]bool{}
+	for _, ins := range instances {
+		if _, ok := reachable[ins.GetHost()]; !ok {
+			reachable[ins.GetHost()] = false
+			hosts = append(hosts, ins.GetHost())
+		}
+	}
+
+	results := make([]bool, len(hosts))
+	parallelDo(len(hosts), concurrency, func(i int) {
+		e, found := ctx.GetExecutor(hosts[i])
+		if !found {
+			return
+		}
+		if _, _, err := e.Execute("true", false); err != nil {
+			log.Debugf("Failed to connect %s via SSH: %v", hosts[i], err)
+			return
+		}
+		results[i] = true
+	})
+	for i, host := range hosts {
+		reachable[host] = results[i]
+	}
+	return reachable
+}
+
+// formatSSHStatus returns OK or Fail for the SSH reachability of a host,
+// Fail is red if colored
+func formatSSHStatus(reachable bool, colored bool) string {
+	if reachable {
+		return "OK"
+	}
+	if colored {
+		return color.RedString("Fail")
+	}
+	return "Fail"
+}
+
+// formatUptime formats the duration with at most two units, e.g. 3d4h
+func formatUptime(d time.Duration) string {
+	days := int64(d / (24 * time.Hour))
+	hours := int64(d/time.Hour) % 24
+	minutes := int64(d/time.Minute) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%ds", int64(d/time.Second))
+}
+
+// roleSummary counts the instances of a role by status
+type roleSummary struct {
+	role   string
+	total  int
+	up     int
+	others map[string]int // the count of each status not up
+}
+
+func (s *roleSummary) add(component, status string, mapping meta.StatusMapping) {
+	s.total++
+	switch statusCategory(component, status, mapping) {
+	case "up", "leader":
+		s.up++
 	default:
-		return status
+		s.others[status]++
+	}
+}
+
+// String formats the summary as "tikv: 5/6 Up (1 Down)"
+func (s *roleSummary) String() string {
+	str := fmt.Sprintf("%s: %d/%d Up", s.role, s.up, s.total)
+	if len(s.others) == 0 {
+		return str
+	}
+	statuses := make([]string, 0, len(s.others))
+	for status := range s.others {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for i, status := range statuses {
+		statuses[i] = fmt.Sprintf("%d %s", s.others[status], status)
+	}
+	return fmt.Sprintf("%s (%s)", str, strings.Join(statuses, ", "))
+}
+
+// displayStatusCounts prints the count of instances per role and status, it
+// only uses the status from the status APIs and never connects to the hosts,
+// so the status of instances without status API is shown as "-"
+func displayStatusCounts(opt *displayOption) error {
+	metadata := opt.metadata
+
+	statusMapping, err := meta.LoadStatusMapping()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	type roleStatus struct {
+		role      string
+		component string
+		status    string
+	}
+	counts := map[roleStatus]int{}
+	pdList := metadata.Topology.GetPDList()
+	for _, ins := range filterInstances(metadata, opt) {
+		counts[roleStatus{ins.Role(), ins.ComponentName(), ins.Status(pdList...)}]++
+	}
+
+	keys := make([]roleStatus, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].role != keys[j].role {
+			return keys[i].role < keys[j].role
+		}
+		return keys[i].status < keys[j].status
+	})
+
+	countTable := [][]string{
+		// Header
+		{"Role", "Status", "Count"},
+	}
+	for _, k := range keys {
+		countTable = append(countTable, []string{
+			k.role,
+			formatInstanceStatus(k.component, k.status, statusMapping),
+			fmt.Sprint(counts[k]),
+		})
+	}
+
+	cliutil.PrintTable(countTable, true)
+	return nil
+}
+
+// the output formats of display
+const (
+	displayFormatTable = "table"
+	displayFormatJSON  = "json"
+	displayFormatYAML  = "yaml"
+)
+
+// displayGroupByHost groups the instances in the table by host
+const displayGroupByHost = "host"
+
+// clusterDocument is the output of display in the json and yaml formats
+type clusterDocument struct {
+	Name      string         `json:"name" yaml:"name"`
+	Version   string         `json:"version" yaml:"version"`
+	Instances []instanceInfo `json:"instances" yaml:"instances"`
+}
+
+// instanceInfo is an instance in the output of the json and yaml formats
+type instanceInfo struct {
+	ID        string `json:"id" yaml:"id"`
+	Role      string `json:"role" yaml:"role"`
+	Host      string `json:"host" yaml:"host"`
+	Ports     string `json:"ports" yaml:"ports"`
+	Status    string `json:"status" yaml:"status"`
+	DataDir   string `json:"data_dir" yaml:"data_dir"`
+	DeployDir string `json:"deploy_dir" yaml:"deploy_dir"`
+	Version   string `json:"version" yaml:"version"`
+	Patch     string `json:"patch,omitempty" yaml:"patch,omitempty"`
+	TLS       bool   `json:"tls" yaml:"tls"`
+	NumaNode  string `json:"numa_node,omitempty" yaml:"numa_node,omitempty"`
+	Arch      string `json:"arch" yaml:"arch"`
+	SSH       string `json:"ssh,omitempty" yaml:"ssh,omitempty"`
+	ExecStart string `json:"exec_start,omitempty" yaml:"exec_start,omitempty"`
+}
+
+// printClusterDocument prints the cluster and instances in the json or yaml
+// format, the instances are sorted the same as the table
+func printClusterDocument(format string, metadata *meta.ClusterMeta, clusterName string, instances []instanceInfo) error {
+	sort.Slice(instances, func(i, j int) bool {
+		lhs, rhs := instances[i], instances[j]
+		if lhs.Role != rhs.Role {
+			return lhs.Role < rhs.Role
+		}
+		if lhs.Host != rhs.Host {
+			return lhs.Host < rhs.Host
+		}
+		return lhs.Ports < rhs.Ports
+	})
+	doc := clusterDocument{
+		Name:      clusterName,
+		Version:   metadata.Version,
+		Instances: instances,
+	}
+
+	var data []byte
+	var err error
+	if format == displayFormatJSON {
+		data, err = json.MarshalIndent(doc, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(doc)
+	}
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	fmt.Print(string(data))
+	return nil
+}
+
+func displayClusterTopology(opt *displayOption) error {
+	metadata := opt.metadata
+
+	topo := metadata.Topology
+	if err := setupStatusTLS(opt.clusterName, metadata); err != nil {
+		return err
+	}
+
+	clusterTable := [][]string{
+		// Header
+		{"ID", "Role", "Host", "Ports", "Status", "Data Dir", "Deploy Dir"},
+	}
+	// only show versions of instances if some roles are overridden
+	showVersion := len(metadata.ComponentVersions) > 0
+	if showVersion {
+		clusterTable[0] = append(clusterTable[0], "Version")
+	}
+	// only show origins of instances if some are created by auto-scaling
+	showOrigin := false
+	for _, im := range metadata.Instances {
+		if im.Origin == meta.InstanceOriginAutoScaling {
+			showOrigin = true
+			break
+		}
+	}
+	if showOrigin {
+		clusterTable[0] = append(clusterTable[0], "Origin")
+	}
+	// only show TLS of instances if some are serving with certificates
+	showTLS := false
+	topo.IterInstance(func(ins meta.Instance) {
+		showTLS = showTLS || topo.InstanceTLSEnabled(ins)
+	})
+	if showTLS {
+		clusterTable[0] = append(clusterTable[0], "TLS")
+	}
+	// the column is only shown if some instances are bound to NUMA nodes
+	showNumaNode := false
+	topo.IterInstance(func(ins meta.Instance) {
+		showNumaNode = showNumaNode || meta.InstanceNumaNode(ins) != ""
+	})
+	if showNumaNode {
+		clusterTable[0] = append(clusterTable[0], "Numa Node")
+	}
+	// the column is only shown if some hosts are not of the default arch
+	showArch := false
+	topo.IterInstance(func(ins meta.Instance) {
+		showArch = showArch || meta.InstanceArch(ins) != meta.DefaultArch
+	})
+	if showArch {
+		clusterTable[0] = append(clusterTable[0], "Arch")
+	}
+	if opt.usage {
+		clusterTable[0] = append(clusterTable[0], "Memory", "CPU")
+	}
+	if opt.uptime {
+		clusterTable[0] = append(clusterTable[0], "Since")
+	}
+	if opt.disk {
+		clusterTable[0] = append(clusterTable[0], "Data Free")
+	}
+	if opt.nofile {
+		clusterTable[0] = append(clusterTable[0], "NOFILE")
+	}
+	if opt.store {
+		clusterTable[0] = append(clusterTable[0], "Store ID", "Leaders", "Regions")
+	}
+	if opt.execStart {
+		clusterTable[0] = append(clusterTable[0], "ExecStart")
+	}
+	if opt.clockSkew {
+		clusterTable[0] = append(clusterTable[0], "Clock Skew")
+	}
+	if opt.sshStatus {
+		clusterTable[0] = append(clusterTable[0], "SSH")
+	}
+
+	ctx, err := newDisplayContext(opt.clusterName, metadata, sshTimeout)
+	if err != nil {
+		return err
+	}
+	// the status is queried with both the SSH dial and the command bounded
+	// by the status timeout, so that no query outlives the display
+	statusCtx := ctx
+	if opt.statusTimeout > 0 {
+		timeout := sshTimeout
+		if secs := int64(math.Ceil(opt.statusTimeout.Seconds())); secs < timeout {
+			timeout = secs
+		}
+		if statusCtx, err = newDisplayContext(opt.clusterName, metadata, timeout); err != nil {
+			return err
+		}
+		statusCtx.SetExecuteTimeout(opt.statusTimeout)
+	}
+
+	statusMapping, err := meta.LoadStatusMapping()
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	instances := filterInstances(metadata, opt)
+	cacheTTL := opt.cacheTTL
+	if opt.noCache {
+		cacheTTL = 0
+	}
+	statuses := cachedInstancesStatus(opt.clusterName, statusCtx, instances, topo.GetPDList(), cacheTTL, opt.concurrency)
+	if len(opt.filterStatus) > 0 {
+		instances, statuses = filterInstancesByStatus(instances, statuses, opt.filterStatus)
+	}
+	var usages map[string]operator.ServiceUsage
+	if opt.usage {
+		usages = instancesUsage(ctx, instances)
+	}
+	var uptimes []string
+	if opt.uptime {
+		uptimes = instancesUptime(ctx, metadata.User, instances, opt.concurrency)
+	}
+	var disks []string
+	if opt.disk {
+		disks = instancesDataFree(ctx, metadata.User, instances, opt.concurrency, opt.diskWarn)
+	}
+	var nofiles []string
+	if opt.nofile {
+		nofiles = instancesLimitNOFILE(ctx, topo, instances, opt.concurrency)
+	}
+	var stores map[string][]string
+	if opt.store {
+		if stores, err = instancesStore(opt.clusterName, topo, instances, opt.statusTimeout); err != nil {
+			log.Warnf("Failed to query the stores from PD: %s", err)
+		}
+	}
+	var skews map[string]string
+	var skewedHosts []string
+	if opt.clockSkew {
+		skews, skewedHosts = hostsClockSkew(ctx, instances, opt.concurrency)
+	}
+	var reachable map[string]bool
+	if opt.sshStatus {
+		reachable = hostsReachable(ctx, instances, opt.concurrency)
+	}
+	var execStarts []string
+	if opt.execStart {
+		execStarts = instancesExecStart(ctx, instances, opt.concurrency)
+	}
+	resolved := map[string]string{}
+	infos := make([]instanceInfo, 0, len(instances))
+	var summaries []*roleSummary
+	summaryIndex := map[string]*roleSummary{}
+	for i, ins := range instances {
+		dataDir := "-"
+		insDirs := ins.UsedDirs()
+		deployDir := insDirs[0]
+		if len(insDirs) > 1 {
+			dataDir = insDirs[1]
+		}
+
+		status := statuses[i]
+		host := ins.GetHost()
+		if opt.resolve {
+			if _, ok := resolved[host]; !ok {
+				resolved[host] = resolveHost(host)
+			}
+			host = resolved[host]
+		}
+
+		infos = append(infos, instanceInfo{
+			ID:        ins.ID(),
+			Role:      ins.Role(),
+			Host:      host,
+			Ports:     utils.JoinInt(ins.UsedPorts(), "/"),
+			Status:    status,
+			DataDir:   dataDir,
+			DeployDir: deployDir,
+			Version:   metadata.RoleVersion(ins.ComponentName()),
+			Patch:     metadata.InstancePatch(ins.ID()),
+			TLS:       topo.InstanceTLSEnabled(ins),
+			NumaNode:  meta.InstanceNumaNode(ins),
+			Arch:      meta.InstanceArch(ins),
+		})
+		if opt.sshStatus {
+			infos[len(infos)-1].SSH = formatSSHStatus(reachable[ins.GetHost()], false)
+		}
+		if opt.execStart {
+			// the full command, only the table truncates it
+			infos[len(infos)-1].ExecStart = execStarts[i]
+		}
+		if opt.format != displayFormatTable {
+			continue
+		}
+
+		summary, ok := summaryIndex[ins.Role()]
+		if !ok {
+			summary = &roleSummary{role: ins.Role(), others: map[string]int{}}
+			summaryIndex[ins.Role()] = summary
+			summaries = append(summaries, summary)
+		}
+		summary.add(ins.ComponentName(), status, statusMapping)
+
+		id := color.CyanString(ins.ID())
+		if metadata.InstancePatch(ins.ID()) != "" {
+			// the binary differs from the one of the version
+			id += color.MagentaString(" (patched)")
+		}
+		row := []string{
+			id,
+			ins.Role(),
+			host,
+			utils.JoinInt(ins.UsedPorts(), "/"),
+			formatInstanceStatus(ins.ComponentName(), status, statusMapping),
+			dataDir,
+			deployDir,
+		}
+		if showVersion {
+			version := metadata.RoleVersion(ins.ComponentName())
+			switch {
+			case version == metadata.Version:
+			case semver.Compare(version, metadata.Version) < 0:
+				// the role is not upgraded to the cluster version yet
+				version = color.YellowString(version + " (behind)")
+			default:
+				// highlight the intentional override
+				version = color.MagentaString(version + " (override)")
+			}
+			row = append(row, version)
+		}
+		if showOrigin {
+			origin := metadata.InstanceOrigin(ins.ID())
+			switch origin {
+			case "":
+				origin = "-"
+			case meta.InstanceOriginAutoScaling:
+				origin = color.BlueString(origin)
+			}
+			row = append(row, origin)
+		}
+		if showTLS {
+			tls := "-"
+			switch {
+			case topo.InstanceTLSEnabled(ins):
+				tls = color.GreenString("enabled")
+			case meta.TLSSupported(ins.ComponentName()):
+				// some instances are left out, e.g. configured by hand
+				tls = color.YellowString("disabled")
+			}
+			row = append(row, tls)
+		}
+		if showNumaNode {
+			numaNode := meta.InstanceNumaNode(ins)
+			if numaNode == "" {
+				numaNode = "-"
+			}
+			row = append(row, numaNode)
+		}
+		if showArch {
+			row = append(row, meta.InstanceArch(ins))
+		}
+		if opt.usage {
+			usage, ok := usages[ins.ID()]
+			rc := topo.InstanceResourceControl(ins)
+			row = append(row, formatMemoryUsage(usage, ok, rc.MemoryLimit), formatCPUUsage(usage, ok, rc.CPUQuota))
+		}
+		if opt.uptime {
+			uptime := uptimes[i]
+			if status == "-" || statusCategory(ins.ComponentName(), status, statusMapping) == "down" {
+				// the service may be running but not serving
+				uptime = "-"
+			}
+			row = append(row, uptime)
+		}
+		if opt.disk {
+			row = append(row, disks[i])
+		}
+		if opt.nofile {
+			row = append(row, nofiles[i])
+		}
+		if opt.store {
+			if store, ok := stores[ins.ID()]; ok {
+				row = append(row, store...)
+			} else {
+				row = append(row, "-", "-", "-")
+			}
+		}
+		if opt.execStart {
+			row = append(row, truncateExecStart(execStarts[i]))
+		}
+		if opt.clockSkew {
+			row = append(row, skews[ins.GetHost()])
+		}
+		if opt.sshStatus {
+			row = append(row, formatSSHStatus(reachable[ins.GetHost()], true))
+		}
+		clusterTable = append(clusterTable, row)
+	}
+
+	if opt.format != displayFormatTable {
+		return printClusterDocument(opt.format, metadata, opt.clusterName, infos)
+	}
+
+	// Sort by role,host,ports
+	sort.Slice(clusterTable[1:], func(i, j int) bool {
+		lhs, rhs := clusterTable[i+1], clusterTable[j+1]
+		// column: 1 => role, 2 => host, 3 => ports
+		for _, col := range []int{1, 2} {
+			if lhs[col] != rhs[col] {
+				return lhs[col] < rhs[col]
+			}
+		}
+		return lhs[3] < rhs[3]
+	})
+
+	if opt.groupBy == displayGroupByHost {
+		printClusterTableByHost(clusterTable)
+	} else {
+		cliutil.PrintTable(clusterTable, true)
+	}
+	// the counts of a part of instances are misleading
+	if len(opt.filterRole) == 0 && len(opt.filterNode) == 0 && len(opt.filterStatus) == 0 && len(summaries) > 0 {
+		parts := make([]string, 0, len(summaries))
+		for _, s := range summaries {
+			parts = append(parts, s.String())
+		}
+		fmt.Println(strings.Join(parts, ", "))
+	}
+	if opt.legend {
+		printStatusLegend(statusMapping)
+	}
+	printClusterURLs(topo, instances, statuses, statusMapping)
+	if len(skewedHosts) > 0 {
+		log.Warnf("The clock of %s skews more than %s, run `%s check` on the topology for the NTP status",
+			strings.Join(skewedHosts, ", "), operator.DefaultMaxClockSkew, cliutil.OsArgs0())
+	}
+
+	return nil
+}
+
+// printClusterTableByHost prints the rows of the cluster table sorted by role
+// and ports in a table per host, each with a subheader of the instances, ports
+// and dirs used on the host
+func printClusterTableByHost(clusterTable [][]string) {
+	header, rows := clusterTable[0], clusterTable[1:]
+	// column: 2 => host, 3 => ports, 5 => data dir, 6 => deploy dir; the rows
+	// are sorted by role and ports already
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i][2] < rows[j][2]
+	})
+
+	for start := 0; start < len(rows); {
+		end := start
+		for end < len(rows) && rows[end][2] == rows[start][2] {
+			end++
+		}
+
+		var ports []int
+		dirs := set.NewStringSet()
+		for _, row := range rows[start:end] {
+			for _, p := range strings.Split(row[3], "/") {
+				if port, err := strconv.Atoi(p); err == nil {
+					ports = append(ports, port)
+				}
+			}
+			for _, dir := range []string{row[5], row[6]} {
+				if dir != "-" && dir != "" {
+					dirs.Insert(dir)
+				}
+			}
+		}
+		sort.Ints(ports)
+
+		fmt.Printf("\n%s: %d instances, %d dirs, ports %s\n",
+			color.CyanString(rows[start][2]), end-start, len(dirs), utils.JoinInt(ports, ","))
+		cliutil.PrintTable(append([][]string{header}, rows[start:end]...), true)
+		start = end
+	}
+}
+
+// journalTailLines is the number of lines of the service logs, i.e. the
+// journal of systemd or the program logs of supervisord, saved by --output-dir
+const journalTailLines = 50
+
+// instanceDetail is everything display collects of an instance, it's saved
+// to a file per instance by --output-dir
+type instanceDetail struct {
+	ID              string               `yaml:"id"`
+	Role            string               `yaml:"role"`
+	Host            string               `yaml:"host"`
+	Ports           []int                `yaml:"ports"`
+	Status          string               `yaml:"status"`
+	Version         string               `yaml:"version"`
+	DeployDir       string               `yaml:"deploy_dir"`
+	DataDir         string               `yaml:"data_dir,omitempty"`
+	ResourceControl meta.ResourceControl `yaml:"resource_control"`
+	MemoryBytes     uint64               `yaml:"memory_bytes,omitempty"`
+	CPUPercent      float64              `yaml:"cpu_percent,omitempty"`
+	ExecStart       string               `yaml:"exec_start,omitempty"`
+	ConfigChecksum  string               `yaml:"config_checksum,omitempty"`
+	Disk            string               `yaml:"disk,omitempty"`
+	Journal         string               `yaml:"journal,omitempty"`
+	// the probes failed, the corresponding fields are left empty
+	Errors []string `yaml:"errors,omitempty"`
+}
+
+// writeInstanceDetails collects the details of each instance and writes them
+// to opt.outputDir as <instance-id>.yaml, the instances are probed concurrently
+func writeInstanceDetails(opt *displayOption) error {
+	metadata := opt.metadata
+	if err := os.MkdirAll(opt.outputDir, 0755); err != nil {
+		return errors.AddStack(err)
+	}
+
+	ctx, err := newDisplayContext(opt.clusterName, metadata, sshTimeout)
+	if err != nil {
+		return err
+	}
+
+	instances := filterInstances(metadata, opt)
+	usages := instancesUsage(ctx, instances)
+	pdList := metadata.Topology.GetPDList()
+
+	errs := make([]error, len(instances))
+	parallelDo(len(instances), opt.concurrency, func(i int) {
+		ins := instances[i]
+		detail := collectInstanceDetail(ctx, metadata, ins, pdList)
+		if usage, ok := usages[ins.ID()]; ok {
+			detail.MemoryBytes = usage.Memory
+			detail.CPUPercent = usage.CPU
+		}
+		data, err := yaml.Marshal(detail)
+		if err == nil {
+			fp := filepath.Join(opt.outputDir, ins.ID()+".yaml")
+			err = ioutil.WriteFile(fp, data, 0644)
+		}
+		errs[i] = errors.AddStack(err)
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	log.Infof("Details of %d instances are written to %s", len(instances), opt.outputDir)
+	return nil
+}
+
+// collectInstanceDetail probes the instance, the failures of probes are
+// recorded in the detail instead of aborting
+func collectInstanceDetail(ctx *task.Context, metadata *meta.ClusterMeta, ins meta.Instance, pdList []string) *instanceDetail {
+	detail := &instanceDetail{
+		ID:              ins.ID(),
+		Role:            ins.Role(),
+		Host:            ins.GetHost(),
+		Ports:           ins.UsedPorts(),
+		Status:          instanceStatus(ctx, ins, pdList),
+		Version:         metadata.RoleVersion(ins.ComponentName()),
+		DeployDir:       clusterutil.Abs(metadata.User, ins.DeployDir()),
+		ResourceControl: metadata.Topology.InstanceResourceControl(ins),
+	}
+	if ins.DataDir() != "" {
+		detail.DataDir = clusterutil.Abs(metadata.User, ins.DataDir())
+	}
+
+	e, found := ctx.GetExecutor(ins.GetHost())
+	if !found {
+		detail.Errors = append(detail.Errors, fmt.Sprintf("no executor of host %s", ins.GetHost()))
+		return detail
+	}
+
+	run := func(cmd string) string {
+		stdout, stderr, err := e.Execute(cmd, true)
+		if err != nil {
+			detail.Errors = append(detail.Errors, fmt.Sprintf("%s: %s, %s", cmd, err, strings.TrimSpace(string(stderr))))
+		}
+		return strings.TrimSpace(string(stdout))
+	}
+
+	if execStart, err := operator.GetServiceExecStart(e, ins.ServiceName()); err != nil {
+		detail.Errors = append(detail.Errors, err.Error())
+	} else {
+		detail.ExecStart = execStart
+	}
+	detail.ConfigChecksum = run(fmt.Sprintf("sha256sum %s/conf/*", detail.DeployDir))
+	diskDir := detail.DataDir
+	if diskDir == "" {
+		diskDir = detail.DeployDir
+	}
+	detail.Disk = run(fmt.Sprintf("df -h %s", diskDir))
+	manager, err := meta.InstanceServiceManager(e, ins)
+	if err != nil {
+		detail.Errors = append(detail.Errors, err.Error())
+		return detail
+	}
+	stdout, stderr, err := manager.Logs(e, ins.ServiceName(), journalTailLines)
+	if err != nil {
+		detail.Errors = append(detail.Errors, fmt.Sprintf("failed to get logs of %s: %s, %s", ins.ServiceName(), err, strings.TrimSpace(string(stderr))))
+	}
+	detail.Journal = strings.TrimSpace(string(stdout))
+	return detail
+}
+
+// warnDuplicateDeployment warns the ports and directories which are also used
+// by other clusters, they may corrupt each other. The meta of the other
+// clusters is only read, it's not rewritten on read anymore.
+func warnDuplicateDeployment(opt *displayOption) error {
+	conflicts, err := operator.FindDuplicateDeployment(opt.clusterName, opt.metadata.Topology)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	log.Warnf("Found %d ports or directories used by other clusters, they may corrupt each other:", len(conflicts))
+	for _, c := range conflicts {
+		log.Warnf("\t%s", c)
+	}
+	return nil
+}
+
+// maxChangefeedLag is the replication lag considered too high
+const maxChangefeedLag = time.Minute
+
+// displayCDCChangefeeds prints the state and replication lag of changefeeds
+// if TiCDC is deployed in the cluster, the failure to query the changefeeds
+// is only warned as it's not the main part of display
+func displayCDCChangefeeds(opt *displayOption) error {
+	metadata := opt.metadata
+
+	var addrs []string
+	for _, s := range metadata.Topology.CDCServers {
+		addrs = append(addrs, fmt.Sprintf("%s:%d", s.Host, s.Port))
+	}
+	if len(addrs) == 0 {
+		return nil
+	}
+
+	tlsCfg, err := opt.tlsConfig()
+	if err != nil {
+		return err
+	}
+	changefeeds, err := api.NewCDCClient(addrs, 10*time.Second, tlsCfg).GetChangefeeds()
+	if err != nil {
+		log.Warnf("Failed to get changefeeds of TiCDC: %s", err)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println("TiCDC Changefeeds:")
+	if len(changefeeds) == 0 {
+		fmt.Println("  no changefeed")
+		return nil
+	}
+	cfTable := [][]string{
+		// Header
+		{"ID", "State", "Lag", "Error"},
+	}
+	for _, cf := range changefeeds {
+		state := cf.State
+		switch strings.ToLower(state) {
+		case "normal":
+			state = color.GreenString(state)
+		case "stopped", "finished":
+			state = color.YellowString(state)
+		default:
+			state = color.RedString(state)
+		}
+
+		lag := cf.Lag().Truncate(time.Second)
+		lagStr := lag.String()
+		if lag > maxChangefeedLag {
+			lagStr = color.RedString(lagStr)
+		}
+
+		errMsg := ""
+		if cf.Error != nil {
+			errMsg = color.RedString("%s: %s", cf.Error.Code, cf.Error.Message)
+		}
+		cfTable = append(cfTable, []string{cf.ID, state, lagStr, errMsg})
+	}
+
+	cliutil.PrintTable(cfTable, true)
+	return nil
+}
+
+// displayPlacementRules prints the placement rules fetched from PD
+func displayPlacementRules(opt *displayOption) error {
+	metadata := opt.metadata
+
+	tlsCfg, err := opt.tlsConfig()
+	if err != nil {
+		return err
+	}
+	pdClient := api.NewPDClient(metadata.Topology.GetPDList(), 10*time.Second, tlsCfg)
+	rules, err := pdClient.GetPlacementRules()
+	if err != nil {
+		return errors.Annotate(err, "failed to get placement rules, please make sure placement rules are enabled")
+	}
+
+	fmt.Println()
+	fmt.Println("Placement Rules:")
+	ruleTable := [][]string{
+		// Header
+		{"Group", "ID", "Index", "Key Range", "Role", "Count", "Label Constraints", "Location Labels"},
+	}
+	for _, rule := range rules {
+		constraints := make([]string, 0, len(rule.LabelConstraints))
+		for _, c := range rule.LabelConstraints {
+			constraints = append(constraints, fmt.Sprintf("%s %s %s", c.Key, c.Op, strings.Join(c.Values, ",")))
+		}
+		ruleTable = append(ruleTable, []string{
+			rule.GroupID,
+			rule.ID,
+			fmt.Sprint(rule.Index),
+			formatKeyRange(rule.StartKeyHex, rule.EndKeyHex),
+			rule.Role,
+			fmt.Sprint(rule.Count),
+			strings.Join(constraints, "; "),
+			strings.Join(rule.LocationLabels, ","),
+		})
+	}
+
+	cliutil.PrintTable(ruleTable, true)
+	return nil
+}
+
+// displaySSHLatency prints the SSH round-trip latency of each host, the
+// hosts with unusually high latency are highlighted
+func displaySSHLatency(opt *displayOption) error {
+	metadata := opt.metadata
+
+	ctx, err := newDisplayContext(opt.clusterName, metadata, sshTimeout)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println("SSH Latency:")
+	latencyTable := [][]string{
+		// Header
+		{"Host", "Latency", "Note"},
+	}
+	for _, r := range operator.Ping(ctx, metadata.Topology) {
+		switch {
+		case r.Err != nil:
+			latencyTable = append(latencyTable, []string{r.Host, "-", color.RedString(r.Err.Error())})
+		case r.Slow:
+			latencyTable = append(latencyTable, []string{r.Host, color.YellowString(r.Latency.String()), color.YellowString("slow")})
+		default:
+			latencyTable = append(latencyTable, []string{r.Host, r.Latency.String(), ""})
+		}
+	}
+
+	cliutil.PrintTable(latencyTable, true)
+	return nil
+}
+
+// verifyClusterID prints the cluster ID reported by each PD and TiKV
+// instance, and fails loudly if any of them belongs to another cluster
+func verifyClusterID(opt *displayOption) error {
+	metadata := opt.metadata
+
+	tlsCfg, err := opt.tlsConfig()
+	if err != nil {
+		return err
+	}
+	expected, results, verifyErr := operator.VerifyClusterID(metadata.Topology, tlsCfg)
+	if results == nil {
+		return errors.Annotate(verifyErr, "failed to get the cluster ID")
+	}
+
+	fmt.Println()
+	fmt.Printf("Cluster ID: %d\n", expected)
+	idTable := [][]string{
+		// Header
+		{"ID", "Role", "Cluster ID"},
+	}
+	for _, r := range results {
+		clusterID := fmt.Sprint(r.ClusterID)
+		switch {
+		case r.Err != nil:
+			log.Debugf("Failed to get cluster ID of %s: %s", r.ID, r.Err)
+			clusterID = color.YellowString("unknown")
+		case r.Mismatch:
+			clusterID = color.New(color.FgRed, color.Bold).Sprintf("%d (MISMATCH)", r.ClusterID)
+		}
+		idTable = append(idTable, []string{color.CyanString(r.ID), r.Role, clusterID})
+	}
+	cliutil.PrintTable(idTable, true)
+
+	if verifyErr != nil {
+		fmt.Println(color.New(color.FgRed, color.Bold).Sprint(
+			"ERROR: some instances belong to a different cluster, please check the PD endpoints of them"))
+		return verifyErr
+	}
+	return nil
+}
+
+// displayPDConnections prints the number of client connections of each PD
+// instance and the roles of the clients
+func displayPDConnections(opt *displayOption) error {
+	metadata := opt.metadata
+
+	ctx, err := newDisplayContext(opt.clusterName, metadata, sshTimeout)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println("PD Connections:")
+	connTable := [][]string{
+		// Header
+		{"ID", "Total", "Clients"},
+	}
+	for _, conns := range operator.GetPDConnections(ctx, metadata.Topology) {
+		if conns.Err != nil {
+			connTable = append(connTable, []string{color.CyanString(conns.ID), "-", color.RedString(conns.Err.Error())})
+			continue
+		}
+		roles := make([]string, 0, len(conns.ByRole))
+		for role := range conns.ByRole {
+			roles = append(roles, role)
+		}
+		sort.Strings(roles)
+		clients := make([]string, 0, len(roles))
+		for _, role := range roles {
+			clients = append(clients, fmt.Sprintf("%s: %d", role, conns.ByRole[role]))
+		}
+		connTable = append(connTable, []string{
+			color.CyanString(conns.ID),
+			fmt.Sprint(conns.Total),
+			strings.Join(clients, ", "),
+		})
+	}
+
+	cliutil.PrintTable(connTable, true)
+	return nil
+}
+
+// displayDDL prints the schema version synced by each TiDB server, servers
+// lagging behind the global schema version may be stuck, and the count of
+// pending DDL jobs
+func displayDDL(opt *displayOption) error {
+	metadata := opt.metadata
+	topo := metadata.Topology
+	if len(topo.TiDBServers) == 0 {
+		return nil
+	}
+
+	tlsCfg, err := opt.tlsConfig()
+	if err != nil {
+		return err
+	}
+	ddlClient, err := api.NewDDLClient(topo.GetPDList(), tlsCfg)
+	if err != nil {
+		return errors.Annotate(err, "failed to connect to PD")
+	}
+	defer ddlClient.Close()
+
+	global, err := ddlClient.GlobalSchemaVersion()
+	if err != nil {
+		return errors.Annotate(err, "failed to get the global schema version")
+	}
+	versions, err := ddlClient.SchemaVersions()
+	if err != nil {
+		return errors.Annotate(err, "failed to get schema versions of TiDB servers")
+	}
+
+	fmt.Println()
+	fmt.Printf("Schema Version: %d\n", global)
+	ddlTable := [][]string{
+		// Header
+		{"ID", "Schema Version"},
+	}
+	for _, spec := range topo.TiDBServers {
+		id := fmt.Sprintf("%s:%d", spec.Host, spec.Port)
+		version := "-"
+		tidbClient := api.NewTiDBClient([]string{fmt.Sprintf("%s:%d", spec.Host, spec.StatusPort)}, 5*time.Second, tlsCfg)
+		if info, err := tidbClient.GetInfo(); err != nil {
+			log.Debugf("Failed to get info of %s: %s", id, err)
+		} else if ver, ok := versions[info.DDLID]; ok {
+			version = fmt.Sprint(ver)
+			if ver < global {
+				version = color.RedString("%d (lagging)", ver)
+			}
+		}
+		ddlTable = append(ddlTable, []string{color.CyanString(id), version})
+	}
+	cliutil.PrintTable(ddlTable, true)
+
+	pending, err := pendingDDLJobs(topo, opt.dbUser, os.Getenv(envNameDBPassword))
+	if err != nil {
+		log.Warnf("Failed to count pending DDL jobs: %s", err)
+		return nil
+	}
+	fmt.Printf("Pending DDL Jobs: %d\n", pending)
+	return nil
+}
+
+// envNameDBPassword is the env var of the password of the TiDB user to query
+// the DDL jobs as, see --db-user
+const envNameDBPassword = "TIUP_CLUSTER_DB_PASSWORD"
+
+// pendingDDLJobs counts the DDL jobs not finished yet through the first
+// reachable TiDB server, as the user with the password
+func pendingDDLJobs(topo *meta.ClusterSpecification, user, password string) (int, error) {
+	var lastErr error
+	for _, spec := range topo.TiDBServers {
+		db, err := createDB(spec, user, password)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM information_schema.ddl_jobs " +
+			"WHERE state NOT IN ('synced', 'cancelled', 'rollback done')").Scan(&count)
+		db.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return count, nil
+	}
+	return 0, errors.AddStack(lastErr)
+}
+
+// displayPDOperators prints the count of pending PD operators by type
+func displayPDOperators(opt *displayOption) error {
+	metadata := opt.metadata
+
+	tlsCfg, err := opt.tlsConfig()
+	if err != nil {
+		return err
+	}
+	pdClient := api.NewPDClient(metadata.Topology.GetPDList(), 10*time.Second, tlsCfg)
+	operators, err := pdClient.GetOperators()
+	if err != nil {
+		return errors.Annotate(err, "failed to get PD operators")
+	}
+
+	counts := map[string]int{}
+	for _, op := range operators {
+		// the description of operator is the first word, e.g. balance-region
+		counts[strings.Fields(op + " -")[0]]++
+	}
+	types := make([]string, 0, len(counts))
+	for typ := range counts {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+
+	fmt.Println()
+	fmt.Printf("PD Operators: %d pending\n", len(operators))
+	if len(operators) == 0 {
+		return nil
+	}
+	opTable := [][]string{
+		// Header
+		{"Type", "Count"},
+	}
+	for _, typ := range types {
+		opTable = append(opTable, []string{typ, fmt.Sprint(counts[typ])})
+	}
+	cliutil.PrintTable(opTable, true)
+	return nil
+}
+
+// displayPDSchedule prints the active scheduling config of PD, which may be
+// changed by pd-schedule for off-peak rebalancing
+func displayPDSchedule(opt *displayOption) error {
+	metadata := opt.metadata
+
+	tlsCfg, err := opt.tlsConfig()
+	if err != nil {
+		return err
+	}
+	pdClient := api.NewPDClient(metadata.Topology.GetPDList(), 10*time.Second, tlsCfg)
+	config, err := pdClient.GetScheduleConfig()
+	if err != nil {
+		return errors.Annotate(err, "failed to get schedule config of PD")
+	}
+
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Println()
+	fmt.Println("PD Schedule Config:")
+	scheduleTable := [][]string{
+		// Header
+		{"Key", "Value"},
+	}
+	for _, key := range keys {
+		value := fmt.Sprint(config[key])
+		if f, ok := config[key].(float64); ok {
+			// avoid the exponent format of large numbers
+			value = strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		scheduleTable = append(scheduleTable, []string{key, value})
+	}
+	cliutil.PrintTable(scheduleTable, true)
+	return nil
+}
+
+// formatKeyRange formats the hex encoded key range of a placement rule, an
+// empty key means unbounded
+func formatKeyRange(start, end string) string {
+	if start == "" {
+		start = "-inf"
+	}
+	if end == "" {
+		end = "+inf"
+	}
+	return fmt.Sprintf("[%s, %s)", start, end)
+}
+
+// instancesUsage reads the memory and CPU usage of the instances, the reads are
+// batched per host and the hosts are read concurrently. The result is keyed
+// by the instance ID.
+func instancesUsage(ctx *task.Context, instances []meta.Instance) map[string]operator.ServiceUsage {
+	byHost := map[string][]meta.Instance{}
+	for _, ins := range instances {
+		byHost[ins.GetHost()] = append(byHost[ins.GetHost()], ins)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	result := make(map[string]operator.ServiceUsage)
+	for host, insts := range byHost {
+		e, found := ctx.GetExecutor(host)
+		if !found {
+			continue
+		}
+		wg.Add(1)
+		go func(host string, insts []meta.Instance) {
+			defer wg.Done()
+			services := make([]string, 0, len(insts))
+			for _, ins := range insts {
+				services = append(services, ins.ServiceName())
+			}
+			usages, err := operator.GetServicesUsage(e, services)
+			if err != nil {
+				log.Debugf("Failed to get usage of instances on %s: %s", host, err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, ins := range insts {
+				if usage, ok := usages[ins.ServiceName()]; ok {
+					result[ins.ID()] = usage
+				}
+			}
+		}(host, insts)
+	}
+	wg.Wait()
+	return result
+}
+
+// colorUsage colors the usage by its ratio to the limit, the limit is not
+// set if it's not greater than 0
+func colorUsage(s string, used, limit float64) string {
+	switch {
+	case limit <= 0:
+		return s
+	case used >= limit*0.9:
+		return color.RedString(s)
+	case used >= limit*0.75:
+		return color.YellowString(s)
+	default:
+		return s
+	}
+}
+
+func formatMemoryUsage(usage operator.ServiceUsage, ok bool, limit string) string {
+	if !ok || !usage.HasMemory {
+		return "-"
+	}
+	s := formatBytes(usage.Memory)
+	limitBytes := parseMemoryLimit(limit)
+	if limitBytes > 0 {
+		s += "/" + formatBytes(limitBytes)
+	}
+	return colorUsage(s, float64(usage.Memory), float64(limitBytes))
+}
+
+func formatCPUUsage(usage operator.ServiceUsage, ok bool, quota string) string {
+	if !ok || !usage.HasCPU {
+		return "-"
+	}
+	s := fmt.Sprintf("%.0f%%", usage.CPU)
+	limit, err := strconv.ParseFloat(strings.TrimSuffix(quota, "%"), 64)
+	if err != nil || !strings.HasSuffix(quota, "%") {
+		limit = 0
+	}
+	if limit > 0 {
+		s += "/" + quota
+	}
+	return colorUsage(s, usage.CPU, limit)
+}
+
+// parseMemoryLimit parses the MemoryLimit of systemd in bytes, the suffixes
+// K, M, G and T are base 1024. 0 is returned for percentage, infinity and
+// invalid values.
+func parseMemoryLimit(limit string) uint64 {
+	limit = strings.TrimSpace(limit)
+	if limit == "" {
+		return 0
+	}
+	unit := uint64(1)
+	switch limit[len(limit)-1] {
+	case 'K', 'k':
+		unit = 1 << 10
+	case 'M', 'm':
+		unit = 1 << 20
+	case 'G', 'g':
+		unit = 1 << 30
+	case 'T', 't':
+		unit = 1 << 40
+	}
+	if unit > 1 {
+		limit = limit[:len(limit)-1]
+	}
+	n, err := strconv.ParseFloat(limit, 64)
+	if err != nil || n <= 0 {
+		return 0
+	}
+	return uint64(n * float64(unit))
+}
+
+// formatBytes formats the bytes in the base 1024 units
+func formatBytes(b uint64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	v := float64(b)
+	i := 0
+	for v >= 1024 && i < len(units)-1 {
+		v /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%d%s", b, units[0])
+	}
+	return fmt.Sprintf("%.1f%s", v, units[i])
+}
+
+// maxExecStartWidth is the max width of the ExecStart column
+const maxExecStartWidth = 60
+
+// instancesExecStart queries the full ExecStart of the instances with at most
+// concurrency workers, "-" is returned for the instances failed to query
+func instancesExecStart(ctx *task.Context, instances []meta.Instance, concurrency int) []string {
+	execStarts := make([]string, len(instances))
+	parallelDo(len(instances), concurrency, func(i int) {
+		ins := instances[i]
+		execStarts[i] = "-"
+		e, found := ctx.GetExecutor(ins.GetHost())
+		if !found {
+			return
+		}
+		execStart, err := operator.GetServiceExecStart(e, ins.ServiceName())
+		if err != nil {
+			log.Debugf("Failed to get ExecStart of %s: %s", ins.ID(), err)
+			return
+		}
+		execStarts[i] = execStart
+	})
+	return execStarts
+}
+
+// truncateExecStart truncates the ExecStart to the width of the column
+func truncateExecStart(execStart string) string {
+	if len(execStart) > maxExecStartWidth {
+		return execStart[:maxExecStartWidth-3] + "..."
+	}
+	return execStart
+}
+
+// resolveHost resolves the hostname to its IP, or the IP to its hostname, and
+// returns both of them, the original value is returned if it can't be resolved.
+func resolveHost(host string) string {
+	if net.ParseIP(host) != nil {
+		names, err := net.LookupAddr(host)
+		if err != nil || len(names) == 0 {
+			return host
+		}
+		return fmt.Sprintf("%s (%s)", strings.TrimSuffix(names[0], "."), host)
+	}
+
+	addrs, err := net.LookupHost(host)
+	if err != nil || len(addrs) == 0 {
+		return host
+	}
+	return fmt.Sprintf("%s (%s)", host, addrs[0])
+}
+
+// statusCategoryInfo describes a category of the instance status
+type statusCategoryInfo struct {
+	name     string
+	color    func(format string, a ...interface{}) string
+	desc     string
+	statuses []string // the built-in status of the category, in lower case
+}
+
+// statusRegistry is the registry of status categories, the display and the
+// legend are both generated from it
+var statusRegistry = []statusCategoryInfo{
+	{"up", color.GreenString, "the instance is serving", []string{"up", "healthy"}},
+	{"leader", color.HiGreenString, "the instance is the PD leader (|L), TiDB DDL owner or TiCDC owner (|Owner)", []string{"healthy|l", "up|owner"}},
+	{"warning", color.YellowString, "the instance is going offline, removed, paused, not connected, changing its state or the status query timed out", []string{"offline", "tombstone", "disconnected", "unknown", "starting", "restarting", "stopping", "pausing", "paused"}},
+	{"down", color.RedString, "the instance is not serving, failed or the status can't be queried", []string{"down", "unhealthy", "err", "failed", "not installed"}},
+}
+
+// statusCategory normalizes the status reported by the component to one of
+// up, leader, warning and down, the user defined mappings of the component
+// take precedence over the built-in ones. An empty string is returned for
+// unknown status.
+func statusCategory(component, status string, mapping meta.StatusMapping) string {
+	if c, ok := mapping.Category(component, status); ok {
+		return c
+	}
+
+	lower := strings.ToLower(status)
+	for _, c := range statusRegistry {
+		for _, s := range c.statuses {
+			if s == lower {
+				return c.name
+			}
+		}
+	}
+	return ""
+}
+
+// formatInstanceStatus colors the status by its category
+func formatInstanceStatus(component, status string, mapping meta.StatusMapping) string {
+	category := statusCategory(component, status, mapping)
+	for _, c := range statusRegistry {
+		if c.name == category {
+			return c.color(status)
+		}
+	}
+	return status
+}
+
+// printStatusLegend explains the colors of status categories, the user
+// defined mappings are listed along with the built-in status
+func printStatusLegend(mapping meta.StatusMapping) {
+	fmt.Println()
+	fmt.Println("Legend:")
+	for _, c := range statusRegistry {
+		statuses := append([]string{}, c.statuses...)
+		for comp, m := range mapping {
+			for s, category := range m {
+				if category == c.name {
+					statuses = append(statuses, comp+":"+s)
+				}
+			}
+		}
+		sort.Strings(statuses)
+		// pad before coloring, the color codes break the width
+		fmt.Printf("  %s %s (%s)\n", c.color("%-8s", c.name), c.desc, strings.Join(statuses, ", "))
 	}
+	fmt.Println("  other    unknown status, not colored")
 }
@@ -0,0 +1,79 @@
+package command
+
+import (
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap/check"
+	"gopkg.in/yaml.v2"
+)
+
+type displaySuite struct{}
+
+var _ = check.Suite(&displaySuite{})
+
+func (s *displaySuite) TestStatusCategory(c *check.C) {
+	c.Assert(statusCategory(meta.ComponentPD, "Healthy", nil), check.Equals, "up")
+	c.Assert(statusCategory(meta.ComponentPD, "Healthy|L", nil), check.Equals, "leader")
+	c.Assert(statusCategory(meta.ComponentPD, "Unhealthy", nil), check.Equals, "down")
+	c.Assert(statusCategory(meta.ComponentTiDB, "Up", nil), check.Equals, "up")
+	c.Assert(statusCategory(meta.ComponentTiKV, "Tombstone", nil), check.Equals, "warning")
+	c.Assert(statusCategory(meta.ComponentTiKV, "Unknown", nil), check.Equals, "warning")
+	c.Assert(statusCategory(meta.ComponentTiKV, "Up (exited)", nil), check.Equals, "")
+
+	// the mapping only applies to its component
+	mapping := meta.StatusMapping{meta.ComponentTiKV: {"pending offline": "warning", "up": "down"}}
+	c.Assert(statusCategory(meta.ComponentTiKV, "Pending Offline", mapping), check.Equals, "warning")
+	c.Assert(statusCategory(meta.ComponentTiKV, "Up", mapping), check.Equals, "down")
+	c.Assert(statusCategory(meta.ComponentTiDB, "Up", mapping), check.Equals, "up")
+	c.Assert(statusCategory(meta.ComponentTiDB, "Pending Offline", mapping), check.Equals, "")
+}
+
+func (s *displaySuite) TestClusterURLs(c *check.C) {
+	topo := meta.TopologySpecification{}
+	err := yaml.Unmarshal([]byte(`
+pd_servers:
+  - host: 172.16.5.138
+  - host: 172.16.5.139
+grafana_servers:
+  - host: 172.16.5.140
+monitoring_servers:
+  - host: 172.16.5.140
+`), &topo)
+	c.Assert(err, check.IsNil)
+
+	var instances []meta.Instance
+	statuses := map[string]string{
+		"172.16.5.138:2379": "Unhealthy",
+		"172.16.5.139:2379": "Healthy|L",
+		"172.16.5.140:3000": "Up",
+		"172.16.5.140:9090": "Down",
+	}
+	var instanceStatuses []string
+	for _, comp := range topo.ComponentsByStartOrder() {
+		for _, ins := range comp.Instances() {
+			instances = append(instances, ins)
+			instanceStatuses = append(instanceStatuses, statuses[ins.ID()])
+		}
+	}
+
+	urls := clusterURLs(&topo, instances, instanceStatuses, nil)
+	c.Assert(urls, check.DeepEquals, [][2]string{
+		{"Dashboard", "http://172.16.5.139:2379/dashboard"},
+		{"Grafana", "http://172.16.5.140:3000"},
+	})
+
+	// no URL is shown for the instances which are not up
+	for i := range instanceStatuses {
+		instanceStatuses[i] = "Down"
+	}
+	c.Assert(clusterURLs(&topo, instances, instanceStatuses, nil), check.HasLen, 0)
+}
+
+func (s *displaySuite) TestTableOnlyFlags(c *check.C) {
+	for _, flag := range []string{"--ddl", "--destroy-tombstone", "--output-dir=/tmp"} {
+		cmd := newDisplayCmd()
+		cmd.SetArgs([]string{"test", "--format=json", flag})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		c.Assert(cmd.Execute(), check.ErrorMatches, ".* only supports the table format")
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+)
+
+const (
+	// move the cursor home and clear the screen
+	ansiClearScreen = "\033[H\033[2J"
+	// reset the attributes in case of being interrupted in a colored text
+	ansiReset = "\033[0m"
+)
+
+// watchClusterTopology displays the cluster topology every interval until
+// interrupted, each refresh replaces the previous one on the screen
+func watchClusterTopology(opt *displayOption) error {
+	// take over the interrupt from the root command, which would exit with an
+	// error code, as it's the normal way to end watching
+	signal.Reset(os.Interrupt, syscall.SIGTERM)
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	ticker := time.NewTicker(opt.watch)
+	defer ticker.Stop()
+
+	for {
+		// refresh in background so that the interrupt is handled at once
+		// even if the status queries are slow, the output in progress is
+		// cut off by the reset
+		done := make(chan error, 1)
+		go func() {
+			done <- refreshClusterTopology(opt)
+		}()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				fmt.Println(color.RedString("Error: %s", err))
+			}
+		case <-sigCh:
+			fmt.Println(ansiReset)
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-sigCh:
+			fmt.Println(ansiReset)
+			return nil
+		}
+	}
+}
+
+// refreshClusterTopology clears the screen and displays the cluster meta and
+// topology with the time of the refresh, the meta is reloaded every time as
+// the cluster may be changed while watching
+func refreshClusterTopology(opt *displayOption) error {
+	fmt.Print(ansiClearScreen)
+	fmt.Printf("Every %s: %s display %s    %s\n\n", opt.watch, cliutil.OsArgs0(), opt.clusterName, time.Now().Format(time.RFC1123))
+	metadata, err := meta.ClusterMetadata(opt.clusterName)
+	if err != nil {
+		return err
+	}
+	opt.metadata = metadata
+	if err := displayClusterMeta(opt); err != nil {
+		return err
+	}
+	return displayClusterTopology(opt)
+}
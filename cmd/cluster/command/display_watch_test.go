@@ -0,0 +1,58 @@
+package command
+
+import (
+	"os"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
+	tiuplocaldata "github.com/pingcap-incubator/tiup/pkg/localdata"
+	"github.com/pingcap/check"
+	"gopkg.in/yaml.v2"
+)
+
+type displayWatchSuite struct{}
+
+var _ = check.Suite(&displayWatchSuite{})
+
+func (s *displayWatchSuite) SetUpSuite(c *check.C) {
+	os.Setenv(tiuplocaldata.EnvNameComponentDataDir, c.MkDir())
+	os.Setenv(task.EnvNameSSHPassword, "password")
+	c.Assert(meta.Initialize("cluster"), check.IsNil)
+}
+
+func (s *displayWatchSuite) TearDownSuite(c *check.C) {
+	os.Unsetenv(tiuplocaldata.EnvNameComponentDataDir)
+	os.Unsetenv(task.EnvNameSSHPassword)
+}
+
+func (s *displayWatchSuite) TestRefreshClusterTopology(c *check.C) {
+	opt := &displayOption{
+		clusterName:   "test-watch",
+		watch:         time.Second,
+		format:        displayFormatTable,
+		concurrency:   1,
+		statusTimeout: time.Second,
+	}
+	c.Assert(refreshClusterTopology(opt), check.NotNil)
+
+	// nothing listens on the ports, the statuses are queried and fail fast
+	topo := meta.TopologySpecification{}
+	c.Assert(yaml.Unmarshal([]byte(`
+pd_servers:
+  - host: 127.0.0.1
+    ssh_port: 1
+    client_port: 1
+    peer_port: 2
+`), &topo), check.IsNil)
+	cm := &meta.ClusterMeta{User: "tidb", Version: "v4.0.0", Topology: &topo}
+	c.Assert(meta.SaveClusterMeta(opt.clusterName, cm), check.IsNil)
+	c.Assert(refreshClusterTopology(opt), check.IsNil)
+	c.Assert(opt.metadata.Version, check.Equals, "v4.0.0")
+
+	// the meta is reloaded on each refresh
+	cm.Version = "v4.0.1"
+	c.Assert(meta.SaveClusterMeta(opt.clusterName, cm), check.IsNil)
+	c.Assert(refreshClusterTopology(opt), check.IsNil)
+	c.Assert(opt.metadata.Version, check.Equals, "v4.0.1")
+}
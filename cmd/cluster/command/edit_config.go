@@ -20,11 +20,14 @@ import (
 	"os"
 
 	"github.com/fatih/color"
+	"github.com/joomcode/errorx"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/edit"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
 	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
 	"github.com/pingcap/errors"
 	"github.com/spf13/cobra"
@@ -32,9 +35,19 @@ import (
 )
 
 func newEditConfigCmd() *cobra.Command {
+	var (
+		role    string
+		node    string
+		options operator.Options
+	)
+
 	cmd := &cobra.Command{
 		Use:   "edit-config <cluster-name>",
 		Short: "Edit TiDB cluster config",
+		Long: `Edit the topology of the cluster in $EDITOR. With --role or --node, only the
+config of the role in server_configs or the effective config of the instance
+is edited, and the change is pushed to the instances and reloaded after
+confirmation.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 1 {
 				return cmd.Help()
@@ -42,7 +55,7 @@ func newEditConfigCmd() *cobra.Command {
 
 			clusterName := args[0]
 			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
-				return errors.Errorf("cannot start non-exists cluster %s", clusterName)
+				return errClusterNotFound.New("cannot start non-exists cluster %s", clusterName)
 			}
 
 			logger.EnableAuditLog()
@@ -51,51 +64,186 @@ func newEditConfigCmd() *cobra.Command {
 				return err
 			}
 
-			return editTopo(clusterName, metadata)
+			if role != "" && node != "" {
+				return errors.New("--role and --node can't be specified at the same time")
+			}
+			var nodes []string
+			if role == "" && node == "" {
+				err = editTopo(clusterName, metadata)
+			} else {
+				if node != "" {
+					nodes = []string{node}
+				}
+				err = editComponentConfig(clusterName, metadata, role, node, options)
+			}
+			auditOperation(clusterName, "edit-config", nodes, err)
+			return err
 		},
 	}
 
+	cmd.Flags().StringVarP(&role, "role", "R", "", "Only edit the config of the role in server_configs")
+	cmd.Flags().StringVarP(&node, "node", "N", "", "Only edit the effective config of the node")
+	cmd.Flags().Int64Var(&options.Timeout, "transfer-timeout", 300, "Timeout in seconds when transferring PD and TiKV store leaders")
+
 	return cmd
 }
 
-// 1. Write Topology to a temporary file.
-// 2. Open file in editro.
-// 3. Check and update Topology.
-// 4. Save meta file.
-func editTopo(clusterName string, metadata *meta.ClusterMeta) error {
-	data, err := yaml.Marshal(metadata.Topology)
-	if err != nil {
-		return errors.AddStack(err)
-	}
-
+// editInEditor opens the data in the editor and returns the edited one
+func editInEditor(data []byte) ([]byte, error) {
 	file, err := ioutil.TempFile(os.TempDir(), "*")
 	if err != nil {
-		return errors.AddStack(err)
+		return nil, errors.AddStack(err)
 	}
 
 	name := file.Name()
+	defer os.Remove(name)
 
 	_, err = io.Copy(file, bytes.NewReader(data))
 	if err != nil {
-		return errors.AddStack(err)
+		return nil, errors.AddStack(err)
 	}
 
 	err = file.Close()
 	if err != nil {
-		return errors.AddStack(err)
+		return nil, errors.AddStack(err)
 	}
 
 	err = edit.OpenFileInEditor(name)
 	if err != nil {
-		return errors.AddStack(err)
+		return nil, errors.AddStack(err)
 	}
 
 	// Now user finish editing the file.
 	newData, err := ioutil.ReadFile(name)
 	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return newData, nil
+}
+
+// editComponentConfig edits the config of the role or the node, then pushes
+// it to the affected instances and reloads them after confirmation
+func editComponentConfig(clusterName string, metadata *meta.ClusterMeta, role, node string, options operator.Options) error {
+	topo := metadata.Topology
+
+	var (
+		instances []meta.Instance
+		config    map[string]interface{}
+		err       error
+	)
+	if role != "" {
+		if err := validRoles([]string{role}); err != nil {
+			return err
+		}
+		instances = filterInstances(metadata, &displayOption{filterRole: []string{role}})
+	} else {
+		instances = filterInstances(metadata, &displayOption{filterNode: []string{node}})
+	}
+	if len(instances) == 0 {
+		return errors.Errorf("no instance of cluster %s matches %s%s", clusterName, role, node)
+	}
+	if role != "" {
+		config, err = topo.GlobalConfig(role)
+	} else {
+		config, err = topo.InstanceConfig(instances[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	newData, err := editInEditor(data)
+	if err != nil {
+		return err
+	}
+
+	newConfig := map[string]interface{}{}
+	if err := yaml.UnmarshalStrict(newData, &newConfig); err != nil {
+		log.Infof("Failed to parse the config: %v", err)
 		return errors.AddStack(err)
 	}
 
+	if bytes.Equal(data, newData) {
+		log.Infof("The file has nothing changed")
+		return nil
+	}
+
+	edit.ShowDiff(string(data), string(newData), os.Stdout)
+
+	if !skipConfirm {
+		if err := cliutil.PromptForConfirmOrAbortError(
+			color.HiYellowString("Please check change highlight above, do you want to apply the change? [y/N]:"),
+		); err != nil {
+			return err
+		}
+	}
+
+	if role != "" {
+		err = topo.SetGlobalConfig(role, newConfig)
+	} else {
+		err = topo.SetEffectiveInstanceConfig(instances[0], newConfig)
+	}
+	if err != nil {
+		return err
+	}
+
+	// the config is rendered and checked by the components when pushing,
+	// the meta is saved only if all of them accept it
+	log.Infof("Push the config to the instances...")
+	t := task.NewBuilder().
+		SSHKeySet(
+			meta.ClusterPath(clusterName, "ssh", "id_rsa"),
+			meta.ClusterPath(clusterName, "ssh", "id_rsa.pub")).
+		ClusterSSH(topo, metadata.User, sshTimeout).
+		Parallel(buildInstancesRefreshConfigTasks(clusterName, metadata, instances)...).
+		Build()
+	if err := t.Execute(task.NewContext()); err != nil {
+		if errorx.Cast(err) != nil {
+			return err
+		}
+		return errors.Trace(err)
+	}
+
+	if err := meta.SaveClusterMeta(clusterName, metadata); err != nil {
+		return errors.Annotate(err, "failed to save")
+	}
+
+	if !skipConfirm {
+		if err := cliutil.PromptForConfirmOrAbortError(
+			"The config is pushed, do you want to reload or restart the instances to apply it now? [y/N]:",
+		); err != nil {
+			log.Infof("Please use `%s reload %s [-N <nodes>] [-R <roles>]` to apply the config later.", cliutil.OsArgs0(), clusterName)
+			return nil
+		}
+	}
+
+	options.Nodes = instanceIDs(instances)
+	if err := reloadInstances(clusterName, metadata, options, instances, false, false); err != nil {
+		return err
+	}
+	log.Infof("Applied the config of cluster `%s` successfully", clusterName)
+	return nil
+}
+
+// 1. Write Topology to a temporary file.
+// 2. Open file in editro.
+// 3. Check and update Topology.
+// 4. Save meta file.
+func editTopo(clusterName string, metadata *meta.ClusterMeta) error {
+	data, err := yaml.Marshal(metadata.Topology)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	newData, err := editInEditor(data)
+	if err != nil {
+		return err
+	}
+
 	newTopo := new(meta.TopologySpecification)
 	err = yaml.UnmarshalStrict(newData, newTopo)
 	if err != nil {
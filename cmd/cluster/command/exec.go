@@ -45,7 +45,7 @@ func newExecCmd() *cobra.Command {
 
 			clusterName := args[0]
 			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
-				return errors.Errorf("cannot execute command on non-exists cluster %s", clusterName)
+				return errClusterNotFound.New("cannot execute command on non-exists cluster %s", clusterName)
 			}
 
 			logger.EnableAuditLog()
@@ -0,0 +1,144 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+)
+
+// prometheusTargetGroup is a target group in the file_sd format of Prometheus
+type prometheusTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// metricsPortIndexes are the indexes in UsedPorts() of the ports serving
+// metrics of each component, keyed by the role label of the targets. They
+// are the same as what the bundled Prometheus scrapes.
+var metricsPortIndexes = []struct {
+	component string
+	role      string
+	index     int
+}{
+	{meta.ComponentPD, "pd", 0},                     // client_port
+	{meta.ComponentTiKV, "tikv", 1},                 // status_port
+	{meta.ComponentTiDB, "tidb", 1},                 // status_port
+	{meta.ComponentTiFlash, "tiflash", 5},           // metrics_port
+	{meta.ComponentTiFlash, "tiflash_learner", 4},   // flash_proxy_status_port
+	{meta.ComponentPump, "pump", 0},                 // port
+	{meta.ComponentDrainer, "drainer", 0},           // port
+	{meta.ComponentCDC, "cdc", 0},                   // port
+	{meta.ComponentPrometheus, "prometheus", 0},     // port
+	{meta.ComponentGrafana, "grafana", 0},           // port
+	{meta.ComponentAlertManager, "alertmanager", 0}, // web_port
+}
+
+func newExportPrometheusTargetsCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "export-prometheus-targets <cluster-name>",
+		Short: "Export the metrics endpoints of a cluster as Prometheus file_sd targets",
+		Long: `Export the metrics endpoints of all instances and the node exporters of a
+cluster in the file_sd format of Prometheus, grouped by role and labeled with
+the cluster name and the role. The file is replaced atomically, so it's safe
+to regenerate it periodically while Prometheus is watching it.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			clusterName := args[0]
+			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot export targets of non-exists cluster %s", clusterName)
+			}
+
+			metadata, err := meta.ClusterMetadata(clusterName)
+			if err != nil {
+				return err
+			}
+
+			data, err := json.MarshalIndent(prometheusTargets(clusterName, metadata.Topology), "", "  ")
+			if err != nil {
+				return errors.AddStack(err)
+			}
+			data = append(data, '\n')
+
+			if output == "" {
+				_, err = os.Stdout.Write(data)
+				return err
+			}
+			// Prometheus reloads the file on change, never let it see a partial one
+			tmp := output + ".tmp"
+			if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+				return errors.AddStack(err)
+			}
+			if err := os.Rename(tmp, output); err != nil {
+				return errors.AddStack(err)
+			}
+			log.Infof("Exported Prometheus targets of cluster %s to %s", clusterName, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the targets to the file instead of stdout")
+
+	return cmd
+}
+
+// prometheusTargets returns the target groups of the metrics endpoints in the
+// topology, the roles without instances are omitted
+func prometheusTargets(clusterName string, topo *meta.ClusterSpecification) []prometheusTargetGroup {
+	var groups []prometheusTargetGroup
+	newGroup := func(role string, targets []string) {
+		if len(targets) == 0 {
+			return
+		}
+		groups = append(groups, prometheusTargetGroup{
+			Targets: targets,
+			Labels:  map[string]string{"cluster": clusterName, "role": role},
+		})
+	}
+
+	for _, m := range metricsPortIndexes {
+		var targets []string
+		topo.IterInstance(func(ins meta.Instance) {
+			if ins.ComponentName() != m.component {
+				return
+			}
+			if ports := ins.UsedPorts(); m.index < len(ports) {
+				targets = append(targets, fmt.Sprintf("%s:%d", ins.GetHost(), ports[m.index]))
+			}
+		})
+		newGroup(m.role, targets)
+	}
+
+	var nodeTargets, blackboxTargets []string
+	topo.IterHost(func(ins meta.Instance) {
+		nodeTargets = append(nodeTargets, fmt.Sprintf("%s:%d", ins.GetHost(), topo.MonitoredOptions.NodeExporterPort))
+		blackboxTargets = append(blackboxTargets, fmt.Sprintf("%s:%d", ins.GetHost(), topo.MonitoredOptions.BlackboxExporterPort))
+	})
+	newGroup(meta.ComponentNodeExporter, nodeTargets)
+	newGroup(meta.ComponentBlackboxExporter, blackboxTargets)
+
+	return groups
+}
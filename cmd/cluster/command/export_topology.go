@@ -0,0 +1,71 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newExportTopologyCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "export-topology <cluster-name>",
+		Short: "Export the topology of a cluster as a topology file",
+		Long: `Export the topology of a cluster in the format of the topology file used by
+deploy, including the deploy user, directories and ports of all instances.
+The instances going offline are excluded.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			clusterName := args[0]
+			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot export topology of non-exists cluster %s", clusterName)
+			}
+
+			metadata, err := meta.ClusterMetadata(clusterName)
+			if err != nil {
+				return err
+			}
+
+			data, err := yaml.Marshal(metadata.Topology.Exported(metadata.User))
+			if err != nil {
+				return errors.AddStack(err)
+			}
+
+			if output == "" {
+				_, err = os.Stdout.Write(data)
+				return err
+			}
+			if err := ioutil.WriteFile(output, data, 0644); err != nil {
+				return errors.AddStack(err)
+			}
+			log.Infof("Exported topology of cluster %s to %s", clusterName, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the topology to the file instead of stdout")
+
+	return cmd
+}
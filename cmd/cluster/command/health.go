@@ -0,0 +1,215 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/api"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/spf13/cobra"
+)
+
+// The results of the health checks, the cluster is unhealthy if any check
+// fails
+const (
+	healthPass = "pass"
+	healthWarn = "warn"
+	healthFail = "fail"
+)
+
+type healthCheck struct {
+	name   string
+	result string
+	detail string
+}
+
+func newHealthCmd() *cobra.Command {
+	var timeout int64
+
+	cmd := &cobra.Command{
+		Use:   "health <cluster-name>",
+		Short: "Check the health of PD members, TiKV stores and regions",
+		Long: `Check the cluster level health from PD: the health of PD members and the
+leader, the state of TiKV stores and the regions which are under-replicated or
+have down or pending peers. It exits with an error if any check fails.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			clusterName := args[0]
+			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot check non-exists cluster %s", clusterName)
+			}
+
+			metadata, err := meta.ClusterMetadata(clusterName)
+			if err != nil {
+				return err
+			}
+
+			pdList := metadata.Topology.GetPDList()
+			if len(pdList) == 0 {
+				return errors.Errorf("no PD instance in cluster %s", clusterName)
+			}
+			var tlsCfg *tls.Config
+			if metadata.Topology.GlobalOptions.EnableTLS {
+				if tlsCfg, err = meta.ClusterTLSConfig(clusterName); err != nil {
+					return err
+				}
+			}
+
+			pdClient := api.NewPDClient(pdList, time.Duration(timeout)*time.Second, tlsCfg)
+			checks := checkClusterHealth(pdClient)
+
+			healthTable := [][]string{
+				// Header
+				{"Check", "Result", "Detail"},
+			}
+			failed := 0
+			for _, c := range checks {
+				if c.result == healthFail {
+					failed++
+				}
+				healthTable = append(healthTable, []string{c.name, formatHealthResult(c.result), c.detail})
+			}
+			cliutil.PrintTable(healthTable, true)
+
+			if failed > 0 {
+				return errClusterUnhealthy.New("cluster %s is unhealthy, %d checks failed", clusterName, failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&timeout, "timeout", 10, "Timeout in seconds of each request to PD")
+
+	return cmd
+}
+
+// checkClusterHealth queries PD for the health of the members, the stores
+// and the regions, the failure of a query fails the corresponding check
+func checkClusterHealth(pdClient *api.PDClient) []healthCheck {
+	var checks []healthCheck
+
+	// PD members
+	check := healthCheck{name: "PD members"}
+	if healths, err := pdClient.GetHealth(); err != nil {
+		check.result, check.detail = healthFail, err.Error()
+	} else {
+		var unhealthy []string
+		for _, h := range healths.Healths {
+			if !h.Health {
+				unhealthy = append(unhealthy, h.Name)
+			}
+		}
+		check.result = healthPass
+		check.detail = fmt.Sprintf("%d/%d healthy", len(healths.Healths)-len(unhealthy), len(healths.Healths))
+		if len(unhealthy) > 0 {
+			check.result = healthFail
+			check.detail += fmt.Sprintf(", unhealthy: %s", strings.Join(unhealthy, ","))
+		}
+	}
+	checks = append(checks, check)
+
+	// PD leader
+	check = healthCheck{name: "PD leader"}
+	if leader, err := pdClient.GetLeader(); err != nil || leader.Name == "" {
+		check.result, check.detail = healthFail, "no leader"
+		if err != nil {
+			check.detail = err.Error()
+		}
+	} else {
+		check.result, check.detail = healthPass, leader.Name
+	}
+	checks = append(checks, check)
+
+	// TiKV stores
+	check = healthCheck{name: "TiKV stores"}
+	if stores, err := pdClient.GetStores(); err != nil {
+		check.result, check.detail = healthFail, err.Error()
+	} else {
+		check.result = healthPass
+		up := 0
+		var abnormal []string
+		for _, s := range stores.Stores {
+			switch s.Store.StateName {
+			case metapb.StoreState_name[int32(metapb.StoreState_Tombstone)]:
+				continue
+			case metapb.StoreState_name[int32(metapb.StoreState_Up)]:
+				up++
+				continue
+			case "Down":
+				check.result = healthFail
+			default:
+				// Disconnected or Offline, which may recover or is being removed
+				if check.result == healthPass {
+					check.result = healthWarn
+				}
+			}
+			abnormal = append(abnormal, fmt.Sprintf("%s(%s)", s.Store.Address, s.Store.StateName))
+		}
+		check.detail = fmt.Sprintf("%d/%d up", up, up+len(abnormal))
+		if len(abnormal) > 0 {
+			check.detail += ", " + strings.Join(abnormal, ",")
+		}
+	}
+	checks = append(checks, check)
+
+	// the regions missing or with down peers may lose data or availability
+	// on another failure, the others are usually transient
+	for _, r := range []struct {
+		state  string
+		name   string
+		result string
+	}{
+		{api.RegionMissPeer, "Under-replicated regions", healthFail},
+		{api.RegionDownPeer, "Regions with down peers", healthFail},
+		{api.RegionOfflinePeer, "Regions with offline peers", healthWarn},
+		{api.RegionPendingPeer, "Regions with pending peers", healthWarn},
+		{api.RegionExtraPeer, "Over-replicated regions", healthWarn},
+	} {
+		check = healthCheck{name: r.name}
+		if count, err := pdClient.GetCheckedRegionCount(r.state); err != nil {
+			check.result, check.detail = healthFail, err.Error()
+		} else {
+			check.result, check.detail = healthPass, fmt.Sprint(count)
+			if count > 0 {
+				check.result = r.result
+			}
+		}
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+func formatHealthResult(result string) string {
+	switch result {
+	case healthPass:
+		return color.GreenString(result)
+	case healthWarn:
+		return color.YellowString(result)
+	default:
+		return color.RedString(result)
+	}
+}
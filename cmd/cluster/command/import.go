@@ -21,6 +21,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/ansible"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil/prepare"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
@@ -62,6 +63,9 @@ func newImportCmd() *cobra.Command {
 			if clsName == "" {
 				return fmt.Errorf("cluster name should not be empty")
 			}
+			if err := utils.ValidateClusterNameOrError(clsName); err != nil {
+				return err
+			}
 			if tiuputils.IsExist(meta.ClusterPath(clsName, meta.MetaFileName)) {
 				return errDeployNameDuplicate.
 					New("Cluster name '%s' is duplicated", clsName).
@@ -101,6 +105,18 @@ func newImportCmd() *cobra.Command {
 				return err
 			}
 
+			// the imported topology must pass the checks of a fresh deploy
+			// before anything is written
+			if err := validateTopology(clsMeta.Topology); err != nil {
+				return err
+			}
+			if err := prepare.CheckClusterPortConflict(clsName, clsMeta.Topology); err != nil {
+				return err
+			}
+			if err := prepare.CheckClusterDirConflict(clsName, clsMeta.Topology); err != nil {
+				return err
+			}
+
 			// copy SSH key to TiOps profile directory
 			if err = utils.CreateDir(meta.ClusterPath(clsName, "ssh")); err != nil {
 				return err
@@ -16,11 +16,13 @@ package command
 import (
 	"io/ioutil"
 	"os"
+	"strconv"
 
+	"github.com/fatih/color"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
-	"github.com/pingcap/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -39,7 +41,7 @@ func listCluster() error {
 	clusterDir := meta.ProfilePath(meta.TiOpsClusterDir)
 	clusterTable := [][]string{
 		// Header
-		{"Name", "User", "Version", "Path", "PrivateKey"},
+		{"Name", "User", "Version", "Instances", "Path", "PrivateKey"},
 	}
 	fileInfos, err := ioutil.ReadDir(clusterDir)
 	if err != nil && !os.IsNotExist(err) {
@@ -50,14 +52,29 @@ func listCluster() error {
 			continue
 		}
 		metadata, err := meta.ClusterMetadata(fi.Name())
-		if err != nil {
-			return errors.Trace(err)
+		if err != nil || metadata.Topology == nil {
+			// a broken cluster should not hide the others
+			log.Warnf("Failed to load meta of cluster %s: %v", fi.Name(), err)
+			clusterTable = append(clusterTable, []string{
+				fi.Name(),
+				"-",
+				color.RedString("error"),
+				"-",
+				meta.ClusterPath(fi.Name()),
+				meta.ClusterPath(fi.Name(), "ssh", "id_rsa"),
+			})
+			continue
 		}
 
+		count := 0
+		metadata.Topology.IterInstance(func(meta.Instance) {
+			count++
+		})
 		clusterTable = append(clusterTable, []string{
 			fi.Name(),
 			metadata.User,
 			metadata.Version,
+			strconv.Itoa(count),
 			meta.ClusterPath(fi.Name()),
 			meta.ClusterPath(fi.Name(), "ssh", "id_rsa"),
 		})
@@ -0,0 +1,127 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+)
+
+func newMetaCmd() *cobra.Command {
+	var backup, restore string
+
+	cmd := &cobra.Command{
+		Use:   "meta <cluster-name>",
+		Short: "Backup or restore the meta of a cluster",
+		Long: `Backup or restore the meta of a cluster, the whole meta directory including
+the SSH keys is saved to or restored from a tar.gz file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 || (backup == "") == (restore == "") {
+				return cmd.Help()
+			}
+
+			clusterName := args[0]
+			if backup != "" {
+				return operator.BackupMeta(clusterName, backup)
+			}
+
+			if tiuputils.IsExist(meta.ClusterPath(clusterName, meta.MetaFileName)) && !skipConfirm {
+				if err := cliutil.PromptForConfirmOrAbortError(
+					"This operation will replace the meta of cluster %s with %s.\nDo you want to continue? [y/N]:",
+					color.HiYellowString(clusterName),
+					color.HiYellowString(restore)); err != nil {
+					return err
+				}
+			}
+
+			logger.EnableAuditLog()
+			err := operator.RestoreMeta(clusterName, restore)
+			auditOperation(clusterName, "meta-restore", nil, err)
+			if err != nil {
+				return errors.Annotatef(err, "failed to restore meta of cluster %s", clusterName)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&backup, "backup", "", "Save the meta of the cluster to the tar.gz file")
+	cmd.Flags().StringVar(&restore, "restore", "", "Restore the meta of the cluster from the tar.gz file created by --backup")
+
+	cmd.AddCommand(newMetaRollbackCmd())
+
+	return cmd
+}
+
+func newMetaRollbackCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback <cluster-name> [backup]",
+		Short: "Roll back the meta of a cluster to an automatic backup",
+		Long: `Roll back the meta of a cluster to one of the backups saved automatically
+each time the meta is changed, the latest backups are kept, see --meta-backups.
+The backups are listed if no backup is specified.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || len(args) > 2 {
+				return cmd.Help()
+			}
+
+			clusterName := args[0]
+			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot roll back meta of non-exists cluster %s", clusterName)
+			}
+
+			if len(args) == 1 {
+				backups, err := meta.ListMetaBackups(clusterName)
+				if err != nil {
+					return err
+				}
+				backupTable := [][]string{{"Backup", "Saved At"}}
+				for _, fi := range backups {
+					backupTable = append(backupTable, []string{fi.Name(), fi.ModTime().Format(time.RFC3339)})
+				}
+				cliutil.PrintTable(backupTable, true)
+				return nil
+			}
+
+			backup := args[1]
+			if !skipConfirm {
+				if err := cliutil.PromptForConfirmOrAbortError(
+					"This operation will replace the meta of cluster %s with the backup %s.\nDo you want to continue? [y/N]:",
+					color.HiYellowString(clusterName),
+					color.HiYellowString(backup)); err != nil {
+					return err
+				}
+			}
+
+			logger.EnableAuditLog()
+			err := meta.RestoreMetaBackup(clusterName, backup)
+			auditOperation(clusterName, "meta-rollback", nil, err)
+			if err != nil {
+				return err
+			}
+			log.Infof("Rolled back meta of cluster %s to backup %s", clusterName, backup)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -15,12 +15,15 @@ package command
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path"
 
 	"github.com/joomcode/errorx"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/clusterutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
@@ -40,6 +43,10 @@ func newPatchCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "patch <cluster-name> <package-path>",
 		Short: "Replace the remote package with a specified package and restart the service",
+		Long: `Replace the remote package with a specified package and restart the service.
+The package is either a tarball of the component or a single binary of it.
+The instances are restarted one by one and each one is checked to be up
+before moving on, use -N to patch a single instance as a canary.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 2 {
 				return cmd.Help()
@@ -52,7 +59,11 @@ func newPatchCmd() *cobra.Command {
 			if len(options.Nodes) == 0 && len(options.Roles) == 0 {
 				return errors.New("the flag -R or -N must be specified at least one")
 			}
-			return patch(args[0], args[1], options, overwrite)
+
+			logger.EnableAuditLog()
+			err := patch(args[0], args[1], options, overwrite)
+			auditOperation(args[0], "patch", options.Nodes, err)
+			return err
 		},
 	}
 
@@ -66,7 +77,7 @@ func newPatchCmd() *cobra.Command {
 
 func patch(clusterName, packagePath string, options operator.Options, overwrite bool) error {
 	if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
-		return errors.Errorf("cannot patch non-exists cluster %s", clusterName)
+		return errClusterNotFound.New("cannot patch non-exists cluster %s", clusterName)
 	}
 
 	if exist := tiuputils.IsExist(packagePath); !exist {
@@ -82,7 +93,15 @@ func patch(clusterName, packagePath string, options operator.Options, overwrite
 	if err != nil {
 		return err
 	}
-	if err := checkPackage(clusterName, insts[0].ComponentName(), packagePath); err != nil {
+	comp := insts[0].ComponentName()
+	if packagePath, err = packBinaryIfNeed(clusterName, comp, packagePath); err != nil {
+		return err
+	}
+	if err := checkPackage(clusterName, comp, packagePath); err != nil {
+		return err
+	}
+	checksum, err := utils.Checksum(packagePath)
+	if err != nil {
 		return err
 	}
 
@@ -90,7 +109,7 @@ func patch(clusterName, packagePath string, options operator.Options, overwrite
 	for _, inst := range insts {
 		deployDir := clusterutil.Abs(metadata.User, inst.DeployDir())
 		tb := task.NewBuilder()
-		tb.BackupComponent(inst.ComponentName(), metadata.Version, inst.GetHost(), deployDir).
+		tb.BackupComponent(inst.ComponentName(), metadata.RoleVersion(inst.ComponentName()), inst.GetHost(), deployDir).
 			InstallPackage(packagePath, inst.GetHost(), deployDir)
 		replacePackageTasks = append(replacePackageTasks, tb.Build())
 	}
@@ -101,10 +120,22 @@ func patch(clusterName, packagePath string, options operator.Options, overwrite
 			meta.ClusterPath(clusterName, "ssh", "id_rsa.pub")).
 		ClusterSSH(metadata.Topology, metadata.User, sshTimeout).
 		Parallel(replacePackageTasks...).
-		ClusterOperate(metadata.Topology, operator.UpgradeOperation, options).
+		ClusterOperate(metadata.Topology, operator.RollingRestartOperation, options).
 		Build()
 
-	if err := t.Execute(task.NewContext()); err != nil {
+	err = t.Execute(task.NewContext())
+
+	// the binaries are replaced even if some instances fail to restart
+	var ids []string
+	for _, inst := range insts {
+		ids = append(ids, inst.ID())
+	}
+	metadata.PatchInstances(ids, comp+"-"+checksum[:7])
+	if serr := meta.SaveClusterMeta(clusterName, metadata); serr != nil {
+		return errors.Trace(serr)
+	}
+
+	if err != nil {
 		if errorx.Cast(err) != nil {
 			// FIXME: Map possible task errors and give suggestions.
 			return err
@@ -113,7 +144,7 @@ func patch(clusterName, packagePath string, options operator.Options, overwrite
 	}
 
 	if overwrite {
-		if err := overwritePatch(clusterName, insts[0].ComponentName(), packagePath); err != nil {
+		if err := overwritePatch(clusterName, comp, packagePath); err != nil {
 			return err
 		}
 	}
@@ -147,19 +178,71 @@ func instancesToPatch(metadata *meta.ClusterMeta, options operator.Options) ([]m
 	return instances, nil
 }
 
-func checkPackage(clusterName, comp, packagePath string) error {
+// componentEntry returns the path of the binary in the package of the
+// component used by the cluster
+func componentEntry(clusterName, comp string) (string, error) {
 	metadata, err := meta.ClusterMetadata(clusterName)
 	if err != nil {
-		return err
+		return "", err
 	}
 	manifest, err := tiupmeta.Repository().ComponentVersions(comp)
 	if err != nil {
-		return err
+		return "", err
 	}
-	ver := meta.ComponentVersion(comp, metadata.Version)
+	ver := meta.ComponentVersion(comp, metadata.RoleVersion(comp))
 	versionInfo, found := manifest.FindVersion(ver)
 	if !found {
-		return fmt.Errorf("cannot found version %v in %s manifest", ver, comp)
+		return "", fmt.Errorf("cannot found version %v in %s manifest", ver, comp)
+	}
+	return versionInfo.Entry, nil
+}
+
+// packBinaryIfNeed packs the package into a tarball of the component if it's
+// a single binary, the path of the tarball is returned
+func packBinaryIfNeed(clusterName, comp, packagePath string) (string, error) {
+	f, err := os.Open(packagePath)
+	if err != nil {
+		return "", errors.AddStack(err)
+	}
+	magic := make([]byte, 2)
+	_, err = io.ReadFull(f, magic)
+	f.Close()
+	// gzip magic number
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		return packagePath, nil
+	}
+
+	entry, err := componentEntry(clusterName, comp)
+	if err != nil {
+		return "", err
+	}
+	checksum, err := utils.Checksum(packagePath)
+	if err != nil {
+		return "", err
+	}
+	packDir := meta.ClusterPath(clusterName, "cache", comp+"-bin-"+checksum[:7])
+	if err := os.MkdirAll(path.Join(packDir, path.Dir(entry)), 0755); err != nil {
+		return "", errors.AddStack(err)
+	}
+	if err := utils.CopyFile(packagePath, path.Join(packDir, entry)); err != nil {
+		return "", errors.AddStack(err)
+	}
+	if err := os.Chmod(path.Join(packDir, entry), 0755); err != nil {
+		return "", errors.AddStack(err)
+	}
+
+	tarball := packDir + ".tar.gz"
+	if out, err := exec.Command("tar", "-czf", tarball, "-C", packDir, entry).CombinedOutput(); err != nil {
+		return "", errors.Annotatef(err, "failed to pack %s: %s", packagePath, out)
+	}
+	log.Infof("Packed binary %s into %s", packagePath, tarball)
+	return tarball, nil
+}
+
+func checkPackage(clusterName, comp, packagePath string) error {
+	entry, err := componentEntry(clusterName, comp)
+	if err != nil {
+		return err
 	}
 
 	checksum, err := utils.Checksum(packagePath)
@@ -174,8 +257,8 @@ func checkPackage(clusterName, comp, packagePath string) error {
 		return err
 	}
 
-	if exists := tiuputils.IsExist(path.Join(cacheDir, versionInfo.Entry)); !exists {
-		return fmt.Errorf("entry %s not found in package %s", versionInfo.Entry, packagePath)
+	if exists := tiuputils.IsExist(path.Join(cacheDir, entry)); !exists {
+		return fmt.Errorf("entry %s not found in package %s", entry, packagePath)
 	}
 
 	return nil
@@ -0,0 +1,79 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func newPDScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pd-schedule <cluster-name> <key=value>...",
+		Short: "Set the scheduling config of PD",
+		Long: `Set the scheduling config of PD, e.g. raise the limits for off-peak rebalancing:
+
+  pd-schedule <cluster-name> region-schedule-limit=64 replica-schedule-limit=64
+
+PD applies the config immediately, run it from a scheduler like cron to change
+the config by time windows. The active config is shown by display --pd-schedule.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return cmd.Help()
+			}
+
+			clusterName := args[0]
+			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot set schedule config of non-exists cluster %s", clusterName)
+			}
+
+			config, err := operator.ParseScheduleConfig(args[1:])
+			if err != nil {
+				return err
+			}
+
+			metadata, err := meta.ClusterMetadata(clusterName)
+			if err != nil {
+				return err
+			}
+
+			if !skipConfirm {
+				if err := cliutil.PromptForConfirmOrAbortError(
+					"This operation will set the schedule config of PD in `%s`:\n%s\nDo you want to continue? [y/N]:",
+					color.HiYellowString(clusterName),
+					color.HiYellowString(strings.Join(args[1:], "\n"))); err != nil {
+					return err
+				}
+			}
+
+			logger.EnableAuditLog()
+			if err := operator.SetScheduleConfig(metadata.Topology, config); err != nil {
+				return err
+			}
+
+			log.Infof("Set schedule config of cluster %s successfully", clusterName)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -14,13 +14,16 @@
 package command
 
 import (
+	"github.com/fatih/color"
 	"github.com/joomcode/errorx"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/clusterutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
+	"github.com/pingcap-incubator/tiup/pkg/set"
 	"github.com/pingcap-incubator/tiup/pkg/utils"
 	"github.com/pingcap/errors"
 	"github.com/spf13/cobra"
@@ -28,10 +31,15 @@ import (
 
 func newReloadCmd() *cobra.Command {
 	var options operator.Options
+	var forceRestart bool
 
 	cmd := &cobra.Command{
 		Use:   "reload <cluster-name>",
 		Short: "Reload a TiDB cluster's config and restart if needed",
+		Long: `Push the config to the selected instances and apply it. The components that
+reload the config on SIGHUP, i.e. Prometheus and Alertmanager, are signaled
+to reload, and the others are restarted one by one. Changes of the start
+arguments, e.g. the ports, always need --force-restart.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 1 {
 				return cmd.Help()
@@ -43,52 +51,154 @@ func newReloadCmd() *cobra.Command {
 
 			clusterName := args[0]
 			if utils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
-				return errors.Errorf("cannot start non-exists cluster %s", clusterName)
+				return errClusterNotFound.New("cannot start non-exists cluster %s", clusterName)
 			}
 
 			logger.EnableAuditLog()
+			defer clearStatusCache(clusterName)
 			metadata, err := meta.ClusterMetadata(clusterName)
 			if err != nil {
 				return err
 			}
 
-			t, err := buildReloadTask(clusterName, metadata, options)
+			instances := filterInstances(metadata, &displayOption{filterRole: options.Roles, filterNode: options.Nodes})
+			if len(instances) == 0 {
+				return errors.Errorf("no instance of cluster %s matches the roles and nodes", clusterName)
+			}
+			err = reloadInstances(clusterName, metadata, options, instances, forceRestart, true)
+			auditOperation(clusterName, "reload", options.Nodes, err)
 			if err != nil {
 				return err
 			}
 
-			if err := t.Execute(task.NewContext()); err != nil {
-				if errorx.Cast(err) != nil {
-					// FIXME: Map possible task errors and give suggestions.
-					return err
-				}
-				return errors.Trace(err)
-			}
-
 			log.Infof("Reloaded cluster `%s` successfully", clusterName)
 
 			return nil
 		},
 	}
 
-	cmd.Flags().StringSliceVarP(&options.Roles, "role", "R", nil, "Only start specified roles")
-	cmd.Flags().StringSliceVarP(&options.Nodes, "node", "N", nil, "Only start specified nodes")
+	cmd.Flags().StringSliceVarP(&options.Roles, "role", "R", nil, "Only reload specified roles")
+	cmd.Flags().StringSliceVarP(&options.Nodes, "node", "N", nil, "Only reload specified nodes")
 	cmd.Flags().Int64Var(&options.Timeout, "transfer-timeout", 300, "Timeout in seconds when transferring PD and TiKV store leaders")
+	cmd.Flags().BoolVar(&forceRestart, "force-restart", false, "Restart all selected instances, even the ones able to reload the config")
+	cmd.Flags().IntVar(&options.Concurrency, "concurrency", defaultDeployConcurrency, "The max number of instances to push the config to concurrently")
 
 	return cmd
 }
 
+// reloadInstances applies the config to the instances by reloading the ones
+// supporting it and restarting the others, the config is pushed to them
+// first if refreshConfig is set
+func reloadInstances(
+	clusterName string,
+	metadata *meta.ClusterMeta,
+	options operator.Options,
+	instances []meta.Instance,
+	forceRestart, refreshConfig bool,
+) error {
+	var reloaded, restarted []meta.Instance
+	for _, ins := range instances {
+		if !forceRestart && operator.ReloadSupported(ins.ComponentName()) {
+			reloaded = append(reloaded, ins)
+		} else {
+			restarted = append(restarted, ins)
+		}
+	}
+
+	t, err := buildReloadTask(clusterName, metadata, options, instances, reloaded, restarted, refreshConfig)
+	if err != nil {
+		return err
+	}
+
+	if err := t.Execute(task.NewContext()); err != nil {
+		if errorx.Cast(err) != nil {
+			// FIXME: Map possible task errors and give suggestions.
+			return err
+		}
+		return errors.Trace(err)
+	}
+
+	printReloadResult(reloaded, restarted)
+	return nil
+}
+
+// buildReloadTask pushes the config to the instances if refreshConfig is
+// set, then signals the reloaded ones to reload and rolling restarts the others
 func buildReloadTask(
 	clusterName string,
 	metadata *meta.ClusterMeta,
 	options operator.Options,
+	instances, reloaded, restarted []meta.Instance,
+	refreshConfig bool,
 ) (task.Task, error) {
+	b := task.NewBuilder().
+		SSHKeySet(
+			meta.ClusterPath(clusterName, "ssh", "id_rsa"),
+			meta.ClusterPath(clusterName, "ssh", "id_rsa.pub")).
+		ClusterSSH(metadata.Topology, metadata.User, sshTimeout)
+	if refreshConfig {
+		b.ParallelWithConcurrency(options.Concurrency, buildInstancesRefreshConfigTasks(clusterName, metadata, instances)...)
+	}
+
+	// an empty node list means all nodes to the operations
+	if len(reloaded) > 0 {
+		reloadOpt := options
+		reloadOpt.Nodes = instanceIDs(reloaded)
+		b.ClusterOperate(metadata.Topology, operator.ReloadOperation, reloadOpt)
+	}
+	if len(restarted) > 0 {
+		restartOpt := options
+		restartOpt.Nodes = instanceIDs(restarted)
+		b.ClusterOperate(metadata.Topology, operator.UpgradeOperation, restartOpt)
+	}
 
+	return b.Build(), nil
+}
+
+// buildInstancesRefreshConfigTasks returns the tasks to push the config of
+// the instances
+func buildInstancesRefreshConfigTasks(clusterName string, metadata *meta.ClusterMeta, instances []meta.Instance) []task.Task {
+	selected := set.NewStringSet(instanceIDs(instances)...)
+	return buildRefreshConfigTasks(clusterName, metadata, func(inst meta.Instance) bool {
+		return selected.Exist(inst.ID())
+	})
+}
+
+func instanceIDs(instances []meta.Instance) []string {
+	ids := make([]string, 0, len(instances))
+	for _, ins := range instances {
+		ids = append(ids, ins.ID())
+	}
+	return ids
+}
+
+// printReloadResult reports how each instance applied the new config
+func printReloadResult(reloaded, restarted []meta.Instance) {
+	result := [][]string{
+		// Header
+		{"ID", "Role", "Host", "Applied By"},
+	}
+	for _, ins := range reloaded {
+		result = append(result, []string{ins.ID(), ins.Role(), ins.GetHost(), color.GreenString("reload")})
+	}
+	for _, ins := range restarted {
+		result = append(result, []string{ins.ID(), ins.Role(), ins.GetHost(), color.YellowString("restart")})
+	}
+	cliutil.PrintTable(result, true)
+}
+
+// buildRefreshConfigTasks returns the tasks to push the config of the
+// instances accepted by filter, all instances in the topology if it's nil
+func buildRefreshConfigTasks(clusterName string, metadata *meta.ClusterMeta, filter func(meta.Instance) bool) []task.Task {
 	var refreshConfigTasks []task.Task
 
 	topo := metadata.Topology
 
 	topo.IterInstance(func(inst meta.Instance) {
+		if filter != nil && !filter(inst) {
+			return
+		}
+
 		deployDir := clusterutil.Abs(metadata.User, inst.DeployDir())
 		// data dir would be empty for components which don't need it
 		dataDir := clusterutil.Abs(metadata.User, inst.DataDir())
@@ -101,13 +211,14 @@ func buildReloadTask(
 			switch compName := inst.ComponentName(); compName {
 			case meta.ComponentGrafana, meta.ComponentPrometheus, meta.ComponentAlertManager:
 				version := meta.ComponentVersion(compName, metadata.Version)
-				tb.Download(compName, version).CopyComponent(compName, version, inst.GetHost(), deployDir)
+				arch := meta.InstanceArch(inst)
+				tb.Download(compName, arch, version).CopyComponent(compName, arch, version, inst.GetHost(), deployDir)
 			}
 		}
 
 		// Refresh all configuration
 		t := tb.InitConfig(clusterName,
-			metadata.Version,
+			metadata.RoleVersion(inst.ComponentName()),
 			inst, metadata.User,
 			meta.DirPaths{
 				Deploy: deployDir,
@@ -118,16 +229,7 @@ func buildReloadTask(
 		refreshConfigTasks = append(refreshConfigTasks, t)
 	})
 
-	t := task.NewBuilder().
-		SSHKeySet(
-			meta.ClusterPath(clusterName, "ssh", "id_rsa"),
-			meta.ClusterPath(clusterName, "ssh", "id_rsa.pub")).
-		ClusterSSH(metadata.Topology, metadata.User, sshTimeout).
-		Parallel(refreshConfigTasks...).
-		ClusterOperate(metadata.Topology, operator.UpgradeOperation, options).
-		Build()
-
-	return t, nil
+	return refreshConfigTasks
 }
 
 func validRoles(roles []string) error {
@@ -147,3 +249,19 @@ func validRoles(roles []string) error {
 
 	return nil
 }
+
+// validNodes checks each of the nodes is the ID of an instance in the
+// topology, i.e. the one shown in the ID column of display, so that a typo
+// doesn't end up operating on nothing silently
+func validNodes(topo *meta.TopologySpecification, nodes []string) error {
+	ids := set.NewStringSet()
+	topo.IterInstance(func(ins meta.Instance) {
+		ids.Insert(ins.ID())
+	})
+	for _, node := range nodes {
+		if !ids.Exist(node) {
+			return errors.Errorf("not valid node: %s, should be the ID of an instance shown by `%s display`", node, cliutil.OsArgs0())
+		}
+	}
+	return nil
+}
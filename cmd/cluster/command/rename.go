@@ -0,0 +1,107 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+)
+
+// renameRefreshedRoles are the roles whose config embeds the cluster name,
+// e.g. the cluster label of Prometheus and the dashboards of Grafana
+var renameRefreshedRoles = []string{meta.ComponentPrometheus, meta.ComponentGrafana}
+
+func newRenameCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rename <old-cluster-name> <new-cluster-name>",
+		Short: "Rename the cluster",
+		Long: `Rename the cluster by moving its metadata, then push the config embedding the
+cluster name, i.e. the ones of Prometheus and Grafana, and reload them.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return cmd.Help()
+			}
+
+			oldName, newName := args[0], args[1]
+			if tiuputils.IsNotExist(meta.ClusterPath(oldName, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot rename non-exists cluster %s", oldName)
+			}
+			if err := utils.ValidateClusterNameOrError(newName); err != nil {
+				return err
+			}
+			if tiuputils.IsExist(meta.ClusterPath(newName)) {
+				return errDeployNameDuplicate.
+					New("Cluster name '%s' is duplicated", newName).
+					WithProperty(cliutil.SuggestionFromFormat("Please specify another cluster name"))
+			}
+
+			// the checkpoint of a resumable operation is only left by an
+			// unfinished one, which would be resumed with the old name
+			checkpoints, err := filepath.Glob(meta.ClusterPath(oldName, "*.checkpoint"))
+			if err != nil {
+				return errors.AddStack(err)
+			}
+			if len(checkpoints) > 0 {
+				operation := strings.TrimSuffix(filepath.Base(checkpoints[0]), ".checkpoint")
+				return errors.Errorf("the %s of cluster %s is unfinished, resume it with --resume before renaming", operation, oldName)
+			}
+
+			logger.EnableAuditLog()
+			metadata, err := meta.ClusterMetadata(oldName)
+			if err != nil {
+				return err
+			}
+
+			if !skipConfirm {
+				if err := cliutil.PromptForConfirmOrAbortError(
+					"This operation will rename cluster %s to %s and reload its Prometheus and Grafana.\nDo you want to continue? [y/N]:",
+					color.HiYellowString(oldName),
+					color.HiYellowString(newName)); err != nil {
+					return err
+				}
+			}
+
+			if err := os.Rename(meta.ClusterPath(oldName), meta.ClusterPath(newName)); err != nil {
+				return errors.Annotatef(err, "failed to rename cluster %s to %s", oldName, newName)
+			}
+			log.Infof("Renamed cluster `%s` to `%s`", oldName, newName)
+
+			instances := filterInstances(metadata, &displayOption{filterRole: renameRefreshedRoles})
+			if len(instances) > 0 {
+				options := operator.Options{Roles: renameRefreshedRoles}
+				if err := reloadInstances(newName, metadata, options, instances, false, true); err != nil {
+					return errors.Annotatef(err, "the cluster is renamed but failed to reload, retry by `%s reload %s -R %s`",
+						cliutil.OsArgs0(), newName, strings.Join(renameRefreshedRoles, ","))
+				}
+			}
+
+			log.Infof("Renamed cluster `%s` to `%s` successfully", oldName, newName)
+			return nil
+		},
+	}
+
+	return cmd
+}
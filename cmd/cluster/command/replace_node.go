@@ -0,0 +1,136 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/joomcode/errorx"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+func newReplaceNodeCmd() *cobra.Command {
+	opt := scaleOutOptions{
+		identityFile: filepath.Join(utils.UserHome(), ".ssh", "id_rsa"),
+		concurrency:  defaultDeployConcurrency,
+	}
+	options := operator.Options{}
+	cmd := &cobra.Command{
+		Use:   "replace-node <cluster-name> <old-host> <new-host>",
+		Short: "Replace a dead host with a new one",
+		Long: `Scale in all instances on the old host and scale out the equivalents on the
+new host, the labels and configs of the instances are kept. The old host is
+usually dead, in which case the steps on it are skipped.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 3 {
+				return cmd.Help()
+			}
+
+			logger.EnableAuditLog()
+			return replaceNode(args[0], args[1], args[2], opt, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&opt.user, "user", utils.CurrentUser(), "The user name to login to the new host via SSH. The user must has root (or sudo) privilege.")
+	cmd.Flags().StringVarP(&opt.identityFile, "identity_file", "i", opt.identityFile, "The path of the SSH identity file. If specified, public key authentication will be used.")
+	cmd.Flags().BoolVarP(&opt.usePassword, "password", "p", false, "Use password of the new host. If specified, password authentication will be used.")
+	cmd.Flags().Int64Var(&options.Timeout, "transfer-timeout", 300, "Timeout in seconds when transferring PD and TiKV store leaders")
+
+	return cmd
+}
+
+func replaceNode(clusterName, oldHost, newHost string, opt scaleOutOptions, options operator.Options) error {
+	if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+		return errClusterNotFound.New("cannot replace node of non-exists cluster %s", clusterName)
+	}
+
+	metadata, err := meta.ClusterMetadata(clusterName)
+	if err != nil {
+		return err
+	}
+
+	// Abort before scaling in if the instances cannot be deployed on the new host
+	newPart := metadata.Topology.CopyHostInstances(oldHost, newHost)
+	if err := metadata.Topology.Merge(newPart).Validate(); err != nil {
+		return err
+	}
+
+	if !skipConfirm {
+		if err := cliutil.PromptForConfirmOrAbortError(
+			"This operation will scale in all instances on %s in `%s` and deploy them on %s.\nDo you want to continue? [y/N]:",
+			color.HiYellowString(oldHost),
+			color.HiYellowString(clusterName),
+			color.HiYellowString(newHost)); err != nil {
+			return err
+		}
+	}
+
+	ctx := task.NewContext()
+	err = ctx.SetSSHKeySet(meta.ClusterPath(clusterName, "ssh", "id_rsa"),
+		meta.ClusterPath(clusterName, "ssh", "id_rsa.pub"))
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	err = ctx.SetClusterSSH(metadata.Topology, metadata.User, sshTimeout)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	newPart, deleted, err := operator.ReplaceNode(ctx, metadata.Topology, oldHost, newHost, options)
+	auditOperation(clusterName, "replace-node", deleted, err)
+	if err != nil {
+		return err
+	}
+	if err := task.NewBuilder().UpdateMeta(clusterName, metadata, deleted).Build().Execute(ctx); err != nil {
+		if errorx.Cast(err) != nil {
+			return err
+		}
+		return errors.Trace(err)
+	}
+
+	// keep the topology of the new host so that the scale out can be retried
+	data, err := yaml.Marshal(newPart)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	topoFile := meta.ClusterPath(clusterName, fmt.Sprintf("replace-%s.yaml", newHost))
+	if err := ioutil.WriteFile(topoFile, data, 0644); err != nil {
+		return errors.AddStack(err)
+	}
+
+	if err := scaleOutTopology(clusterName, newPart, opt, false); err != nil {
+		log.Errorf("Instances on %s are scaled in but failed to scale out to %s, retry with `%s scale-out %s %s`",
+			oldHost, newHost, cliutil.OsArgs0(), clusterName, topoFile)
+		return err
+	}
+	_ = os.Remove(topoFile)
+
+	log.Infof("Replaced %s with %s in cluster `%s` successfully", oldHost, newHost, clusterName)
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 
 func newRestartCmd() *cobra.Command {
 	var options operator.Options
+	rolling := false
 
 	cmd := &cobra.Command{
 		Use:   "restart <cluster-name>",
@@ -42,21 +43,29 @@ func newRestartCmd() *cobra.Command {
 
 			clusterName := args[0]
 			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
-				return errors.Errorf("cannot restart non-exists cluster %s", clusterName)
+				return errClusterNotFound.New("cannot restart non-exists cluster %s", clusterName)
 			}
 
 			logger.EnableAuditLog()
+			defer clearStatusCache(clusterName)
 			metadata, err := meta.ClusterMetadata(clusterName)
 			if err != nil {
 				return err
 			}
+			if err := validNodes(metadata.Topology, options.Nodes); err != nil {
+				return err
+			}
 
+			op := operator.RestartOperation
+			if rolling {
+				op = operator.RollingRestartOperation
+			}
 			t := task.NewBuilder().
 				SSHKeySet(
 					meta.ClusterPath(clusterName, "ssh", "id_rsa"),
 					meta.ClusterPath(clusterName, "ssh", "id_rsa.pub")).
 				ClusterSSH(metadata.Topology, metadata.User, sshTimeout).
-				ClusterOperate(metadata.Topology, operator.RestartOperation, options).
+				ClusterOperate(metadata.Topology, op, options).
 				Build()
 
 			if err := t.Execute(task.NewContext()); err != nil {
@@ -75,5 +84,8 @@ func newRestartCmd() *cobra.Command {
 
 	cmd.Flags().StringSliceVarP(&options.Roles, "role", "R", nil, "Only restart specified roles")
 	cmd.Flags().StringSliceVarP(&options.Nodes, "node", "N", nil, "Only restart specified nodes")
+	cmd.Flags().BoolVar(&rolling, "rolling", false, "Restart instances one by one with the PD and TiKV leaders transferred away first")
+	cmd.Flags().BoolVar(&options.Force, "force", false, "Restart the PD leader even if the leadership can't be transferred to another healthy member")
+	cmd.Flags().Int64Var(&options.Timeout, "transfer-timeout", 300, "Timeout in seconds when transferring PD and TiKV store leaders")
 	return cmd
 }
@@ -0,0 +1,109 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"github.com/fatih/color"
+	"github.com/joomcode/errorx"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
+	"github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+)
+
+func newRollbackCmd() *cobra.Command {
+	var options operator.Options
+	cmd := &cobra.Command{
+		Use:   "rollback <cluster-name>",
+		Short: "Rollback the last upgrade of a TiDB cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			logger.EnableAuditLog()
+			err := rollbackUpgrade(args[0], options)
+			auditOperation(args[0], "rollback", nil, err)
+			return err
+		},
+	}
+	cmd.Flags().BoolVar(&options.Force, "force", false, "Force rollback won't transfer leader")
+	cmd.Flags().Int64Var(&options.Timeout, "transfer-timeout", 300, "Timeout in seconds when transferring PD and TiKV store leaders")
+
+	return cmd
+}
+
+func rollbackUpgrade(clusterName string, options operator.Options) error {
+	if utils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+		return errClusterNotFound.New("cannot rollback non-exists cluster %s", clusterName)
+	}
+
+	metadata, err := meta.ClusterMetadata(clusterName)
+	if err != nil {
+		return err
+	}
+	if metadata.PrevVersion == "" {
+		return errors.Errorf("no upgrade of cluster %s to rollback", clusterName)
+	}
+
+	if !skipConfirm {
+		if err := cliutil.PromptForConfirmOrAbortError(
+			"This operation will rollback cluster %s from %s to %s.\nDo you want to continue? [y/N]:",
+			color.HiYellowString(clusterName),
+			color.HiYellowString(metadata.Version),
+			color.HiYellowString(metadata.PrevVersion)); err != nil {
+			return err
+		}
+	}
+
+	downloadCompTasks, copyCompTasks, err := buildUpgradeCompTasks(clusterName, metadata,
+		metadata.PrevVersion, metadata.PrevComponentVersions, nil)
+	if err != nil {
+		return err
+	}
+
+	t := task.NewBuilder().
+		SSHKeySet(
+			meta.ClusterPath(clusterName, "ssh", "id_rsa"),
+			meta.ClusterPath(clusterName, "ssh", "id_rsa.pub")).
+		ClusterSSH(metadata.Topology, metadata.User, sshTimeout).
+		Parallel(downloadCompTasks...).
+		Parallel(copyCompTasks...).
+		ClusterOperate(metadata.Topology, operator.RollbackUpgradeOperation, options).
+		Build()
+
+	if err := t.Execute(task.NewContext()); err != nil {
+		if errorx.Cast(err) != nil {
+			// FIXME: Map possible task errors and give suggestions.
+			return err
+		}
+		return errors.Trace(err)
+	}
+
+	metadata.Version = metadata.PrevVersion
+	metadata.ComponentVersions = metadata.PrevComponentVersions
+	metadata.PrevVersion = ""
+	metadata.PrevComponentVersions = nil
+	if err := meta.SaveClusterMeta(clusterName, metadata); err != nil {
+		return errors.Trace(err)
+	}
+
+	log.Infof("Rolled back cluster `%s` to %s successfully", clusterName, metadata.Version)
+	return nil
+}
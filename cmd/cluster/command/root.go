@@ -14,18 +14,28 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/joomcode/errorx"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil/progress"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/colorutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/errutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/flags"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/version"
 	"github.com/pingcap-incubator/tiup/pkg/localdata"
 	tiupmeta "github.com/pingcap-incubator/tiup/pkg/meta"
@@ -36,10 +46,37 @@ import (
 
 var rootCmd *cobra.Command
 
+// the errors of the commands with dedicated exit codes, see errutil.ExitCode
+var (
+	errClusterNotFound  = errNS.NewType("cluster_not_found", errutil.ErrTraitClusterNotFound)
+	errClusterUnhealthy = errNS.NewType("cluster_unhealthy", errutil.ErrTraitUnhealthy)
+)
+
 var (
 	errNS       = errorx.NewNamespace("cmd")
 	sshTimeout  int64 // timeout in seconds when connecting an SSH server
 	skipConfirm bool
+
+	// retries of the SSH operations failed by transient network errors
+	sshRetries       int
+	sshRetryInterval time.Duration
+	sshPassword      bool // login the cluster hosts with the password of the deploy user
+
+	// the bastion host to tunnel all SSH connections through
+	sshProxyHost    string
+	sshProxyKeyFile string
+
+	// the passphrase of the encrypted SSH identity files
+	sshKeyPassphrase string
+
+	logFormat  string // the output format of the console log, text or json
+	noProgress bool
+
+	// the local directory or HTTP endpoint to download the components from
+	mirror string
+
+	// the number of the automatic meta backups kept for each cluster
+	metaBackups int
 )
 
 func init() {
@@ -58,9 +95,41 @@ func init() {
 		SilenceErrors: true,
 		Version:       version.NewTiOpsVersion().FullInfo(),
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := log.SetFormat(logFormat); err != nil {
+				return err
+			}
+			// the bars redrawn in place would break the JSON lines
+			if noProgress || logFormat == log.FormatJSON {
+				progress.Disable()
+			}
 			if err := meta.Initialize("cluster"); err != nil {
 				return err
 			}
+			if sshRetries < 0 {
+				return fmt.Errorf("--ssh-retries must not be negative")
+			}
+			executor.SetSSHRetry(sshRetries, sshRetryInterval)
+			if sshPassword {
+				task.EnableUserSSHPassword()
+			}
+			cliutil.SetSSHKeyPassphrase(sshKeyPassphrase)
+			task.SetMirror(mirror)
+			if metaBackups < 1 {
+				return fmt.Errorf("--meta-backups must be positive")
+			}
+			meta.SetMaxMetaBackups(metaBackups)
+			if sshProxyHost != "" {
+				proxy, err := executor.ParseSSHProxy(sshProxyHost, sshProxyKeyFile)
+				if err != nil {
+					return err
+				}
+				proxy.Timeout = time.Second * time.Duration(sshTimeout)
+				proxy.Passphrase = cliutil.SSHKeyPassphrase()
+				executor.SetSSHProxy(proxy)
+				if err := executor.CheckSSHProxy(); err != nil {
+					return err
+				}
+			}
 			return tiupmeta.InitRepository(repository.Options{
 				GOOS:   "linux",
 				GOARCH: "amd64",
@@ -77,7 +146,17 @@ func init() {
 	cliutil.BeautifyCobraUsageAndHelp(rootCmd)
 
 	rootCmd.PersistentFlags().Int64Var(&sshTimeout, "ssh-timeout", 5, "Timeout in seconds to connect host via SSH, ignored for operations that don't need an SSH connection.")
+	rootCmd.PersistentFlags().IntVar(&sshRetries, "ssh-retries", executor.DefaultSSHRetries, "Times to retry an SSH command failed to connect or a file transfer failed by a transient network error")
+	rootCmd.PersistentFlags().DurationVar(&sshRetryInterval, "ssh-retry-interval", executor.DefaultSSHRetryInterval, "Interval before the first SSH retry, doubled after each retry")
+	rootCmd.PersistentFlags().BoolVar(&sshPassword, "ssh-password", false, fmt.Sprintf("Login the hosts of the cluster with the password of the deploy user, read from $%s or prompted once. It's used automatically if the SSH key of the cluster is missing", task.EnvNameSSHPassword))
+	rootCmd.PersistentFlags().StringVar(&sshKeyPassphrase, "ssh-key-passphrase", "", fmt.Sprintf("The passphrase of the encrypted SSH identity file, also read from $%s. It's prompted if not set and the key is not in the SSH agent", cliutil.EnvNameSSHKeyPassphrase))
+	rootCmd.PersistentFlags().StringVar(&sshProxyHost, "ssh-proxy", "", "The bastion host to tunnel all SSH connections through, in format of [user@]host[:port]")
+	rootCmd.PersistentFlags().StringVar(&sshProxyKeyFile, "ssh-proxy-identity-file", filepath.Join(utils.UserHome(), ".ssh", "id_rsa"), "The private key file to login the SSH proxy")
 	rootCmd.PersistentFlags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip all confirmations and assumes 'yes'")
+	rootCmd.PersistentFlags().BoolVar(&noProgress, "no-progress", false, "Print the progress of the steps line by line instead of the progress bars, it's implied if the output is not a terminal")
+	rootCmd.PersistentFlags().StringVar(&mirror, "mirror", "", "The local directory or HTTP endpoint of the mirror to download the components from, it overrides $TIUP_MIRRORS. See README.md for the layout")
+	rootCmd.PersistentFlags().IntVar(&metaBackups, "meta-backups", meta.DefaultMetaBackups, "The number of the automatic meta backups kept for each cluster, see `meta rollback`")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", log.FormatText, "The output format of the log, text or json (one JSON object per line)")
 
 	rootCmd.AddCommand(
 		newCheckCmd(),
@@ -89,19 +168,36 @@ func init() {
 		newScaleOutCmd(),
 		newDestroyCmd(),
 		newUpgradeCmd(),
+		newRollbackCmd(),
 		newExecCmd(),
+		newCollectCmd(),
 		newDisplayCmd(),
+		newHealthCmd(),
 		newListCmd(),
+		newRenameCmd(),
 		newAuditCmd(),
 		newImportCmd(),
 		newEditConfigCmd(),
 		newReloadCmd(),
+		newCheckConfigCmd(),
+		newExportTopologyCmd(),
+		newCloneCmd(),
+		newExportPrometheusTargetsCmd(),
 		newPatchCmd(),
+		newStoreStateCmd(),
+		newPDScheduleCmd(),
+		newReplaceNodeCmd(),
+		newMetaCmd(),
+		newTLSCmd(),
 		newTestCmd(), // hidden command for test internally
 	)
 }
 
 func printErrorMessageForNormalError(err error) {
+	if log.Format() == log.FormatJSON {
+		log.Errorf("Error: %s", err.Error())
+		return
+	}
 	_, _ = colorutil.ColorErrorMsg.Fprintf(os.Stderr, "\nError: %s\n", err.Error())
 }
 
@@ -138,6 +234,10 @@ func printErrorMessageForErrorX(err *errorx.Error) {
 			break
 		}
 	}
+	if log.Format() == log.FormatJSON {
+		log.Errorf("Error: %s", strings.TrimSpace(msg))
+		return
+	}
 	_, _ = colorutil.ColorErrorMsg.Fprintf(os.Stderr, "\nError: %s", msg)
 }
 
@@ -156,10 +256,43 @@ func extractSuggestionFromErrorX(err *errorx.Error) string {
 	return ""
 }
 
+// redactedEnviron returns the environment variables with the values of the
+// SSH password and the key passphrase hidden
+func redactedEnviron() []string {
+	env := os.Environ()
+	for i, kv := range env {
+		for _, name := range []string{task.EnvNameSSHPassword, cliutil.EnvNameSSHKeyPassphrase} {
+			if strings.HasPrefix(kv, name+"=") {
+				env[i] = name + "=******"
+			}
+		}
+	}
+	return env
+}
+
+// handleInterrupt cancels the running tasks on the first interrupt, so that
+// the tasks being executed can finish and the finished ones are rolled back,
+// the process exits on the second interrupt or if no task is running
+func handleInterrupt(cancel context.CancelFunc) {
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	sig := <-sigCh
+	if !task.Running() {
+		os.Exit(errutil.ExitCodeInterrupted)
+	}
+	log.Warnf("\nReceived %s, cancelling: waiting for the running tasks to finish and rolling back the finished ones...", sig)
+	log.Warnf("Press Ctrl+C again to exit immediately, which may leave the cluster half-configured")
+	cancel()
+
+	<-sigCh
+	os.Exit(errutil.ExitCodeInterrupted)
+}
+
 // Execute executes the root command
 func Execute() {
 	zap.L().Info("Execute command", zap.String("command", cliutil.OsArgs()))
-	zap.L().Debug("Environment variables", zap.Strings("env", os.Environ()))
+	zap.L().Debug("Environment variables", zap.Strings("env", redactedEnviron()))
 
 	// Switch current work directory if running in TiUP component mode
 	if wd := os.Getenv(localdata.EnvNameWorkDir); wd != "" {
@@ -168,11 +301,13 @@ func Execute() {
 		}
 	}
 
-	code := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	task.SetBaseContext(ctx)
+	go handleInterrupt(cancel)
+
 	err := rootCmd.Execute()
-	if err != nil {
-		code = 1
-	}
+	code := errutil.ExitCode(err)
 
 	zap.L().Info("Execute command finished", zap.Int("code", code), zap.Error(err))
 
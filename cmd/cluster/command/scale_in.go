@@ -14,10 +14,14 @@
 package command
 
 import (
+	"crypto/tls"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/joomcode/errorx"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/api"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/clusterutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
@@ -25,6 +29,7 @@ import (
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
 	"github.com/pingcap-incubator/tiup/pkg/set"
 	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
 	"github.com/pingcap/errors"
@@ -34,6 +39,7 @@ import (
 func newScaleInCmd() *cobra.Command {
 	var (
 		options operator.Options
+		dryRun  bool
 	)
 	cmd := &cobra.Command{
 		Use:   "scale-in <cluster-name>",
@@ -44,6 +50,11 @@ func newScaleInCmd() *cobra.Command {
 			}
 
 			clusterName := args[0]
+			if dryRun {
+				return scaleInDryRun(clusterName, options)
+			}
+
+			warnDrainerCheckpoint(clusterName, options.Nodes)
 			if !skipConfirm {
 				if err := cliutil.PromptForConfirmOrAbortError(
 					"This operation will delete the %s nodes in `%s` and all their data.\nDo you want to continue? [y/N]:",
@@ -55,23 +66,160 @@ func newScaleInCmd() *cobra.Command {
 			}
 
 			logger.EnableAuditLog()
-			return scaleIn(clusterName, options)
+			err := scaleIn(clusterName, options)
+			auditOperation(clusterName, "scale-in", options.Nodes, err)
+			return err
 		},
 	}
 
 	cmd.Flags().StringSliceVarP(&options.Nodes, "node", "N", nil, "Specify the nodes")
 	cmd.Flags().Int64Var(&options.Timeout, "transfer-timeout", 300, "Timeout in seconds when transferring PD and TiKV store leaders")
 	cmd.Flags().BoolVar(&options.Force, "force", false, "Force just try stop and destroy instance before removing the instance from topo")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the instances to remove and the regions to move off the stores without changing anything")
 
 	_ = cmd.MarkFlagRequired("node")
 
 	return cmd
 }
 
+// warnDrainerCheckpoint warns that the checkpoint of the drainers to be
+// scaled in is lost, a new drainer replicates from its own commit_ts
+func warnDrainerCheckpoint(clusterName string, nodes []string) {
+	metadata, err := meta.ClusterMetadata(clusterName)
+	if err != nil {
+		// reported by scaleIn
+		return
+	}
+	deletedNodes := set.NewStringSet(nodes...)
+	for _, ins := range (&meta.DrainerComponent{ClusterSpecification: metadata.Topology}).Instances() {
+		if deletedNodes.Exist(ins.ID()) {
+			log.Warnf("The replication checkpoint of drainer %s will be lost, a new drainer starts from its commit_ts instead of where %s stops",
+				ins.ID(), ins.ID())
+		}
+	}
+}
+
+// scaleInDryRun prints the plan of scaling in the nodes in the table of
+// display: the instances to remove, the regions and leaders to move off the
+// TiKV stores, and the tombstone nodes already waiting to be destroyed
+func scaleInDryRun(clusterName string, options operator.Options) error {
+	if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+		return errClusterNotFound.New("cannot scale-in non-exists cluster %s", clusterName)
+	}
+
+	metadata, err := meta.ClusterMetadata(clusterName)
+	if err != nil {
+		return err
+	}
+	topo := metadata.Topology
+
+	deleted, err := operator.ScaleInInstances(topo, options.Nodes)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := newDisplayContext(clusterName, metadata, sshTimeout)
+	if err != nil {
+		return err
+	}
+	var tlsCfg *tls.Config
+	if topo.GlobalOptions.EnableTLS {
+		if tlsCfg, err = meta.ClusterTLSConfig(clusterName); err != nil {
+			return err
+		}
+	}
+
+	// the region and leader counts of the stores, by the store address
+	counts := map[string][2]int{}
+	pdList := topo.GetPDList()
+	if stores, err := api.NewPDClient(pdList, 10*time.Second, tlsCfg).GetStores(); err == nil {
+		for _, store := range stores.Stores {
+			counts[store.Store.Address] = [2]int{store.Status.RegionCount, store.Status.LeaderCount}
+		}
+	} else {
+		log.Warnf("Failed to query the stores from PD, the regions to move are unknown: %s", err)
+	}
+
+	// the tombstone nodes left by the earlier scale-ins, destroyed by display
+	tombstones, err := operator.DestroyTombstone(ctx, topo, true /* returnNodesOnly */)
+	if err != nil {
+		log.Warnf("Failed to query the tombstone nodes: %s", err)
+	}
+
+	plans := map[string]string{}
+	instances := deleted
+	for _, ins := range deleted {
+		if operator.IsAsyncOffline(ins.ComponentName()) {
+			plans[ins.ID()] = "would-remove (offline, then tombstone)"
+		} else {
+			plans[ins.ID()] = "would-remove (stop and destroy)"
+		}
+	}
+	tombstoneNodes := set.NewStringSet(tombstones...)
+	topo.IterInstance(func(ins meta.Instance) {
+		if _, ok := plans[ins.ID()]; !ok && tombstoneNodes.Exist(ins.ID()) {
+			plans[ins.ID()] = "would-remove (tombstone)"
+			instances = append(instances, ins)
+		}
+	})
+
+	statusMapping, err := meta.LoadStatusMapping()
+	if err != nil {
+		return err
+	}
+	ctx.SetExecuteTimeout(5 * time.Second)
+	statuses := instancesStatus(ctx, instances, pdList, 8)
+
+	planTable := [][]string{
+		// Header
+		{"ID", "Role", "Host", "Ports", "Status", "Data Dir", "Deploy Dir", "Regions", "Leaders", "Plan"},
+	}
+	regions, leaders, stores := 0, 0, 0
+	for i, ins := range instances {
+		dataDir := "-"
+		insDirs := ins.UsedDirs()
+		deployDir := insDirs[0]
+		if len(insDirs) > 1 {
+			dataDir = insDirs[1]
+		}
+
+		regionCount, leaderCount := "-", "-"
+		if c, ok := counts[ins.ID()]; ok && ins.ComponentName() == meta.ComponentTiKV {
+			regionCount, leaderCount = strconv.Itoa(c[0]), strconv.Itoa(c[1])
+			if plans[ins.ID()] != "would-remove (tombstone)" {
+				regions += c[0]
+				leaders += c[1]
+				stores++
+			}
+		}
+
+		planTable = append(planTable, []string{
+			color.CyanString(ins.ID()),
+			ins.Role(),
+			ins.GetHost(),
+			utils.JoinInt(ins.UsedPorts(), "/"),
+			formatInstanceStatus(ins.ComponentName(), statuses[i], statusMapping),
+			dataDir,
+			deployDir,
+			regionCount,
+			leaderCount,
+			color.YellowString(plans[ins.ID()]),
+		})
+	}
+	cliutil.PrintTable(planTable, true)
+
+	if stores > 0 {
+		log.Infof("About %d regions and %d leaders would be moved off the %d TiKV stores going offline", regions, leaders, stores)
+	}
+	log.Infof("This is a dry run, nothing is changed")
+	return nil
+}
+
 func scaleIn(clusterName string, options operator.Options) error {
 	if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
-		return errors.Errorf("cannot scale-in non-exists cluster %s", clusterName)
+		return errClusterNotFound.New("cannot scale-in non-exists cluster %s", clusterName)
 	}
+	defer clearStatusCache(clusterName)
 
 	metadata, err := meta.ClusterMetadata(clusterName)
 	if err != nil {
@@ -98,12 +246,13 @@ func scaleIn(clusterName string, options operator.Options) error {
 				switch compName := instance.ComponentName(); compName {
 				case meta.ComponentGrafana, meta.ComponentPrometheus, meta.ComponentAlertManager:
 					version := meta.ComponentVersion(compName, metadata.Version)
-					tb.Download(compName, version).CopyComponent(compName, version, instance.GetHost(), deployDir)
+					arch := meta.InstanceArch(instance)
+					tb.Download(compName, arch, version).CopyComponent(compName, arch, version, instance.GetHost(), deployDir)
 				}
 			}
 
 			t := tb.InitConfig(clusterName,
-				metadata.Version,
+				metadata.RoleVersion(instance.ComponentName()),
 				instance,
 				metadata.User,
 				meta.DirPaths{
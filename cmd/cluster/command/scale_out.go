@@ -36,11 +36,15 @@ type scaleOutOptions struct {
 	user         string // username to login to the SSH server
 	identityFile string // path to the private key file
 	usePassword  bool   // use password instead of identity file for ssh connection
+	autoScaling  bool   // the scale out is triggered by an autoscaler
+	resume       bool   // skip the tasks finished by the last failed scale out
+	concurrency  int    // the max number of instances to copy files and push the config to concurrently
 }
 
 func newScaleOutCmd() *cobra.Command {
 	opt := scaleOutOptions{
 		identityFile: filepath.Join(utils.UserHome(), ".ssh", "id_rsa"),
+		concurrency:  defaultDeployConcurrency,
 	}
 	cmd := &cobra.Command{
 		Use:          "scale-out <cluster-name> <topology.yaml>",
@@ -52,20 +56,25 @@ func newScaleOutCmd() *cobra.Command {
 			}
 
 			logger.EnableAuditLog()
-			return scaleOut(args[0], args[1], opt)
+			err := scaleOut(args[0], args[1], opt)
+			auditOperation(args[0], "scale-out", nil, err)
+			return err
 		},
 	}
 
 	cmd.Flags().StringVar(&opt.user, "user", utils.CurrentUser(), "The user name to login via SSH. The user must has root (or sudo) privilege.")
 	cmd.Flags().StringVarP(&opt.identityFile, "identity_file", "i", opt.identityFile, "The path of the SSH identity file. If specified, public key authentication will be used.")
 	cmd.Flags().BoolVarP(&opt.usePassword, "password", "p", false, "Use password of target hosts. If specified, password authentication will be used.")
+	cmd.Flags().BoolVar(&opt.autoScaling, "auto-scaling", false, "Mark the new instances as created by auto-scaling.")
+	cmd.Flags().BoolVar(&opt.resume, "resume", false, "Resume the last failed scale-out of the cluster with the same topology, skipping the tasks already finished")
+	cmd.Flags().IntVar(&opt.concurrency, "concurrency", opt.concurrency, "The max number of instances to copy files and push the config to concurrently")
 
 	return cmd
 }
 
 func scaleOut(clusterName, topoFile string, opt scaleOutOptions) error {
 	if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
-		return errors.Errorf("cannot scale-out non-exists cluster %s", clusterName)
+		return errClusterNotFound.New("cannot scale-out non-exists cluster %s", clusterName)
 	}
 
 	var newPart meta.TopologySpecification
@@ -73,17 +82,27 @@ func scaleOut(clusterName, topoFile string, opt scaleOutOptions) error {
 		return err
 	}
 
+	return scaleOutTopology(clusterName, &newPart, opt, !skipConfirm)
+}
+
+// scaleOutTopology deploys and starts the instances in newPart, and merges
+// them into the topology of the cluster
+func scaleOutTopology(clusterName string, newPart *meta.TopologySpecification, opt scaleOutOptions, confirm bool) error {
+	defer clearStatusCache(clusterName)
 	metadata, err := meta.ClusterMetadata(clusterName)
 	if err != nil {
 		return err
 	}
 
 	// Abort scale out operation if the merged topology is invalid
-	mergedTopo := metadata.Topology.Merge(&newPart)
-	if err := mergedTopo.Validate(); err != nil {
+	mergedTopo := metadata.Topology.Merge(newPart)
+	if err := validateTopology(mergedTopo); err != nil {
 		return err
 	}
 
+	if err := logDuplicateDeployment(clusterName, mergedTopo); err != nil {
+		return err
+	}
 	if err := prepare.CheckClusterPortConflict(clusterName, mergedTopo); err != nil {
 		return err
 	}
@@ -97,9 +116,9 @@ func scaleOut(clusterName, topoFile string, opt scaleOutOptions) error {
 			patchedComponents.Insert(instance.ComponentName())
 		}
 	})
-	if !skipConfirm {
+	if confirm {
 		// patchedComponents are components that have been patched and overwrited
-		if err := confirmTopology(clusterName, metadata.Version, &newPart, patchedComponents); err != nil {
+		if err := confirmTopology(clusterName, metadata.Version, newPart, patchedComponents); err != nil {
 			return err
 		}
 	}
@@ -114,19 +133,50 @@ func scaleOut(clusterName, topoFile string, opt scaleOutOptions) error {
 		return err
 	}
 
+	// detect the arch of the new hosts, the instances on the deployed hosts
+	// share the arch of the existing ones
+	newHosts := map[string]int{} // host -> ssh-port
+	deployedHosts := set.NewStringSet()
+	metadata.Topology.IterInstance(func(inst meta.Instance) {
+		deployedHosts.Insert(inst.GetHost())
+	})
+	newPart.IterInstance(func(inst meta.Instance) {
+		if !deployedHosts.Exist(inst.GetHost()) {
+			newHosts[inst.GetHost()] = inst.GetSSHPort()
+		}
+	})
+	for host := range deployedHosts {
+		if err := newPart.SetHostArch(host, metadata.Topology.HostArch(host)); err != nil {
+			return err
+		}
+	}
+	if err := detectArch(newPart, newHosts, opt.user, sshConnProps); err != nil {
+		return err
+	}
+	mergedTopo = metadata.Topology.Merge(newPart)
+
 	// Build the scale out tasks
-	t, err := buildScaleOutTask(clusterName, metadata, mergedTopo, opt, sshConnProps, &newPart, patchedComponents)
+	t, err := buildScaleOutTask(clusterName, metadata, mergedTopo, opt, sshConnProps, newPart, patchedComponents)
 	if err != nil {
 		return err
 	}
 
-	if err := t.Execute(task.NewContext()); err != nil {
+	cp, err := newCheckpoint(clusterName, "scale-out", opt.resume)
+	if err != nil {
+		return err
+	}
+	ctx := task.NewContext()
+	ctx.SetCheckpoint(cp)
+	if err := t.Execute(ctx); err != nil {
 		if errorx.Cast(err) != nil {
 			// FIXME: Map possible task errors and give suggestions.
 			return err
 		}
 		return errors.Trace(err)
 	}
+	if err := cp.Clear(); err != nil {
+		return err
+	}
 
 	log.Infof("Scaled cluster `%s` out successfully", clusterName)
 
@@ -193,11 +243,11 @@ func buildScaleOutTask(
 	})
 
 	// Download missing component
-	downloadCompTasks = convertStepDisplaysToTasks(prepare.BuildDownloadCompTasks(metadata.Version, newPart))
+	downloadCompTasks = convertStepDisplaysToTasks(prepare.BuildDownloadCompTasks(metadata.Version, metadata.ComponentVersions, newPart))
 
 	// Deploy the new topology and refresh the configuration
 	newPart.IterInstance(func(inst meta.Instance) {
-		version := meta.ComponentVersion(inst.ComponentName(), metadata.Version)
+		version := meta.ComponentVersion(inst.ComponentName(), metadata.RoleVersion(inst.ComponentName()))
 		deployDir := clusterutil.Abs(metadata.User, inst.DeployDir())
 		// data dir would be empty for components which don't need it
 		dataDir := clusterutil.Abs(metadata.User, inst.DataDir())
@@ -215,10 +265,10 @@ func buildScaleOutTask(
 		if patchedComponents.Exist(inst.ComponentName()) {
 			tb.InstallPackage(meta.ClusterPath(clusterName, meta.PatchDirName, inst.ComponentName()+".tar.gz"), inst.GetHost(), deployDir)
 		} else {
-			tb.CopyComponent(inst.ComponentName(), version, inst.GetHost(), deployDir)
+			tb.CopyComponent(inst.ComponentName(), meta.InstanceArch(inst), version, inst.GetHost(), deployDir)
 		}
 		t := tb.ScaleConfig(clusterName,
-			metadata.Version,
+			metadata.RoleVersion(inst.ComponentName()),
 			metadata.Topology,
 			inst,
 			metadata.User,
@@ -244,13 +294,14 @@ func buildScaleOutTask(
 			switch compName := inst.ComponentName(); compName {
 			case meta.ComponentGrafana, meta.ComponentPrometheus, meta.ComponentAlertManager:
 				version := meta.ComponentVersion(compName, metadata.Version)
-				tb.Download(compName, version).CopyComponent(compName, version, inst.GetHost(), deployDir)
+				arch := meta.InstanceArch(inst)
+				tb.Download(compName, arch, version).CopyComponent(compName, arch, version, inst.GetHost(), deployDir)
 			}
 		}
 
 		// Refresh all configuration
 		t := tb.InitConfig(clusterName,
-			metadata.Version,
+			metadata.RoleVersion(inst.ComponentName()),
 			inst,
 			metadata.User,
 			meta.DirPaths{
@@ -267,6 +318,7 @@ func buildScaleOutTask(
 	dlTasks, dpTasks := buildMonitoredDeployTask(
 		clusterName,
 		uninitializedHosts,
+		newPart,
 		metadata.Topology.GlobalOptions,
 		metadata.Topology.MonitoredOptions,
 		metadata.Version,
@@ -280,17 +332,22 @@ func buildScaleOutTask(
 			meta.ClusterPath(clusterName, "ssh", "id_rsa.pub")).
 		Parallel(downloadCompTasks...).
 		Parallel(envInitTasks...).
-		Parallel(deployCompTasks...).
+		ParallelWithConcurrency(opt.concurrency, deployCompTasks...).
 		// TODO: find another way to make sure current cluster started
 		ClusterSSH(metadata.Topology, metadata.User, sshTimeout).
 		ClusterOperate(metadata.Topology, operator.StartOperation, operator.Options{}).
 		ClusterSSH(newPart, metadata.User, sshTimeout).
 		Func("save meta", func() error {
 			metadata.Topology = mergedTopo
+			origin := meta.InstanceOriginManual
+			if opt.autoScaling {
+				origin = meta.InstanceOriginAutoScaling
+			}
+			metadata.StampInstances(newPart, origin)
 			return meta.SaveClusterMeta(clusterName, metadata)
 		}).
 		ClusterOperate(newPart, operator.StartOperation, operator.Options{}).
-		Parallel(refreshConfigTasks...).
+		ParallelWithConcurrency(opt.concurrency, refreshConfigTasks...).
 		ClusterOperate(metadata.Topology, operator.RestartOperation, operator.Options{Roles: []string{meta.ComponentPrometheus}}).
 		Build(), nil
 
@@ -37,12 +37,12 @@ func newStartCmd() *cobra.Command {
 			}
 
 			if err := validRoles(options.Roles); err != nil {
-				return nil
+				return err
 			}
 
 			clusterName := args[0]
 			if utils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
-				return errors.Errorf("cannot start non-exists cluster %s", clusterName)
+				return errClusterNotFound.New("cannot start non-exists cluster %s", clusterName)
 			}
 
 			return startCluster(clusterName, options)
@@ -56,11 +56,15 @@ func newStartCmd() *cobra.Command {
 
 func startCluster(clusterName string, options operator.Options) error {
 	logger.EnableAuditLog()
+	defer clearStatusCache(clusterName)
 	log.Infof("Starting cluster %s...", clusterName)
 	metadata, err := meta.ClusterMetadata(clusterName)
 	if err != nil {
 		return err
 	}
+	if err := validNodes(metadata.Topology, options.Nodes); err != nil {
+		return err
+	}
 
 	t := task.NewBuilder().
 		SSHKeySet(
@@ -0,0 +1,125 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
+)
+
+// statusCacheFileName is the file in the cluster dir caching the statuses of
+// the instances queried by the last display
+const statusCacheFileName = "status.cache"
+
+type statusCacheEntry struct {
+	Status string    `json:"status"`
+	Time   time.Time `json:"time"`
+}
+
+// cachedInstancesStatus is instancesStatus with the statuses queried within
+// the ttl by the previous display reused, the ones not cached or expired are
+// queried and saved to the cache. The cache is not read if ttl is 0.
+func cachedInstancesStatus(clusterName string, ctx *task.Context, instances []meta.Instance, pdList []string,
+	ttl time.Duration, concurrency int) []string {
+	cache := loadStatusCache(clusterName)
+	now := time.Now()
+
+	statuses := make([]string, len(instances))
+	var missed []meta.Instance
+	var missedIndexes []int
+	for i, ins := range instances {
+		if entry, ok := cache[ins.ID()]; ok && ttl > 0 && now.Sub(entry.Time) < ttl {
+			statuses[i] = entry.Status
+			continue
+		}
+		missed = append(missed, ins)
+		missedIndexes = append(missedIndexes, i)
+	}
+	if len(missed) == 0 {
+		log.Debugf("Using the cached status of all %d instances", len(instances))
+		return statuses
+	}
+
+	queried := instancesStatus(ctx, missed, pdList, concurrency)
+	for i, status := range queried {
+		statuses[missedIndexes[i]] = status
+		if status == statusUnknown {
+			// the query timed out, try again next time
+			delete(cache, missed[i].ID())
+			continue
+		}
+		cache[missed[i].ID()] = statusCacheEntry{Status: status, Time: now}
+	}
+	saveStatusCache(clusterName, cache)
+	return statuses
+}
+
+// loadStatusCache reads the status cache of the cluster, an empty one is
+// returned if it's absent or broken as it's only an optimization
+func loadStatusCache(clusterName string) map[string]statusCacheEntry {
+	cache := map[string]statusCacheEntry{}
+	data, err := ioutil.ReadFile(meta.ClusterPath(clusterName, statusCacheFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Debugf("Failed to read the status cache: %s", err)
+		}
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		log.Debugf("Ignored the broken status cache: %s", err)
+		return map[string]statusCacheEntry{}
+	}
+	return cache
+}
+
+// clearStatusCache removes the status cache of the cluster, it's called by
+// the commands changing the status of instances so that the next display
+// never shows the statuses before the change
+func clearStatusCache(clusterName string) {
+	if err := os.Remove(meta.ClusterPath(clusterName, statusCacheFileName)); err != nil && !os.IsNotExist(err) {
+		log.Debugf("Failed to remove the status cache: %s", err)
+	}
+}
+
+// saveStatusCache replaces the status cache of the cluster atomically, so
+// that the concurrent displays never read a partial one
+func saveStatusCache(clusterName string, cache map[string]statusCacheEntry) {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		log.Debugf("Failed to encode the status cache: %s", err)
+		return
+	}
+	tmp, err := ioutil.TempFile(meta.ClusterPath(clusterName), statusCacheFileName+".*")
+	if err != nil {
+		log.Debugf("Failed to write the status cache: %s", err)
+		return
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), meta.ClusterPath(clusterName, statusCacheFileName))
+	}
+	if err != nil {
+		log.Debugf("Failed to write the status cache: %s", err)
+		_ = os.Remove(tmp.Name())
+	}
+}
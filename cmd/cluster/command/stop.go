@@ -42,14 +42,18 @@ func newStopCmd() *cobra.Command {
 
 			clusterName := args[0]
 			if utils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
-				return errors.Errorf("cannot stop non-exists cluster %s", clusterName)
+				return errClusterNotFound.New("cannot stop non-exists cluster %s", clusterName)
 			}
 
 			logger.EnableAuditLog()
+			defer clearStatusCache(clusterName)
 			metadata, err := meta.ClusterMetadata(clusterName)
 			if err != nil {
 				return err
 			}
+			if err := validNodes(metadata.Topology, options.Nodes); err != nil {
+				return err
+			}
 
 			t := task.NewBuilder().
 				SSHKeySet(
@@ -75,5 +79,8 @@ func newStopCmd() *cobra.Command {
 
 	cmd.Flags().StringSliceVarP(&options.Roles, "role", "R", nil, "Only stop specified roles")
 	cmd.Flags().StringSliceVarP(&options.Nodes, "node", "N", nil, "Only stop specified nodes")
+	cmd.Flags().BoolVar(&options.SkipEvictLeader, "skip-evict-leader", false, "Stop TiKV instances without evicting their region leaders first, for emergency stops")
+	cmd.Flags().BoolVar(&options.Force, "force", false, "Stop the PD leader even if the leadership can't be transferred to another healthy member")
+	cmd.Flags().Int64Var(&options.Timeout, "transfer-timeout", 300, "Timeout in seconds when transferring PD leader and evicting TiKV store leaders")
 	return cmd
 }
@@ -0,0 +1,88 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"crypto/tls"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func newStoreStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "store-state <cluster-name> <store-address> <state>",
+		Short: "Set the state of a TiKV store manually, for emergency recovery only",
+		Long: `Set the state of a TiKV or TiFlash store through PD manually, the state is
+one of ` + strings.Join(operator.StoreStates, ", ") + `. This is for emergency
+recovery only, use scale-in to remove stores normally.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 3 {
+				return cmd.Help()
+			}
+
+			clusterName, store := args[0], args[1]
+			state, err := operator.NormalizeStoreState(args[2])
+			if err != nil {
+				return err
+			}
+			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot set store state of non-exists cluster %s", clusterName)
+			}
+
+			metadata, err := meta.ClusterMetadata(clusterName)
+			if err != nil {
+				return err
+			}
+
+			if !skipConfirm {
+				if err := cliutil.PromptForConfirmOrAbortError(
+					color.HiRedString("DANGER: ")+"This operation will set the state of store %s in `%s` to %s manually,\n"+
+						"it may cause data loss or unavailability if used wrongly.\nDo you want to continue? [y/N]:",
+					color.HiYellowString(store),
+					color.HiYellowString(clusterName),
+					color.HiRedString(state)); err != nil {
+					return err
+				}
+			}
+
+			var tlsCfg *tls.Config
+			if metadata.Topology.GlobalOptions.EnableTLS {
+				if tlsCfg, err = meta.ClusterTLSConfig(clusterName); err != nil {
+					return err
+				}
+			}
+
+			logger.EnableAuditLog()
+			err = operator.SetStoreState(metadata.Topology, store, state, tlsCfg)
+			auditOperation(clusterName, "store-state", []string{store}, err)
+			if err != nil {
+				return err
+			}
+
+			log.Infof("Set state of store %s to %s successfully, check it with `%s display %s`",
+				store, state, cliutil.OsArgs0(), clusterName)
+			return nil
+		},
+	}
+
+	return cmd
+}
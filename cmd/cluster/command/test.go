@@ -20,7 +20,6 @@ import (
 
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	"github.com/pingcap-incubator/tiup/pkg/utils"
-	"github.com/pingcap/errors"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 
@@ -40,7 +39,7 @@ func newTestCmd() *cobra.Command {
 
 			clusterName := args[0]
 			if utils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
-				return errors.Errorf("cannot start non-exists cluster %s", clusterName)
+				return errClusterNotFound.New("cannot start non-exists cluster %s", clusterName)
 			}
 
 			metadata, err := meta.ClusterMetadata(clusterName)
@@ -62,8 +61,8 @@ func newTestCmd() *cobra.Command {
 	return cmd
 }
 
-func createDB(spec meta.TiDBSpec) (db *sql.DB, err error) {
-	dsn := fmt.Sprintf("root:@tcp(%s:%d)/?charset=utf8mb4,utf8&multiStatements=true", spec.Host, spec.Port)
+func createDB(spec meta.TiDBSpec, user, password string) (db *sql.DB, err error) {
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/?charset=utf8mb4,utf8&multiStatements=true", user, password, spec.Host, spec.Port)
 	db, err = sql.Open("mysql", dsn)
 
 	return
@@ -75,7 +74,7 @@ func writable(topo *meta.TopologySpecification) error {
 	for _, spec := range topo.TiDBServers {
 		spec := spec
 		errg.Go(func() error {
-			db, err := createDB(spec)
+			db, err := createDB(spec, "root", "")
 			if err != nil {
 				return err
 			}
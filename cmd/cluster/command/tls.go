@@ -0,0 +1,248 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/joomcode/errorx"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+)
+
+func newTLSCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tls",
+		Short: "Enable or rotate the mutual TLS between the components of a cluster",
+	}
+
+	cmd.AddCommand(
+		newTLSEnableCmd(),
+		newTLSRotateCmd(),
+	)
+	return cmd
+}
+
+func newTLSEnableCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "enable <cluster-name>",
+		Short: "Enable the mutual TLS between the components of a cluster",
+		Long: `Enable the mutual TLS between PD, TiKV, TiDB, Pump and Drainer. A CA is
+generated in the meta directory of the cluster, it issues a certificate for
+each instance, which is pushed to the tls directory in the deploy directory
+along with the config pointing at it. The instances whose certificates are
+already configured in the topology are left as they are.
+
+The whole cluster is restarted as the components can't talk to each other
+during the switch.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			clusterName := args[0]
+			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot enable TLS of non-exists cluster %s", clusterName)
+			}
+
+			metadata, err := meta.ClusterMetadata(clusterName)
+			if err != nil {
+				return err
+			}
+			if metadata.Topology.GlobalOptions.EnableTLS {
+				return errors.Errorf("TLS is already enabled for cluster %s, run `%s tls rotate %s` to reissue the certificates",
+					clusterName, cliutil.OsArgs0(), clusterName)
+			}
+			metadata.Topology.GlobalOptions.EnableTLS = true
+			if err := metadata.Topology.Validate(); err != nil {
+				return err
+			}
+
+			if !skipConfirm {
+				if err := cliutil.PromptForConfirmOrAbortError(
+					"This operation will enable TLS and restart the whole cluster %s.\nDo you want to continue? [y/N]:",
+					color.HiYellowString(clusterName)); err != nil {
+					return err
+				}
+			}
+
+			logger.EnableAuditLog()
+			err = enableTLS(clusterName, metadata)
+			auditOperation(clusterName, "tls-enable", nil, err)
+			if err != nil {
+				return err
+			}
+
+			log.Infof("Enabled TLS of cluster `%s` successfully", clusterName)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// enableTLS pushes the certificates and the config to all instances and
+// restarts the cluster, the meta is saved once the config is pushed so that
+// a failed restart can be retried by restart
+func enableTLS(clusterName string, metadata *meta.ClusterMeta) error {
+	t := task.NewBuilder().
+		SSHKeySet(
+			meta.ClusterPath(clusterName, "ssh", "id_rsa"),
+			meta.ClusterPath(clusterName, "ssh", "id_rsa.pub")).
+		ClusterSSH(metadata.Topology, metadata.User, sshTimeout).
+		Parallel(buildRefreshConfigTasks(clusterName, metadata, nil)...).
+		Build()
+	if err := t.Execute(task.NewContext()); err != nil {
+		if errorx.Cast(err) != nil {
+			return err
+		}
+		return errors.Trace(err)
+	}
+
+	if err := meta.SaveClusterMeta(clusterName, metadata); err != nil {
+		return err
+	}
+	if err := setupStatusTLS(clusterName, metadata); err != nil {
+		return err
+	}
+
+	t = task.NewBuilder().
+		SSHKeySet(
+			meta.ClusterPath(clusterName, "ssh", "id_rsa"),
+			meta.ClusterPath(clusterName, "ssh", "id_rsa.pub")).
+		ClusterSSH(metadata.Topology, metadata.User, sshTimeout).
+		ClusterOperate(metadata.Topology, operator.RestartOperation, operator.Options{}).
+		Build()
+	if err := t.Execute(task.NewContext()); err != nil {
+		if errorx.Cast(err) != nil {
+			return err
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func newTLSRotateCmd() *cobra.Command {
+	var options operator.Options
+
+	cmd := &cobra.Command{
+		Use:   "rotate <cluster-name>",
+		Short: "Reissue the certificates of the instances and restart them one by one",
+		Long: `Reissue the certificates of the instances by the CA of the cluster, push them
+to the hosts and restart the instances one by one. The CA itself is kept so
+that the instances with old and new certificates trust each other during
+the rolling restart.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+
+			if err := validRoles(options.Roles); err != nil {
+				return err
+			}
+
+			clusterName := args[0]
+			if tiuputils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
+				return errClusterNotFound.New("cannot rotate certificates of non-exists cluster %s", clusterName)
+			}
+
+			metadata, err := meta.ClusterMetadata(clusterName)
+			if err != nil {
+				return err
+			}
+			if !metadata.Topology.GlobalOptions.EnableTLS {
+				return errors.Errorf("TLS is not enabled for cluster %s, run `%s tls enable %s` first",
+					clusterName, cliutil.OsArgs0(), clusterName)
+			}
+
+			logger.EnableAuditLog()
+			err = rotateCerts(clusterName, metadata, options)
+			auditOperation(clusterName, "tls-rotate", options.Nodes, err)
+			if err != nil {
+				return err
+			}
+
+			log.Infof("Rotated certificates of cluster `%s` successfully", clusterName)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&options.Roles, "role", "R", nil, "Only rotate the certificates of specified roles")
+	cmd.Flags().StringSliceVarP(&options.Nodes, "node", "N", nil, "Only rotate the certificates of specified nodes")
+	cmd.Flags().Int64Var(&options.Timeout, "transfer-timeout", 300, "Timeout in seconds when transferring PD and TiKV store leaders")
+
+	return cmd
+}
+
+// rotateCerts removes the issued certificates of the instances so that they
+// are reissued when the config is pushed, and rolling restarts the instances
+func rotateCerts(clusterName string, metadata *meta.ClusterMeta, options operator.Options) error {
+	for _, ins := range filterInstances(metadata, &displayOption{filterRole: options.Roles, filterNode: options.Nodes}) {
+		if !meta.TLSSupported(ins.ComponentName()) {
+			continue
+		}
+		certFile := meta.TLSCertPath(clusterName, ins.ComponentName(), ins.GetHost(), ins.GetPort())
+		for _, f := range []string{certFile, strings.TrimSuffix(certFile, ".crt") + ".pem"} {
+			if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+				return errors.AddStack(err)
+			}
+		}
+	}
+
+	tlsCfg, err := meta.ClusterTLSConfig(clusterName)
+	if err != nil {
+		return err
+	}
+	options.TLSConfig = tlsCfg
+	meta.SetStatusTLSConfig(tlsCfg)
+
+	t := task.NewBuilder().
+		SSHKeySet(
+			meta.ClusterPath(clusterName, "ssh", "id_rsa"),
+			meta.ClusterPath(clusterName, "ssh", "id_rsa.pub")).
+		ClusterSSH(metadata.Topology, metadata.User, sshTimeout).
+		Parallel(buildRefreshConfigTasks(clusterName, metadata, nil)...).
+		ClusterOperate(metadata.Topology, operator.RollingRestartOperation, options).
+		Build()
+	if err := t.Execute(task.NewContext()); err != nil {
+		if errorx.Cast(err) != nil {
+			return err
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// setupStatusTLS makes the status probes talk to the cluster over HTTPS with
+// the client certificate if TLS is enabled for the cluster
+func setupStatusTLS(clusterName string, metadata *meta.ClusterMeta) error {
+	if !metadata.Topology.GlobalOptions.EnableTLS {
+		return nil
+	}
+	tlsCfg, err := meta.ClusterTLSConfig(clusterName)
+	if err != nil {
+		return errors.Annotatef(err, "failed to load the TLS config of cluster %s", clusterName)
+	}
+	meta.SetStatusTLSConfig(tlsCfg)
+	return nil
+}
@@ -15,8 +15,11 @@ package command
 
 import (
 	"os"
+	"strings"
 
+	"github.com/fatih/color"
 	"github.com/joomcode/errorx"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/clusterutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/logger"
@@ -24,6 +27,7 @@ import (
 	operator "github.com/pingcap-incubator/tiup-cluster/pkg/operation"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/task"
 	"github.com/pingcap-incubator/tiup/pkg/repository"
+	"github.com/pingcap-incubator/tiup/pkg/set"
 	"github.com/pingcap-incubator/tiup/pkg/utils"
 	"github.com/pingcap/errors"
 	"github.com/spf13/cobra"
@@ -31,7 +35,11 @@ import (
 )
 
 type upgradeOptions struct {
-	options operator.Options
+	options      operator.Options
+	compVersions []string // per role version overrides in the form of role=version
+	// upgrade one instance of each role first and wait for them to be healthy
+	canary        bool
+	canaryTimeout int64 // in seconds
 }
 
 func newUpgradeCmd() *cobra.Command {
@@ -45,11 +53,16 @@ func newUpgradeCmd() *cobra.Command {
 			}
 
 			logger.EnableAuditLog()
-			return upgrade(args[0], args[1], opt)
+			err := upgrade(args[0], args[1], opt)
+			auditOperation(args[0], "upgrade", nil, err)
+			return err
 		},
 	}
 	cmd.Flags().BoolVar(&opt.options.Force, "force", false, "Force upgrade won't transfer leader")
 	cmd.Flags().Int64Var(&opt.options.Timeout, "transfer-timeout", 300, "Timeout in seconds when transferring PD and TiKV store leaders")
+	cmd.Flags().BoolVar(&opt.canary, "canary", false, "Upgrade one instance of each role first, and continue after they are healthy and it's confirmed")
+	cmd.Flags().Int64Var(&opt.canaryTimeout, "canary-timeout", 300, "Timeout in seconds to wait for each canary instance to be healthy")
+	cmd.Flags().StringSliceVar(&opt.compVersions, "component-version", nil, "Override the version of specified roles, in the form of role=version, an empty version removes the override")
 
 	return cmd
 }
@@ -72,7 +85,7 @@ func versionCompare(curVersion, newVersion string) error {
 
 func upgrade(clusterName, clusterVersion string, opt upgradeOptions) error {
 	if utils.IsNotExist(meta.ClusterPath(clusterName, meta.MetaFileName)) {
-		return errors.Errorf("cannot upgrade non-exists cluster %s", clusterName)
+		return errClusterNotFound.New("cannot upgrade non-exists cluster %s", clusterName)
 	}
 
 	metadata, err := meta.ClusterMetadata(clusterName)
@@ -80,25 +93,213 @@ func upgrade(clusterName, clusterVersion string, opt upgradeOptions) error {
 		return err
 	}
 
-	var (
-		downloadCompTasks []task.Task // tasks which are used to download components
-		copyCompTasks     []task.Task // tasks which are used to copy components to remote host
+	// the cluster version is kept if only some roles are upgraded
+	if clusterVersion != metadata.Version || len(opt.compVersions) == 0 {
+		if err := versionCompare(metadata.Version, clusterVersion); err != nil {
+			return err
+		}
+	}
+
+	compVersions, err := parseComponentVersions(metadata.ComponentVersions, opt.compVersions)
+	if err != nil {
+		return err
+	}
+
+	ids, err := upgradedInstances(metadata, clusterVersion, compVersions)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return errors.Errorf("the versions of all roles of cluster %s are unchanged, nothing to upgrade", clusterName)
+	}
+	total := 0
+	metadata.Topology.IterInstance(func(meta.Instance) { total++ })
+	if len(ids) == total {
+		// the whole cluster is upgraded
+		ids = nil
+	}
+
+	// record the current versions before touching anything, so that a failed
+	// upgrade can be rolled back as well
+	metadata.PrevVersion = metadata.Version
+	metadata.PrevComponentVersions = metadata.ComponentVersions
+	if err := meta.SaveClusterMeta(clusterName, metadata); err != nil {
+		return errors.Trace(err)
+	}
+
+	if opt.canary {
+		err = upgradeWithCanary(clusterName, metadata, clusterVersion, compVersions, opt, ids)
+	} else {
+		err = upgradeInstances(clusterName, metadata, clusterVersion, compVersions, opt.options, ids)
+	}
+	if err != nil {
+		log.Warnf("Run `%s rollback %s` to roll back to %s if needed", cliutil.OsArgs0(), clusterName, metadata.PrevVersion)
+		return err
+	}
+
+	metadata.Version = clusterVersion
+	metadata.ComponentVersions = compVersions
+	metadata.ClearPatches()
+	if err := meta.SaveClusterMeta(clusterName, metadata); err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.RemoveAll(meta.ClusterPath(clusterName, "patch")); err != nil {
+		return errors.Trace(err)
+	}
+
+	log.Infof("Upgraded cluster `%s` successfully", clusterName)
+
+	return nil
+}
+
+// upgradedInstances returns the IDs of the instances whose versions are
+// changed by the upgrade, no role can be downgraded
+func upgradedInstances(metadata *meta.ClusterMeta, clusterVersion string, compVersions map[string]string) ([]string, error) {
+	var ids []string
+	for _, comp := range metadata.Topology.ComponentsByStartOrder() {
+		from := meta.ComponentVersion(comp.Name(), metadata.RoleVersion(comp.Name()))
+		to := meta.ComponentVersion(comp.Name(), meta.RoleVersion(comp.Name(), clusterVersion, compVersions))
+		if from == to && !to.IsNightly() {
+			continue
+		}
+		if err := versionCompare(string(from), string(to)); err != nil {
+			return nil, errors.Annotatef(err, "cannot upgrade %s", comp.Name())
+		}
+		for _, inst := range comp.Instances() {
+			ids = append(ids, inst.ID())
+		}
+	}
+	return ids, nil
+}
+
+// upgradeInstances replaces the binaries of the instances with the ones of
+// the new versions and restarts them, all instances are upgraded if ids is nil
+func upgradeInstances(
+	clusterName string,
+	metadata *meta.ClusterMeta,
+	clusterVersion string,
+	compVersions map[string]string,
+	options operator.Options,
+	ids []string,
+) error {
+	var filter func(meta.Instance) bool
+	if ids != nil {
+		idSet := set.NewStringSet(ids...)
+		filter = func(inst meta.Instance) bool { return idSet.Exist(inst.ID()) }
+		options.Nodes = ids
+	}
+
+	downloadCompTasks, copyCompTasks, err := buildUpgradeCompTasks(clusterName, metadata, clusterVersion, compVersions, filter)
+	if err != nil {
+		return err
+	}
+
+	t := task.NewBuilder().
+		SSHKeySet(
+			meta.ClusterPath(clusterName, "ssh", "id_rsa"),
+			meta.ClusterPath(clusterName, "ssh", "id_rsa.pub")).
+		ClusterSSH(metadata.Topology, metadata.User, sshTimeout).
+		Parallel(downloadCompTasks...).
+		Parallel(copyCompTasks...).
+		ClusterOperate(metadata.Topology, operator.UpgradeOperation, options).
+		Build()
+
+	if err := t.Execute(task.NewContext()); err != nil {
+		if errorx.Cast(err) != nil {
+			// FIXME: Map possible task errors and give suggestions.
+			return err
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// upgradeWithCanary upgrades the first instance of each role and waits for
+// them to be healthy, the rest are upgraded after being confirmed. The rest
+// are left untouched if any canary fails.
+func upgradeWithCanary(
+	clusterName string,
+	metadata *meta.ClusterMeta,
+	clusterVersion string,
+	compVersions map[string]string,
+	opt upgradeOptions,
+	ids []string, // the instances to upgrade, all if nil
+) error {
+	var canaries []meta.Instance
+	var canaryIDs, restIDs []string
+	upgraded := set.NewStringSet(ids...)
+	for _, comp := range metadata.Topology.ComponentsByStartOrder() {
+		first := true
+		for _, inst := range comp.Instances() {
+			if ids != nil && !upgraded.Exist(inst.ID()) {
+				continue
+			}
+			if first {
+				canaries = append(canaries, inst)
+				canaryIDs = append(canaryIDs, inst.ID())
+				first = false
+			} else {
+				restIDs = append(restIDs, inst.ID())
+			}
+		}
+	}
 
-		uniqueComps = map[componentInfo]struct{}{}
-	)
+	log.Infof("Upgrading canary instances %v", canaryIDs)
+	if err := upgradeInstances(clusterName, metadata, clusterVersion, compVersions, opt.options, canaryIDs); err != nil {
+		return errors.Annotate(err, "failed to upgrade canary instances, the others are untouched")
+	}
 
-	if err := versionCompare(metadata.Version, clusterVersion); err != nil {
+	ctx, err := newDisplayContext(clusterName, metadata, sshTimeout)
+	if err != nil {
 		return err
 	}
+	log.Infof("Waiting for canary instances to be healthy, timeout %ds", opt.canaryTimeout)
+	if err := operator.WaitInstancesUp(ctx, metadata.Topology, canaries, opt.canaryTimeout); err != nil {
+		return errors.Annotate(err, "canary instances are not healthy, the others are untouched")
+	}
+
+	if len(restIDs) == 0 {
+		return nil
+	}
+	if !skipConfirm {
+		if err := cliutil.PromptForConfirmOrAbortError(
+			"Canary instances %s are upgraded and healthy.\nDo you want to upgrade the other %d instances? [y/N]:",
+			color.HiYellowString(strings.Join(canaryIDs, ",")),
+			len(restIDs)); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Upgrading the other instances")
+	return upgradeInstances(clusterName, metadata, clusterVersion, compVersions, opt.options, restIDs)
+}
+
+// buildUpgradeCompTasks builds the tasks to download the components of the
+// given versions and copy them to the hosts, the binaries being replaced are
+// backed up. Only the instances accepted by filter are included, all are if
+// filter is nil.
+func buildUpgradeCompTasks(
+	clusterName string,
+	metadata *meta.ClusterMeta,
+	clusterVersion string,
+	compVersions map[string]string,
+	filter func(meta.Instance) bool,
+) (downloadCompTasks, copyCompTasks []task.Task, err error) {
+	uniqueComps := map[componentInfo]struct{}{}
 
 	for _, comp := range metadata.Topology.ComponentsByStartOrder() {
 		for _, inst := range comp.Instances() {
-			version := meta.ComponentVersion(inst.ComponentName(), clusterVersion)
+			if filter != nil && !filter(inst) {
+				continue
+			}
+			roleVersion := meta.RoleVersion(inst.ComponentName(), clusterVersion, compVersions)
+			version := meta.ComponentVersion(inst.ComponentName(), roleVersion)
 			if version == "" {
-				return errors.Errorf("unsupported component: %v", inst.ComponentName())
+				return nil, nil, errors.Errorf("unsupported component: %v", inst.ComponentName())
 			}
 			compInfo := componentInfo{
 				component: inst.ComponentName(),
+				arch:      meta.InstanceArch(inst),
 				version:   version,
 			}
 
@@ -106,7 +307,7 @@ func upgrade(clusterName, clusterVersion string, opt upgradeOptions) error {
 			if _, found := uniqueComps[compInfo]; !found {
 				uniqueComps[compInfo] = struct{}{}
 				t := task.NewBuilder().
-					Download(inst.ComponentName(), version).
+					Download(compInfo.component, compInfo.arch, version).
 					Build()
 				downloadCompTasks = append(downloadCompTasks, t)
 			}
@@ -122,14 +323,14 @@ func upgrade(clusterName, clusterVersion string, opt upgradeOptions) error {
 			if inst.IsImported() {
 				switch inst.ComponentName() {
 				case meta.ComponentPrometheus, meta.ComponentGrafana, meta.ComponentAlertManager:
-					tb.CopyComponent(inst.ComponentName(), version, inst.GetHost(), deployDir)
+					tb.CopyComponent(inst.ComponentName(), compInfo.arch, version, inst.GetHost(), deployDir)
 				default:
-					tb.BackupComponent(inst.ComponentName(), metadata.Version, inst.GetHost(), deployDir).
-						CopyComponent(inst.ComponentName(), version, inst.GetHost(), deployDir)
+					tb.BackupComponent(inst.ComponentName(), metadata.RoleVersion(inst.ComponentName()), inst.GetHost(), deployDir).
+						CopyComponent(inst.ComponentName(), compInfo.arch, version, inst.GetHost(), deployDir)
 				}
 				tb.InitConfig(
 					clusterName,
-					clusterVersion,
+					roleVersion,
 					inst,
 					metadata.User,
 					meta.DirPaths{
@@ -140,40 +341,12 @@ func upgrade(clusterName, clusterVersion string, opt upgradeOptions) error {
 					},
 				)
 			} else {
-				tb.BackupComponent(inst.ComponentName(), metadata.Version, inst.GetHost(), deployDir).
-					CopyComponent(inst.ComponentName(), version, inst.GetHost(), deployDir)
+				tb.BackupComponent(inst.ComponentName(), metadata.RoleVersion(inst.ComponentName()), inst.GetHost(), deployDir).
+					CopyComponent(inst.ComponentName(), compInfo.arch, version, inst.GetHost(), deployDir)
 			}
 			copyCompTasks = append(copyCompTasks, tb.Build())
 		}
 	}
 
-	t := task.NewBuilder().
-		SSHKeySet(
-			meta.ClusterPath(clusterName, "ssh", "id_rsa"),
-			meta.ClusterPath(clusterName, "ssh", "id_rsa.pub")).
-		ClusterSSH(metadata.Topology, metadata.User, sshTimeout).
-		Parallel(downloadCompTasks...).
-		Parallel(copyCompTasks...).
-		ClusterOperate(metadata.Topology, operator.UpgradeOperation, opt.options).
-		Build()
-
-	if err := t.Execute(task.NewContext()); err != nil {
-		if errorx.Cast(err) != nil {
-			// FIXME: Map possible task errors and give suggestions.
-			return err
-		}
-		return errors.Trace(err)
-	}
-
-	metadata.Version = clusterVersion
-	if err := meta.SaveClusterMeta(clusterName, metadata); err != nil {
-		return errors.Trace(err)
-	}
-	if err := os.RemoveAll(meta.ClusterPath(clusterName, "patch")); err != nil {
-		return errors.Trace(err)
-	}
-
-	log.Infof("Upgraded cluster `%s` successfully", clusterName)
-
-	return nil
+	return downloadCompTasks, copyCompTasks, nil
 }
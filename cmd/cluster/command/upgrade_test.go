@@ -3,7 +3,9 @@ package command
 import (
 	"testing"
 
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	"github.com/pingcap/check"
+	"gopkg.in/yaml.v2"
 )
 
 func Test(t *testing.T) {
@@ -29,3 +31,53 @@ func (s *upgradeSuite) TestVersionCompare(c *check.C) {
 	err = versionCompare("nightly", "nightly")
 	c.Assert(err, check.IsNil)
 }
+
+func (s *upgradeSuite) TestParseComponentVersions(c *check.C) {
+	overrides, err := parseComponentVersions(nil, []string{"tikv=v4.0.0-patch"})
+	c.Assert(err, check.IsNil)
+	c.Assert(overrides, check.DeepEquals, map[string]string{"tikv": "v4.0.0-patch"})
+
+	overrides, err = parseComponentVersions(overrides, []string{"tidb=v4.0.1", "tikv="})
+	c.Assert(err, check.IsNil)
+	c.Assert(overrides, check.DeepEquals, map[string]string{"tidb": "v4.0.1"})
+
+	overrides, err = parseComponentVersions(map[string]string{"tikv": "v4.0.0"}, []string{"tikv="})
+	c.Assert(err, check.IsNil)
+	c.Assert(overrides, check.IsNil)
+
+	_, err = parseComponentVersions(nil, []string{"tikv"})
+	c.Assert(err, check.NotNil)
+
+	_, err = parseComponentVersions(nil, []string{"unknown=v4.0.0"})
+	c.Assert(err, check.NotNil)
+}
+
+func (s *upgradeSuite) TestUpgradedInstances(c *check.C) {
+	topo := meta.TopologySpecification{}
+	err := yaml.Unmarshal([]byte(`
+tidb_servers:
+  - host: 172.16.5.138
+tikv_servers:
+  - host: 172.16.5.138
+  - host: 172.16.5.139
+pd_servers:
+  - host: 172.16.5.138
+`), &topo)
+	c.Assert(err, check.IsNil)
+	metadata := &meta.ClusterMeta{Version: "v4.0.0", Topology: &topo}
+
+	ids, err := upgradedInstances(metadata, "v4.0.0", map[string]string{"tikv": "v4.0.1"})
+	c.Assert(err, check.IsNil)
+	c.Assert(ids, check.DeepEquals, []string{"172.16.5.138:20160", "172.16.5.139:20160"})
+
+	ids, err = upgradedInstances(metadata, "v4.0.1", nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(len(ids), check.Equals, 4)
+
+	ids, err = upgradedInstances(metadata, "v4.0.0", nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(ids, check.HasLen, 0)
+
+	_, err = upgradedInstances(metadata, "v4.0.0", map[string]string{"tikv": "v3.0.0"})
+	c.Assert(err, check.NotNil)
+}
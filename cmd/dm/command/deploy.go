@@ -196,7 +196,7 @@ func deploy(clusterName, clusterVersion, topoFile string, opt deployOptions) err
 	})
 
 	// Download missing component
-	downloadCompTasks = prepare.BuildDownloadCompTasks(clusterVersion, &topo)
+	downloadCompTasks = prepare.BuildDownloadCompTasks(clusterVersion, nil, &topo)
 
 	// Deploy components to remote
 	topo.IterInstance(func(inst meta.Instance) {
@@ -214,7 +214,7 @@ func deploy(clusterName, clusterVersion, topoFile string, opt deployOptions) err
 				filepath.Join(deployDir, "bin"),
 				filepath.Join(deployDir, "conf"),
 				filepath.Join(deployDir, "scripts")).
-			CopyComponent(inst.ComponentName(), version, inst.GetHost(), deployDir).
+			CopyComponent(inst.ComponentName(), meta.InstanceArch(inst), version, inst.GetHost(), deployDir).
 			InitConfig(
 				clusterName,
 				clusterVersion,
@@ -91,7 +91,7 @@ func (c *BinlogClient) IsPumpTombstone(nodeID string) (bool, error) {
 
 // IsDrainerTombstone check if drainer is tombstone.
 func (c *BinlogClient) IsDrainerTombstone(nodeID string) (bool, error) {
-	return c.isTombstone("drainer", nodeID)
+	return c.isTombstone("drainers", nodeID)
 }
 
 func (c *BinlogClient) isTombstone(ty string, nodeID string) (bool, error) {
@@ -112,16 +112,21 @@ func (c *BinlogClient) isTombstone(ty string, nodeID string) (bool, error) {
 	return false, errors.Errorf("node not exist: %s", nodeID)
 }
 
-// nolint (unused)
-func (c *BinlogClient) pumpNodeStatus() (status []*NodeStatus, err error) {
+// PumpNodeStatus returns the status of the pumps registered in PD.
+func (c *BinlogClient) PumpNodeStatus() (status []*NodeStatus, err error) {
 	return c.nodeStatus("pumps")
 }
 
-// nolint (unused)
-func (c *BinlogClient) drainerNodeStatus() (status []*NodeStatus, err error) {
+// DrainerNodeStatus returns the status of the drainers registered in PD.
+func (c *BinlogClient) DrainerNodeStatus() (status []*NodeStatus, err error) {
 	return c.nodeStatus("drainers")
 }
 
+// Close closes the connection to PD.
+func (c *BinlogClient) Close() error {
+	return errors.AddStack(c.etcdClient.Close())
+}
+
 func (c *BinlogClient) nodeStatus(ty string) (status []*NodeStatus, err error) {
 	key := fmt.Sprintf("/tidb-binlog/v1/%s", ty)
 
@@ -0,0 +1,134 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
+	"github.com/pingcap/errors"
+)
+
+// CDCClient is an HTTP client of the TiCDC server
+type CDCClient struct {
+	addrs      []string
+	tlsEnabled bool
+	httpClient *utils.HTTPClient
+}
+
+// NewCDCClient returns a new CDCClient
+func NewCDCClient(addrs []string, timeout time.Duration, tlsConfig *tls.Config) *CDCClient {
+	return &CDCClient{
+		addrs:      addrs,
+		tlsEnabled: tlsConfig != nil,
+		httpClient: utils.NewHTTPClient(timeout, tlsConfig),
+	}
+}
+
+// GetURL builds the the client URL of CDCClient
+func (cc *CDCClient) GetURL(addr string) string {
+	httpPrefix := "http"
+	if cc.tlsEnabled {
+		httpPrefix = "https"
+	}
+	return fmt.Sprintf("%s://%s", httpPrefix, addr)
+}
+
+var (
+	cdcChangefeedsURI = "api/v1/changefeeds"
+	cdcStatusURI      = "status"
+)
+
+// CDCServerStatus is the status of a TiCDC server, the ID of the capture is
+// empty until the server is registered as a capture and serving
+type CDCServerStatus struct {
+	Version string `json:"version"`
+	ID      string `json:"id"`
+	IsOwner bool   `json:"is_owner"`
+}
+
+// CDCChangefeed is the brief of a changefeed from TiCDC's API
+type CDCChangefeed struct {
+	ID            string `json:"id"`
+	State         string `json:"state"`
+	CheckpointTSO uint64 `json:"checkpoint_tso"`
+	Error         *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Lag returns the replication lag of the changefeed, it's the duration
+// between now and the physical time of the checkpoint
+func (cf *CDCChangefeed) Lag() time.Duration {
+	// the lower 18 bits of a TSO is the logical part
+	physical := int64(cf.CheckpointTSO >> 18)
+	return time.Since(time.Unix(0, physical*int64(time.Millisecond)))
+}
+
+func (cc *CDCClient) getEndpoints(cmd string) (endpoints []string) {
+	for _, addr := range cc.addrs {
+		endpoint := fmt.Sprintf("%s/%s", cc.GetURL(addr), cmd)
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return
+}
+
+// GetStatus queries the status of the TiCDC server
+func (cc *CDCClient) GetStatus() (*CDCServerStatus, error) {
+	endpoints := cc.getEndpoints(cdcStatusURI)
+
+	status := CDCServerStatus{}
+
+	err := tryURLs(endpoints, func(endpoint string) error {
+		body, err := cc.httpClient.Get(endpoint)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(body, &status)
+	})
+
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+
+	return &status, nil
+}
+
+// GetChangefeeds queries all changefeeds of the TiCDC cluster
+func (cc *CDCClient) GetChangefeeds() ([]CDCChangefeed, error) {
+	endpoints := cc.getEndpoints(cdcChangefeedsURI)
+
+	changefeeds := []CDCChangefeed{}
+
+	err := tryURLs(endpoints, func(endpoint string) error {
+		body, err := cc.httpClient.Get(endpoint)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(body, &changefeeds)
+	})
+
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+
+	return changefeeds, nil
+}
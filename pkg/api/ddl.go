@@ -0,0 +1,88 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"go.etcd.io/etcd/clientv3"
+)
+
+// the keys TiDB saves the schema versions to in the etcd of PD
+const (
+	ddlGlobalSchemaVersionKey = "/tidb/ddl/global_schema_version"
+	ddlAllSchemaVersionsKey   = "/tidb/ddl/all_schema_versions/"
+)
+
+// DDLClient reads the DDL states of TiDB servers from the etcd of PD
+type DDLClient struct {
+	etcdClient *clientv3.Client
+}
+
+// NewDDLClient returns a new DDLClient, it must be closed after use
+func NewDDLClient(pdEndpoint []string, tlsConfig *tls.Config) (*DDLClient, error) {
+	etcdClient, err := clientv3.New(clientv3.Config{
+		Endpoints:   pdEndpoint,
+		DialTimeout: time.Second * 5,
+		TLS:         tlsConfig,
+	})
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+
+	return &DDLClient{etcdClient: etcdClient}, nil
+}
+
+// Close closes the connection to etcd
+func (c *DDLClient) Close() error {
+	return c.etcdClient.Close()
+}
+
+// GlobalSchemaVersion returns the latest schema version of the cluster
+func (c *DDLClient) GlobalSchemaVersion() (int64, error) {
+	resp, err := c.etcdClient.KV.Get(context.Background(), ddlGlobalSchemaVersionKey)
+	if err != nil {
+		return 0, errors.AddStack(err)
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, errors.New("global schema version not found")
+	}
+
+	ver, err := strconv.ParseInt(string(resp.Kvs[0].Value), 10, 64)
+	return ver, errors.AddStack(err)
+}
+
+// SchemaVersions returns the schema version each TiDB server has synced to,
+// keyed by the DDL ID of TiDB servers
+func (c *DDLClient) SchemaVersions() (map[string]int64, error) {
+	resp, err := c.etcdClient.KV.Get(context.Background(), ddlAllSchemaVersionsKey, clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+
+	versions := make(map[string]int64)
+	for _, kv := range resp.Kvs {
+		ver, err := strconv.ParseInt(string(kv.Value), 10, 64)
+		if err != nil {
+			return nil, errors.Annotatef(err, "key: %s, data: %s", string(kv.Key), string(kv.Value))
+		}
+		versions[strings.TrimPrefix(string(kv.Key), ddlAllSchemaVersionsKey)] = ver
+	}
+	return versions, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pingcap/errors"
+)
+
+// GrafanaClient is an HTTP client of the Grafana server
+type GrafanaClient struct {
+	addr       string
+	user       string
+	password   string
+	httpClient *http.Client
+}
+
+// NewGrafanaClient returns a new GrafanaClient, requests are authenticated
+// with basic auth of the user and password
+func NewGrafanaClient(addr, user, password string, timeout time.Duration) *GrafanaClient {
+	return &GrafanaClient{
+		addr:     addr,
+		user:     user,
+		password: password,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+var (
+	grafanaSearchURI    = "api/search?type=dash-db"
+	grafanaDashboardURI = "api/dashboards/uid"
+	grafanaImportURI    = "api/dashboards/db"
+)
+
+// GrafanaDashboardRef is the brief of a dashboard returned by search API
+type GrafanaDashboardRef struct {
+	UID   string `json:"uid"`
+	Title string `json:"title"`
+}
+
+func (gc *GrafanaClient) do(method, uri string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequest(method, fmt.Sprintf("http://%s/%s", gc.addr, uri), body)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	req.SetBasicAuth(gc.user, gc.password)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := gc.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 400 {
+		return nil, errors.Errorf("error requesting %s, response: %s, code %d",
+			req.URL, string(data), res.StatusCode)
+	}
+	return data, nil
+}
+
+// SearchDashboards lists all dashboards of the Grafana server
+func (gc *GrafanaClient) SearchDashboards() ([]GrafanaDashboardRef, error) {
+	data, err := gc.do(http.MethodGet, grafanaSearchURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := []GrafanaDashboardRef{}
+	if err := json.Unmarshal(data, &refs); err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return refs, nil
+}
+
+// GetDashboard returns the JSON model of the dashboard
+func (gc *GrafanaClient) GetDashboard(uid string) (json.RawMessage, error) {
+	data, err := gc.do(http.MethodGet, fmt.Sprintf("%s/%s", grafanaDashboardURI, uid), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := struct {
+		Dashboard json.RawMessage `json:"dashboard"`
+	}{}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return resp.Dashboard, nil
+}
+
+// ImportDashboard creates the dashboard from its JSON model, the existing
+// dashboard with the same uid is overwritten
+func (gc *GrafanaClient) ImportDashboard(model json.RawMessage) error {
+	dashboard := map[string]interface{}{}
+	if err := json.Unmarshal(model, &dashboard); err != nil {
+		return errors.AddStack(err)
+	}
+	// the id is allocated by the Grafana server
+	delete(dashboard, "id")
+
+	body, err := json.Marshal(map[string]interface{}{
+		"dashboard": dashboard,
+		"overwrite": true,
+	})
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	_, err = gc.do(http.MethodPost, grafanaImportURI, bytes.NewReader(body))
+	return err
+}
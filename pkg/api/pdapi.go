@@ -66,10 +66,14 @@ var (
 	pdStoresURI         = "pd/api/v1/stores"
 	pdStoreURI          = "pd/api/v1/store"
 	pdConfigURI         = "pd/api/v1/config"
+	pdScheduleConfigURI = "pd/api/v1/config/schedule"
 	pdClusterIDURI      = "pd/api/v1/cluster"
 	pdSchedulersURI     = "pd/api/v1/schedulers"
 	pdLeaderURI         = "pd/api/v1/leader"
 	pdLeaderTransferURI = "pd/api/v1/leader/transfer"
+	pdPlacementRulesURI = "pd/api/v1/config/rules"
+	pdOperatorsURI      = "pd/api/v1/operators"
+	pdRegionsCheckURI   = "pd/api/v1/regions/check"
 )
 
 type doFunc func(endpoint string) error
@@ -93,7 +97,7 @@ func tryURLs(endpoints []string, f doFunc) error {
 		break
 	}
 	if len(endpoints) > 1 && err != nil {
-		err = errors.Errorf("after trying all endpoints, no endpoint is available, the last error we met: %s", err)
+		err = errors.Annotate(err, "after trying all endpoints, no endpoint is available, the last error we met")
 	}
 	return err
 }
@@ -134,6 +138,174 @@ func (pc *PDClient) GetHealth() (*PDHealthInfo, error) {
 	return &PDHealthInfo{healths}, nil
 }
 
+// GetClusterID queries the ID of the cluster which the PD server belongs to
+func (pc *PDClient) GetClusterID() (uint64, error) {
+	endpoints := pc.getEndpoints(pdClusterIDURI)
+
+	cluster := metapb.Cluster{}
+
+	err := tryURLs(endpoints, func(endpoint string) error {
+		body, err := pc.httpClient.Get(endpoint)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(body, &cluster)
+	})
+
+	if err != nil {
+		return 0, errors.AddStack(err)
+	}
+
+	return cluster.Id, nil
+}
+
+// GetOperators queries the pending operators from PD server, each operator
+// is described as a string, e.g. "balance-leader {transfer leader: store 1 to 4} (kind:leader, ...)"
+func (pc *PDClient) GetOperators() ([]string, error) {
+	endpoints := pc.getEndpoints(pdOperatorsURI)
+
+	operators := []string{}
+
+	err := tryURLs(endpoints, func(endpoint string) error {
+		body, err := pc.httpClient.Get(endpoint)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(body, &operators)
+	})
+
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+
+	return operators, nil
+}
+
+// The states of regions which are checked by PD, see GetCheckedRegionCount
+const (
+	RegionMissPeer    = "miss-peer"
+	RegionExtraPeer   = "extra-peer"
+	RegionDownPeer    = "down-peer"
+	RegionPendingPeer = "pending-peer"
+	RegionOfflinePeer = "offline-peer"
+)
+
+// GetCheckedRegionCount queries the number of regions in the state from PD
+// server, e.g. the under-replicated ones in RegionMissPeer
+func (pc *PDClient) GetCheckedRegionCount(state string) (int, error) {
+	endpoints := pc.getEndpoints(fmt.Sprintf("%s/%s", pdRegionsCheckURI, state))
+
+	regions := struct {
+		Count int `json:"count"`
+	}{}
+
+	err := tryURLs(endpoints, func(endpoint string) error {
+		body, err := pc.httpClient.Get(endpoint)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(body, &regions)
+	})
+
+	if err != nil {
+		return 0, errors.AddStack(err)
+	}
+
+	return regions.Count, nil
+}
+
+// GetScheduleConfig queries the scheduling config of PD server, e.g. the
+// leader-schedule-limit and region-schedule-limit
+func (pc *PDClient) GetScheduleConfig() (map[string]interface{}, error) {
+	endpoints := pc.getEndpoints(pdScheduleConfigURI)
+
+	config := map[string]interface{}{}
+
+	err := tryURLs(endpoints, func(endpoint string) error {
+		body, err := pc.httpClient.Get(endpoint)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(body, &config)
+	})
+
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+
+	return config, nil
+}
+
+// UpdateScheduleConfig updates the scheduling config of PD server, only the
+// items in config are changed
+func (pc *PDClient) UpdateScheduleConfig(config map[string]interface{}) error {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	endpoints := pc.getEndpoints(pdConfigURI)
+
+	err = tryURLs(endpoints, func(endpoint string) error {
+		_, err := pc.httpClient.Post(endpoint, bytes.NewBuffer(body))
+		return err
+	})
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	log.Debugf("Updated schedule config of PD: %s", string(body))
+	return nil
+}
+
+// PlacementLabelConstraint is a label constraint of the placement rule
+type PlacementLabelConstraint struct {
+	Key    string   `json:"key"`
+	Op     string   `json:"op"`
+	Values []string `json:"values"`
+}
+
+// PlacementRule is a placement rule from PD's API
+type PlacementRule struct {
+	GroupID          string                     `json:"group_id"`
+	ID               string                     `json:"id"`
+	Index            int                        `json:"index,omitempty"`
+	Override         bool                       `json:"override,omitempty"`
+	StartKeyHex      string                     `json:"start_key"`
+	EndKeyHex        string                     `json:"end_key"`
+	Role             string                     `json:"role"`
+	Count            int                        `json:"count"`
+	LabelConstraints []PlacementLabelConstraint `json:"label_constraints,omitempty"`
+	LocationLabels   []string                   `json:"location_labels,omitempty"`
+}
+
+// GetPlacementRules queries the placement rules from PD server, an empty
+// list is returned if placement rules are not enabled
+func (pc *PDClient) GetPlacementRules() ([]PlacementRule, error) {
+	endpoints := pc.getEndpoints(pdPlacementRulesURI)
+
+	rules := []PlacementRule{}
+
+	err := tryURLs(endpoints, func(endpoint string) error {
+		body, err := pc.httpClient.Get(endpoint)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(body, &rules)
+	})
+
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+
+	return rules, nil
+}
+
 // GetStores queries the stores info from PD server
 func (pc *PDClient) GetStores() (*pdserverapi.StoresInfo, error) {
 	// Return all stores
@@ -281,6 +453,45 @@ func (pc *PDClient) EvictPDLeader(retryOpt *utils.RetryOption) error {
 	return nil
 }
 
+// TransferPDLeader transfers the PD leader to the member and waits until it
+// becomes the leader
+func (pc *PDClient) TransferPDLeader(name string, retryOpt *utils.RetryOption) error {
+	cmd := fmt.Sprintf("%s/%s", pdLeaderTransferURI, name)
+	endpoints := pc.getEndpoints(cmd)
+
+	err := tryURLs(endpoints, func(endpoint string) error {
+		_, err := pc.httpClient.Post(endpoint, nil)
+		return err
+	})
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	// wait for the transfer to complete
+	if retryOpt == nil {
+		retryOpt = &utils.RetryOption{
+			Delay:   time.Second * 2,
+			Timeout: time.Second * 60,
+		}
+	}
+	if err := utils.Retry(func() error {
+		currLeader, err := pc.GetLeader()
+		if err != nil {
+			return err
+		}
+		if currLeader.Name == name {
+			return nil
+		}
+
+		// return error by default, to make the retry work
+		log.Debugf("Still waitting for the PD leader to transfer to %s", name)
+		return errors.New("still waitting for the PD leader to transfer")
+	}, *retryOpt); err != nil {
+		return fmt.Errorf("error transferring PD leader to %s, %v", name, err)
+	}
+	return nil
+}
+
 const (
 	// pdEvictLeaderName is evict leader scheduler name.
 	pdEvictLeaderName = "evict-leader-scheduler"
@@ -514,6 +725,42 @@ func (pc *PDClient) IsTombStone(host string) (bool, error) {
 // ErrStoreNotExists represents the store not exists.
 var ErrStoreNotExists = errors.New("store not exists")
 
+// SetStoreState sets the state of the store on a (TiKV) host manually, the
+// state is one of Up, Offline and Tombstone.
+// The host parameter should be in format of IP:Port, that matches store's address
+func (pc *PDClient) SetStoreState(host, state string) error {
+	// get info of current stores
+	stores, err := pc.GetStores()
+	if err != nil {
+		return err
+	}
+
+	// get the latest store ID of host, older stores might be legacy ones
+	var storeID uint64
+	for _, storeInfo := range stores.Stores {
+		if storeInfo.Store.Address == host && storeInfo.Store.Id > storeID {
+			storeID = storeInfo.Store.Id
+		}
+	}
+	if storeID == 0 {
+		return errors.Annotatef(ErrStoreNotExists, "id: %s", host)
+	}
+
+	cmd := fmt.Sprintf("%s/%d/state?state=%s", pdStoreURI, storeID, url.QueryEscape(state))
+	endpoints := pc.getEndpoints(cmd)
+
+	err = tryURLs(endpoints, func(endpoint string) error {
+		_, err := pc.httpClient.Post(endpoint, nil)
+		return err
+	})
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	log.Debugf("Set state of store %d (%s) to %s", storeID, host, state)
+	return nil
+}
+
 // DelStore deletes stores from a (TiKV) host
 // The host parameter should be in format of IP:Port, that matches store's address
 func (pc *PDClient) DelStore(host string, retryOpt *utils.RetryOption) error {
@@ -0,0 +1,105 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
+	"github.com/pingcap/errors"
+)
+
+// TiDBClient is an HTTP client of the TiDB status server
+type TiDBClient struct {
+	addrs      []string
+	tlsEnabled bool
+	httpClient *utils.HTTPClient
+}
+
+// NewTiDBClient returns a new TiDBClient, the addrs are the status addresses
+// of TiDB servers
+func NewTiDBClient(addrs []string, timeout time.Duration, tlsConfig *tls.Config) *TiDBClient {
+	return &TiDBClient{
+		addrs:      addrs,
+		tlsEnabled: tlsConfig != nil,
+		httpClient: utils.NewHTTPClient(timeout, tlsConfig),
+	}
+}
+
+// GetURL builds the the client URL of TiDBClient
+func (tc *TiDBClient) GetURL(addr string) string {
+	httpPrefix := "http"
+	if tc.tlsEnabled {
+		httpPrefix = "https"
+	}
+	return fmt.Sprintf("%s://%s", httpPrefix, addr)
+}
+
+var (
+	tidbInfoURI = "info"
+)
+
+// TiDBServerInfo is the server info from TiDB's info API
+type TiDBServerInfo struct {
+	Version    string `json:"version"`
+	GitHash    string `json:"git_hash"`
+	DDLID      string `json:"ddl_id"`
+	IP         string `json:"ip"`
+	Port       int    `json:"listening_port"`
+	StatusPort int    `json:"status_port"`
+	IsOwner    bool   `json:"is_owner"`
+}
+
+func (tc *TiDBClient) getEndpoints(cmd string) (endpoints []string) {
+	for _, addr := range tc.addrs {
+		endpoint := fmt.Sprintf("%s/%s", tc.GetURL(addr), cmd)
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return
+}
+
+// GetInfo queries the server info of TiDB
+func (tc *TiDBClient) GetInfo() (*TiDBServerInfo, error) {
+	endpoints := tc.getEndpoints(tidbInfoURI)
+
+	info := TiDBServerInfo{}
+
+	err := tryURLs(endpoints, func(endpoint string) error {
+		body, err := tc.httpClient.Get(endpoint)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(body, &info)
+	})
+
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+
+	return &info, nil
+}
+
+// IsDDLOwner checks if the TiDB server is the current DDL owner
+func (tc *TiDBClient) IsDDLOwner() (bool, error) {
+	info, err := tc.GetInfo()
+	if err != nil {
+		return false, err
+	}
+	return info.IsOwner, nil
+}
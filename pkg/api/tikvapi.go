@@ -0,0 +1,102 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
+	"github.com/pingcap/errors"
+)
+
+// TiKVClient is an HTTP client of the TiKV status server
+type TiKVClient struct {
+	addrs      []string
+	tlsEnabled bool
+	httpClient *utils.HTTPClient
+}
+
+// NewTiKVClient returns a new TiKVClient, the addrs are the status addresses
+// of TiKV servers
+func NewTiKVClient(addrs []string, timeout time.Duration, tlsConfig *tls.Config) *TiKVClient {
+	return &TiKVClient{
+		addrs:      addrs,
+		tlsEnabled: tlsConfig != nil,
+		httpClient: utils.NewHTTPClient(timeout, tlsConfig),
+	}
+}
+
+// GetURL builds the the client URL of TiKVClient
+func (tc *TiKVClient) GetURL(addr string) string {
+	httpPrefix := "http"
+	if tc.tlsEnabled {
+		httpPrefix = "https"
+	}
+	return fmt.Sprintf("%s://%s", httpPrefix, addr)
+}
+
+var (
+	tikvConfigURI = "config"
+)
+
+// tikvConfig is the part of the config from TiKV's config API we care about
+type tikvConfig struct {
+	PD struct {
+		Endpoints []string `json:"endpoints"`
+	} `json:"pd"`
+}
+
+func (tc *TiKVClient) getEndpoints(cmd string) (endpoints []string) {
+	for _, addr := range tc.addrs {
+		endpoint := fmt.Sprintf("%s/%s", tc.GetURL(addr), cmd)
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return
+}
+
+// GetPDEndpoints queries the PD endpoints TiKV is connected to from its
+// running config, in the form of host:port
+func (tc *TiKVClient) GetPDEndpoints() ([]string, error) {
+	endpoints := tc.getEndpoints(tikvConfigURI)
+
+	config := tikvConfig{}
+
+	err := tryURLs(endpoints, func(endpoint string) error {
+		body, err := tc.httpClient.Get(endpoint)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(body, &config)
+	})
+
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+
+	var pdAddrs []string
+	for _, addr := range config.PD.Endpoints {
+		addr = strings.TrimPrefix(strings.TrimPrefix(addr, "http://"), "https://")
+		pdAddrs = append(pdAddrs, addr)
+	}
+	if len(pdAddrs) == 0 {
+		return nil, errors.New("no PD endpoints in the config of TiKV")
+	}
+	return pdAddrs, nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/joomcode/errorx"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/colorutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/errutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup/pkg/localdata"
 	"github.com/spf13/cobra"
 )
@@ -42,9 +43,9 @@ var templateFuncs = template.FuncMap{
 func args() []string {
 	if wd := os.Getenv(localdata.EnvNameWorkDir); wd != "" {
 		// FIXME: We should use TiUp's arg0 instead of hardcode
-		return append([]string{"tiup cluster"}, os.Args[1:]...)
+		return append([]string{"tiup cluster"}, log.RedactArgs(os.Args[1:])...)
 	}
-	return os.Args
+	return log.RedactArgs(os.Args)
 }
 
 // OsArgs return the whole command line that user inputs, e.g. tiops deploy --xxx, or tiup cluster deploy --xxx
@@ -271,19 +271,29 @@ Please change to use another port or another host.
 	return nil
 }
 
-// BuildDownloadCompTasks build download component tasks
-func BuildDownloadCompTasks(version string, topo meta.Specification) []*task.StepDisplay {
+// BuildDownloadCompTasks build download component tasks, the versions in
+// overrides take precedence over the cluster version for their roles
+func BuildDownloadCompTasks(version string, overrides map[string]string, topo meta.Specification) []*task.StepDisplay {
 	var tasks []*task.StepDisplay
 	topo.IterComponent(func(comp meta.Component) {
 		if len(comp.Instances()) < 1 {
 			return
 		}
-		version := meta.ComponentVersion(comp.Name(), version)
-		t := task.
-			NewBuilder().
-			Download(comp.Name(), version).
-			BuildAsStep(fmt.Sprintf("  - Download %s:%s", comp.Name(), version))
-		tasks = append(tasks, t)
+		version := meta.ComponentVersion(comp.Name(), meta.RoleVersion(comp.Name(), version, overrides))
+		// download the package for each arch of the hosts of the component
+		archs := map[string]struct{}{}
+		for _, inst := range comp.Instances() {
+			arch := meta.InstanceArch(inst)
+			if _, found := archs[arch]; found {
+				continue
+			}
+			archs[arch] = struct{}{}
+			t := task.
+				NewBuilder().
+				Download(comp.Name(), arch, version).
+				BuildAsStep(fmt.Sprintf("  - Download %s:%s (%s)", comp.Name(), version, arch))
+			tasks = append(tasks, t)
+		}
 	})
 	return tasks
 }
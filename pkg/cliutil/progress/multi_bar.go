@@ -30,6 +30,7 @@ type MultiBarItem struct {
 // UpdateDisplay updates the display property of this bar item.
 // This function is thread safe.
 func (i *MultiBarItem) UpdateDisplay(newDisplay *DisplayProps) {
+	i.core.printPlain(newDisplay)
 	i.core.displayProps.Store(newDisplay)
 }
 
@@ -65,6 +66,10 @@ func (b *MultiBar) AddBar(prefix string) *MultiBarItem {
 // StartRenderLoop starts the render loop.
 // This function is thread safe.
 func (b *MultiBar) StartRenderLoop() {
+	if !Enabled() {
+		fmt.Println(b.prefix)
+		return
+	}
 	b.preRender()
 	b.renderer.startRenderLoop()
 }
@@ -72,6 +77,13 @@ func (b *MultiBar) StartRenderLoop() {
 // StopRenderLoop stops the render loop.
 // This function is thread safe.
 func (b *MultiBar) StopRenderLoop() {
+	if !Enabled() {
+		for _, bar := range b.bars {
+			bar.core.renderTo(os.Stdout)
+			fmt.Println()
+		}
+		return
+	}
 	b.renderer.stopRenderLoop()
 }
 
@@ -79,6 +79,18 @@ func (b *singleBarCore) renderSpinner(w io.Writer, dp *DisplayProps) {
 	b.spinnerFrame = (b.spinnerFrame + 1) % len(spinnerText)
 }
 
+// printPlain prints the display as a line if the bars are disabled, the
+// spinner is only printed when its suffix changes to avoid flooding the output
+func (b *singleBarCore) printPlain(newDisplay *DisplayProps) {
+	if Enabled() || newDisplay.Mode != ModeSpinner || newDisplay.Suffix == "" {
+		return
+	}
+	if old := (b.displayProps.Load()).(*DisplayProps); newDisplay.Suffix == old.Suffix {
+		return
+	}
+	fmt.Printf("%s ... %s\n", newDisplay.Prefix, newDisplay.Suffix)
+}
+
 func (b *singleBarCore) renderTo(w io.Writer) {
 	dp := (b.displayProps.Load()).(*DisplayProps)
 	if dp.Mode == ModeDone || dp.Mode == ModeError {
@@ -119,12 +131,16 @@ func NewSingleBar(prefix string) *SingleBar {
 // UpdateDisplay updates the display property of this single bar.
 // This function is thread safe.
 func (b *SingleBar) UpdateDisplay(newDisplay *DisplayProps) {
+	b.core.printPlain(newDisplay)
 	b.core.displayProps.Store(newDisplay)
 }
 
 // StartRenderLoop starts the render loop.
 // This function is thread safe.
 func (b *SingleBar) StartRenderLoop() {
+	if !Enabled() {
+		return
+	}
 	b.preRender()
 	b.renderer.startRenderLoop()
 }
@@ -132,6 +148,11 @@ func (b *SingleBar) StartRenderLoop() {
 // StopRenderLoop stops the render loop.
 // This function is thread safe.
 func (b *SingleBar) StopRenderLoop() {
+	if !Enabled() {
+		b.core.renderTo(os.Stdout)
+		fmt.Println()
+		return
+	}
 	b.renderer.stopRenderLoop()
 }
 
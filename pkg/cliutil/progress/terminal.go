@@ -27,8 +27,25 @@ import (
 var (
 	termSizeWidth  = atomic.Int32{}
 	termSizeHeight = atomic.Int32{}
+
+	// isTerminal is false if the stdout is not a terminal, e.g. redirected
+	// to a file, where the bars can't be redrawn in place
+	isTerminal = atomic.Bool{}
+	disabled   = atomic.Bool{}
 )
 
+// Disable disables the bars, the final state of each bar is printed as a
+// line instead, along with a line for each change of the bar
+func Disable() {
+	disabled.Store(true)
+}
+
+// Enabled returns if the bars are rendered in place, they're disabled
+// automatically if the stdout is not a terminal
+func Enabled() bool {
+	return isTerminal.Load() && !disabled.Load()
+}
+
 func updateTerminalSize() error {
 	ws, err := unix.IoctlGetWinsize(syscall.Stdout, unix.TIOCGWINSZ)
 	if err != nil {
@@ -56,7 +73,7 @@ func clearLine(w io.Writer) {
 }
 
 func init() {
-	_ = updateTerminalSize()
+	isTerminal.Store(updateTerminalSize() == nil)
 
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGWINCH)
@@ -14,13 +14,70 @@
 package cliutil
 
 import (
+	"bytes"
 	"io/ioutil"
+	"net"
+	"os"
 
 	"github.com/ScaleFT/sshkeys"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/errutil"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
 )
 
+// EnvNameSSHKeyPassphrase is the env var of the passphrase of the SSH identity file
+const EnvNameSSHKeyPassphrase = "TIUP_CLUSTER_SSH_KEY_PASSPHRASE"
+
+// sshKeyPassphrase is used to decrypt the identity file instead of prompting
+var sshKeyPassphrase string
+
+// SetSSHKeyPassphrase sets the passphrase of the SSH identity file, so that
+// it's not prompted for
+func SetSSHKeyPassphrase(passphrase string) {
+	sshKeyPassphrase = passphrase
+}
+
+// SSHKeyPassphrase returns the passphrase set by SetSSHKeyPassphrase, or the
+// env var if it's not set
+func SSHKeyPassphrase() string {
+	if sshKeyPassphrase != "" {
+		return sshKeyPassphrase
+	}
+	return os.Getenv(EnvNameSSHKeyPassphrase)
+}
+
+// agentHasKey returns if the SSH agent holds the key of the identity file,
+// which is found by the public key next to the identity file
+func agentHasKey(identityFilePath string) bool {
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return false
+	}
+	buf, err := ioutil.ReadFile(identityFilePath + ".pub")
+	if err != nil {
+		return false
+	}
+	pub, _, _, _, err := ssh.ParseAuthorizedKey(buf)
+	if err != nil {
+		return false
+	}
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+	keys, err := agent.NewClient(conn).List()
+	if err != nil {
+		return false
+	}
+	for _, k := range keys {
+		if bytes.Equal(k.Blob, pub.Marshal()) {
+			return true
+		}
+	}
+	return false
+}
+
 var (
 	// ErrIdentityFileReadFiled is ErrIdentityFileReadFiled
 	ErrIdentityFileReadFiled = errNS.NewType("id_read_failed", errutil.ErrTraitPreCheck)
@@ -75,10 +132,23 @@ Looks like your SSH private key {{ColorKeyword}}{{.File}}{{ColorReset}} is inval
 	}
 
 	// SSH key is passphrase protected
-	passphrase := PromptForPassword("The SSH identity key is encrypted. Input its passphrase: ")
+	passphrase := SSHKeyPassphrase()
+	if passphrase == "" {
+		// Sign by the SSH agent, the key can't be used without the passphrase
+		if agentHasKey(identityFilePath) {
+			return &SSHConnectionProps{}, nil
+		}
+		passphrase = PromptForPassword("The SSH identity key is encrypted. Input its passphrase: ")
+	}
 	if _, err := sshkeys.ParseEncryptedPrivateKey(buf, []byte(passphrase)); err != nil {
 		return nil, ErrIdentityFileReadFiled.
-			Wrap(err, "Failed to decrypt SSH identity file '%s'", identityFilePath)
+			Wrap(err, "Failed to decrypt SSH identity file '%s'", identityFilePath).
+			WithProperty(SuggestionFromTemplate(`
+Please check the passphrase of your SSH identity file {{ColorKeyword}}{{.File}}{{ColorReset}}, it can be set by {{ColorKeyword}}--ssh-key-passphrase{{ColorReset}} or {{ColorKeyword}}${{.Env}}{{ColorReset}}.
+`, map[string]string{
+				"File": identityFilePath,
+				"Env":  EnvNameSSHKeyPassphrase,
+			}))
 	}
 
 	return &SSHConnectionProps{
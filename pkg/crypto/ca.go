@@ -0,0 +1,172 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/pingcap/errors"
+)
+
+const (
+	keyBits = 2048
+	// the certificates are meant to be rotated by the user, the long validity
+	// avoids a cluster breaking silently when they expire
+	certValidity = 10 * 365 * 24 * time.Hour
+)
+
+// CertificateAuthority issues the certificates of a cluster
+type CertificateAuthority struct {
+	Cert *x509.Certificate
+	Key  *rsa.PrivateKey
+}
+
+// NewCA generates a self-signed CA named by the common name
+func NewCA(commonName string) (*CertificateAuthority, error) {
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"PingCAP"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(certValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	return &CertificateAuthority{Cert: cert, Key: key}, nil
+}
+
+// LoadCA reads the CA saved by Save
+func LoadCA(certFile, keyFile string) (*CertificateAuthority, error) {
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid CA certificate %s", certFile)
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.Errorf("invalid CA key %s", keyFile)
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid CA key %s", keyFile)
+	}
+	return &CertificateAuthority{Cert: cert, Key: key}, nil
+}
+
+// Save writes the certificate and the key of the CA in PEM format
+func (ca *CertificateAuthority) Save(certFile, keyFile string) error {
+	if err := ioutil.WriteFile(certFile, ca.CertPEM(), 0644); err != nil {
+		return errors.AddStack(err)
+	}
+	if err := ioutil.WriteFile(keyFile, encodeKey(ca.Key), 0600); err != nil {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+// CertPEM returns the certificate of the CA in PEM format
+func (ca *CertificateAuthority) CertPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Cert.Raw})
+}
+
+// Sign issues a certificate for both server and client authentication, the
+// hosts are the IPs or DNS names the certificate is valid for. The
+// certificate and the key are returned in PEM format.
+func (ca *CertificateAuthority) Sign(commonName string, hosts []string) (certPEM, keyPEM []byte, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return nil, nil, errors.AddStack(err)
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"PingCAP"}},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, h)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.Cert, &key.PublicKey, ca.Key)
+	if err != nil {
+		return nil, nil, errors.AddStack(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), encodeKey(key), nil
+}
+
+// ParseCertificate parses the first certificate in the PEM data
+func ParseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no certificate found in PEM data")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	return cert, errors.AddStack(err)
+}
+
+func encodeKey(key *rsa.PrivateKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// newSerial returns a random serial number of 128 bits
+func newSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	return serial, errors.AddStack(err)
+}
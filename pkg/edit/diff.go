@@ -16,6 +16,7 @@ package edit
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/sergi/go-diff/diffmatchpatch"
 )
@@ -29,3 +30,91 @@ func ShowDiff(t1 string, t2 string, w io.Writer) {
 
 	fmt.Fprint(w, dmp.DiffPrettyText(diffs))
 }
+
+// unifiedContext is the number of unchanged lines around each hunk
+const unifiedContext = 3
+
+type diffLine struct {
+	op   diffmatchpatch.Operation
+	text string
+}
+
+// UnifiedDiff returns the line based diff from t1 to t2 in unified format,
+// name1 and name2 are used in the file headers. An empty string is returned
+// if there's no diff.
+func UnifiedDiff(t1, t2, name1, name2 string) string {
+	dmp := diffmatchpatch.New()
+	c1, c2, lines := dmp.DiffLinesToChars(t1, t2)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(c1, c2, false), lines)
+
+	var all []diffLine
+	changed := false
+	for _, d := range diffs {
+		if d.Type != diffmatchpatch.DiffEqual {
+			changed = true
+		}
+		for _, l := range strings.SplitAfter(d.Text, "\n") {
+			if l != "" {
+				all = append(all, diffLine{op: d.Type, text: l})
+			}
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", name1, name2)
+
+	// line numbers of all[i] in t1 and t2
+	pos1, pos2 := make([]int, len(all)+1), make([]int, len(all)+1)
+	for i, l := range all {
+		pos1[i+1], pos2[i+1] = pos1[i], pos2[i]
+		if l.op != diffmatchpatch.DiffInsert {
+			pos1[i+1]++
+		}
+		if l.op != diffmatchpatch.DiffDelete {
+			pos2[i+1]++
+		}
+	}
+
+	for i := 0; i < len(all); {
+		if all[i].op == diffmatchpatch.DiffEqual {
+			i++
+			continue
+		}
+		// extend the hunk until there are more than 2*context equal lines
+		start := i - unifiedContext
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(all) && j-end <= 2*unifiedContext; j++ {
+			if all[j].op != diffmatchpatch.DiffEqual {
+				end = j
+			}
+		}
+		end += unifiedContext + 1
+		if end > len(all) {
+			end = len(all)
+		}
+
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n",
+			pos1[start]+1, pos1[end]-pos1[start], pos2[start]+1, pos2[end]-pos2[start])
+		for _, l := range all[start:end] {
+			prefix := " "
+			switch l.op {
+			case diffmatchpatch.DiffDelete:
+				prefix = "-"
+			case diffmatchpatch.DiffInsert:
+				prefix = "+"
+			}
+			b.WriteString(prefix + l.text)
+			if !strings.HasSuffix(l.text, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+	return b.String()
+}
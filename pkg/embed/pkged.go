@@ -9,4 +9,4 @@ import (
 	"github.com/markbates/pkger/pkging/mem"
 )
 
-var _ = pkger.Apply(mem.UnmarshalEmbed([]byte(`1f8b08000000000000ffec7d6973a33ad6ff57e9cadbe9e90601b649d5ff852106436cd2c636dbd453b7581cc016364f8c17fcd47cf77f4962f396383de93bb76752b76eb5630b211d49e7fcceaaffbb8b97cfabf5ddfdffdd8571166dbc6ffe2af99ec6cbd077d3bfc74b7fe3b9d9eae57b166fd2bffb70b3ce662fa8f143fc72777ff77dba9ebdacbf471b7719ce63377257dfc3d5f7f58bfffdf6cebede2949ba7ac97eb8597477ff8e517cbdd3dc6476777f577ff1b0f2efeeefeebede4ddc9770965d1d61ba08bf07eecb2e5efee126418bbd7db8dfdcbbaf77fa6a85fbbed8f9ddd7bba19bf9d1ddfd3feebeddfdcfd7bb71e6c2d9dd7df6b299157fe83377bd5adedddf2d57d99778b9ce5c0867c1176f937d71b76e0c5d0fcebec4cb2fde2686c117dff5a3d9ddd73b7925c570b646fda211fd518e285ca1b71022aeefee971b08bfde3dccd2eaf364b6ceaa87ebaf4e9e18ae820d1ae7ffddfdc44a0cdd78594ef123b786bc1aae828fe9ed7bb8fa96ac02dca9317b59c77805e86f347bf7cf7ffef3ebdd33a1cf3bcec1fdf76c96a4d0cd66ebeffe6af91c87e871749ed0bfc12c7363887b5c16fb94b4f97ab78e0fb3bb7bc0b5bede25ab007da4d936db616996c6dffc91c5f8014001eaef14fb77869ad0cc3dd3baa7d86f3ccf702cdbe15a7fa33af71475f7f52e5eff11209213eaaf73fcca87d9f6ee9e6eb5db6d0068bcb833f477a7ddfe7aa7c178b9b8bbef7cbd5396abbb7b9a693374bb437dbd9bc6c1dd3d47d15fef64f409505feff40075447dbdfbe1067ff8e1ea0feaeefe1fd457fcdfff7cbdeba291aed3998fde3946ffd05ca70300cbb7b8af77da1a7dc33034cdb629aaf5cfaf77c3ebedd9b27d35c37f7ebd13dfd95e885fb2e8d2331cc5b4f84ebb7ca6dd6259a6dd01cc3fbfde8debd510e0ca5facf17c05b820cbc4527cebeb9d04dd90fc20cf96f8dfc13a755f66f8e3a8f88808f33f7833459f5cf2762e79fb643eb9e96fc14dbfdea578d2ff77f76311fedc6e3d67adfffc7a17b8995b12189db86556bfa37e140fe05f60e2df5d387bc91277e986b3976f79025fe7ea67ad4bfe4ef32da664f02ce2a517383b454fe8f63da0ee59f61bdb6e537487a5b833cefeecc2f51bac9d61e84ea762ed74c9dab936d3a6db9d0f63ed2dd0622850b1519aa5299e6579fe1a6be7da0ce8b015abaea6788db5bfd2fe3a6b3f7da6d56179becd57ac9d2c44c9db3b7f35de7ebed54f987d838797bf9fb3f09ae1d6ac959c948263169be8986536192169fdc9f9fe6a9cef9c1f55acf0ce01fbad9d48eba770152ae23034e4082a7d2db32d957265fe108842e425a3704ad1d3a9281c1c935b3896120696061d51483c46093d534a3d51d8788c9e3a099cdb969e7a803b0c509f7d2d0bfac2b30fe8c85fea2b8fd1a8a7b8bbf4647e6e9b7bf2f75ca1d55dddd659aa5b6fb20a5520ad1d4ba53c53dad86600fd850067b294f9f21e0e123e7772ff5111858367e93b0b48742047cf685c7ec2459e396d29621079b29107321d7989b4742c6529c6ddd00734e54b7c1458faca6284c86734c663d4051a972f4b079f09b67e32c26d95bce89f51a16f19a99f18cf81bc3f3cc502e52f0d2836e926696bdbd45e947e00ed24da7a601d1a863a1c146d7cb08f6c903ddb96905a8c917b0fab50cdfd4771a96e034b87e51a8cc6c2c2319d2830f794d257a10d8c34488c1c8f3d31e68e098163292d455417764c93398a3424eba03c16e39ebaf234f4642976ccfd4191554ee9072bd7d4568a0c3736e033d7e4968a4c68ece742e427d3d061d05804b4b694070218c811f46336d4133e576483b54d7ae7c9d375f18e0c8dd1b584b53316c838fac3b0ee1b6e14d9d9fab130f7fac621908dfcc7b417da26b728dfeb2552e64ce8a16de9d04b346e2cc3a52b0a8cc7186ba74be86127d3d04e24ca06111a57ea2df5ad22f7421fc08d03f6509183dc638c1da673f1d9022af7140b9e0a8c4d10fb6b15c08dcfe891976850cd774bdbdc437fa96f6c93c67f077db873c6a81d791e7de7267cacd254494fcb958d0d7aaf97184cf91e45e693060d533f17e67e6244816c2c30cd65697389de0351606c13528a2c5145ff6bc7940e81d85d166bf01c00290d443f0ce44ee8036383d604ada96b726920c3e8fcccb19b7aede141e907d16c2c406fa9d17e621c94bebeb2ad513833795a9103188897d7cf416bddd757ce583820fa54732d9e4774f497a34745ecce15d949fd046e9c5c38b8329f07f21ecd39416b8de6ee31fa0af10bdb52a122ab51206b2bc71c8581cc2f1d8bfce6013644fb0cf7b5d428a558772fe129d774521b48946bf29ba3f56582c8b5462d45ec86e8ffe1413854671144d08b09ad9c04e63e333aeb0bd1c6cf313fabd7420c967ec2d3bec4a33d06fda51379c7f48fca391474de3ab1b0712c3f2cf6d3e198463ae691aea5a65ee2577445bf9335eee2f52ad7db3539cab154609b3b34af83375a111af76042f611d93b8a1ce1b107a636772cede02c8db5d75f847662a0799fce29772c01da96deec7fa9f4f56d3187dcb13488783c5a036fcc229e7ae99996f2a06598c67dc2affc43313e91423280b241d8227d76c326df7a8a05c631d59507f8978199454e924503535ad89640399652f4d1457c6313c8701320deddd7f3c09c5eef5fec868ec901c4871d4ba306a6b60e2ceda8bf235e29ebf1c02cce9e45f874d157a6c8345ed392ffe23d251b9b60be0a03d93804225df1af2bf33be7c3ddd563fd7ef5f014aec83c30bf5b852a53c988cc0552e2825e669b7ae4f775e8c7fe237947d9267b46b47112b8c434c7f3a7c89e9478b47e5bafef40e76115067d957646ab39391702ed27682f05aba0afef9ee2ce7638e96e86227b18ccedd6701eee9e1e946d30ef6d8bfd9bd9261739c0c8d51d7a4f63bce09c7eea0ebd473878b23477697eee012e71cda0982ba2abb6b24d6ee3e0fdeccfcb33429e43f3330e8ea5621e8c64af232b196983642f5907d7d4b61ee4a167f2ffeba2711c5224cfd64f258d104fb4f4351aa322769733661d0e16dad633e9adb7d8477662acfd9c2b7000c605e1b34555fd07b2c1060ff8d95065b2b622720f1ea0330f70829770db4026fcc2cfb94360d20817714a9fe6155168cff2ee6604f8cc33f9cdc8e410af8b02196ebde570e3c8c6c166d4d4ef63be8cde89f614ebf79596d23f7acfd03655e8f5871bc78a767e2cf0cfe36ee8f675ca7f586d07871e338cd9dd60dedd0c272b6ef8b0380cf2e1b641cbc7c158b844c3cc31e9c8357718c790cffbe37d84d6a88fcebefe8ce48307f6c03147cdb317223e81fa9df52376d48fd899c8cd3d402dc5f0fffdbfbb5faa447bd0f517de6affb6027dd4b2549edb3cfdbaeeccfc1db013ba754f31f754fb5bbbc576189a6f7fa4ee4ccca2e017eace34d5a139eeaaee7c66b22453bcaa3b5f6fff0eb3689be25a6cabd29df13a7caace9faaf3efa93a1ff1965a6df64c7e1120364bd868a8c8118554d7e13262098cc0a269e7277c4ce007fabdfb58c2d582bd577f2ba2902138ec81514be90592d15dd5ed247e87d464eba0b2b3c6337e5fddda047e60f18ea14935966eabfa5bec869e6920757ef1140ba309ad4dc96f0265330216095e62cc83cb7d5336538cbb2f6cfd07ed600135f2120efaf337da63f1aaed6a7aa03e24dab1540eabd38cb0f5961a3cfa5da44224061d139b09622bce7e8c73a54927caeb0f8f4554f5bd5689b7e33e118ce00e8e89d5a0671f64a92ff10896a5ce72d1526427f2fa1a2c6872f041f46c2712a2497e712dfbfadcef56dfa3f91ed1dab70ce82f2116a9becc6f7c303dfa7d3016e0ac2f409b41ea8b3a9bd29a3298289ba148c5c518523fd17ef518b02907d178b2800fe3bcea3bbed6ce3034691a57edaa3557fa42ee0115fa71b7557df7ca9a4e7bf049cfbbad47299b2912f5f2386eae2fda23581d41b4194d28aeaf887a7b6851a7e33aa2e113247d290fddddb0ec4f8673cf6ad0a51ce77c856065d6a49f6bd2d0630c343edadf55dfa736a04ff6af903ba603fda50a1d8947fb08d183f280b6f5108c4ce04e13955f0c8d02771d792bf725405ce95b96be818fce9b571e6400de02490c35a1e97b96ba07f437aadd0180e15bec473a18689ae61080f8750e86360b3a577dc71cc7f02d34ca02c01433bc8a91ae36bf0e914e1fa1da14e0dbb5e3182dc22742fa4448bf27423ae72e354cb22d21351223f701dc7af355381ca5885d02d7d4a1bf1c62d65c6baf48a3e61e4a4bcb249132c7a2f94224261ed82f88e8cbda834569ed519fd0b3cf16556aab9c2f4f91484f3db98007b29efa408a3dd9e839e61e620d7dbe0a9d445afba06c632c5c4b8fec640f2bcb7e5c585064a4d51b6a6511943488610682210fc30276095ba2e97387a6b8b62d7d55ccabe758c2da6360cfb554fe79cc2f6c4b5bd9091ff9897ef8d5e22273d7abcd8b3fbb555e9cb52f050643bd29308eb56a0e501d067cb852cdfe5287748b6933372bd56486d4cd4a75ddfe76a59aa3780ab4412532f0327c8a8c4f91f1bb8a8c3306f3aacc5810be6df46c4b8f7cc0d37ea2c1ca822917dede2b72c19125cab6b46d60a973c77a97cca1669680da209995116f9571287e8b6ca0419f41aaa8907b4cc455f222d995ea7e6b90f3a87f75daa7f9a765d61e4061eb2f75fe794464440020e5f6b4dc3175e82df5d436b1673941ff3a451bd7d27aa537f8f4b740de1f469641b90f27f2acafaf7d0a7bc42dd7d257234a7a702cf5fcf9233aaf0aaf8a9e063292979517e617ab33e18bfbec2edd6ff1327e5b389d362e25530750dc3b7519aed5ea7ca8c197e832bf3458aac3b599ebb2e954d32033bc5997a99adfaecb306d40315c2598c82a949209b09fa2e95334fd56a2e994bdd47249c987f3abfff782dc369dc84b7ae1a8304106964abc81b12095514b8af84a1fbbd55c8c87a16e39d05f42ca957154c5cab686a1231b896d19eba03f0c7dd0292238f88dd75f845e6290689d5ca0bd047b3ea1b7c4defcd44b8283d287db602c30b6c951c4eb3f0c6d10455e1240315ccdb15997d152a4fb287d27f2fa06f4f32e916b40a76d067b30d78aacc32031d61e23648ec951e27c1dda96b0b300bd75e469f8638ce5e4223035ec31c57dcb75d44d21a7713f4e22d15e1f474b6c955ef4634aeb4fa3292d61af7b02730f70f81d683c7e02a3e6d81499cf1539dafa0c89de5164981cf5819e8971240ef42c819a8d56ede6382c40bcc03fc6ddc58caa9f7b1ead1e5f5de34bff4b4214c8d1e1d575bdb8d6a9e7cb12e5f6b500adc7147d266bc8b8b2913b6361e92752629b5ca4c85ae4c5c2219079f43de598f48ee8b7c661200a07c7d20ec443bd23915545e48e6feed3409e1e14598f15314a1d1c1123510a8e0c320e8e394ac57848f0d1b8cb13fca4435fde6f67533df597343f0048f7ed3da2bda9f4f4d44f8c391ac76cfce638d71e080ea87f4fe6977e7eadfffdd66186b7f5df0f524fde85b665501ea0d1d99adbe67e3d1b0b071be0e8988d23a2f7f22f45d4d9ceeb1b4bd7e4f0387c794f3b006eae8e8511d68119a4deb2188fec6c3d59877e45376deb2df508f547309f9362ec44a29f8ae8073f7412b876ac213a7f51205e1a3f1ad702473d155142b45f445906204a3d791afa4b6343fa651ffdbeba0d1288d71847903d50e18c596f74d9d87932cfe932cc9f2d6a7bda0e9d3f317ee7becc858963a9c0b194f0bd674184d9813cab923d8dc623f3730fecc2478287438f51884bab3f4cc579569bcec52e5fbac8d0b3389a52863b4596164e5f45381b4761d98826f89cec42740e82fe0261e8b5270ab14bf6fe569125f43789e04a9cc806c6419c67d8456601d49f12fec0679d4448e2f188c2ce63544a29f828c2d1b5eb4da54a9a4f653e0ffa348f799b84cfd92e30d5b56b0e11fe9e93083f7d8b238062a26314678d8cbf88d852e45a5ee068d7318e2ccb3d26383896f2e8c87c669b7053be776c083ce14fc218e1737c4ec858e71ea3e68ea9518df7a2b38e236b153982b689f7f0c203c64191b96d200a996de9735744dfd1916bb2a8df919f1800f15c3f177a13380cfdc4c0342da216a94096e62e337c7c02c6c649f8dc06c673739ca5fe51af9f43a3fdeef78dd893213a4f910df059413ad2e313a36e3d467f0e2c758d9e57c751633fc0c353dcd9aae3a81c63ea1f56f0b170b3fa681d447818e0282561e8013f0c80112b7d1ca50b7d46476b9e7b8c8174c167c4831cfc6eb4cf0a771f967d640d6d4b5fa1673dc4872d072afd60eb27198edac37cbabb6afb24ba676e15e3c6dfa37ddb17683bd9a736190bf412ac3b850e93a67e77d5764c0ecb2f0b042dd7ea223959ea60a83dde977e2ecc4904693751e40ccec642e29a7b28ce33fcbd05b03d13eddbb60d8c3c90f817c75a14fbf89d67bc87e621c5b6a5bd8e47aec82d2c2f4c35f28181cfb96e22ac62e48aa866334bdb7ba2b2564415bbac1d46857eaee073af2cb5bd2743ca990ce38128a4815860134b417864eb9a1a9a2fd6b9d17ef01969ed5a3a1cee566d1289dee595875eaee55534d46178e802719e917386f620a3464e226decd1aa8d23c163b43e68bd468f4f8d486fe581c2fb1447359f8e1570ebd918f1976a5e0bd7d208068977a1b22c23c1a731c622a25aba92331c89292a8f4f8c76f0243ef3804ec625c3836daaeb62cde5537a14586eadf4119e08d1d95bdb969e06c914f30d4c73832ff6a90683be8ef6269e136affa3212707891ee3bdfdbe7d114e8171c0f22ceebef7d94793d120c2901ee0cef68463d25b7fb988076237c6b2718cf7478ef9588e3f5fdb370dac380a5d6b182a093e0771213b881f21c6671adbfb2bb993409ca55086032832bf2b22f310fdcb31b50a7eb8b2d19997a5cd6c5ccbf1996950a83f3ce6c92af42bfa080bd752085f1077785c67eb55eec3b1b0b02d3db2c83a3de2685b34efc3aa31b67dea8229ea6381fb42e73b3172459e22f91fda58062afc70a23078df8becfe696e1fb4c362edcbfcd693f8836ba5f0c7a4b71b8afb853da7abbd6077118ea13974969e6261e9e5f4c1377799d35771e4ea0090df14593f78713d779fd173c4f707a2000789bf516424138dfc69599fa1515f9ffb62b81fce7d34a6dd60de6b0d0fc39d162fb60d1ef18c2348cd2e9a7bbdb68715ce20f1e77484f67cd9e70f5309f1597fa0d71ed0220fe3fc5188f9fd03456b0fc31ce1d9226b60aef4b5832763fd836f9cb1b65fed01b50a09c13ca55cc3e590ec957a4de70837bae69444bc623ecb6f5d001bfa427dc62cbcdf491f63d326fa1892f3e31a8f93ec072caf0ed5f93ed9cfa5cc7c42320f64a963f04598caf4544e4cea7ef7a983f719cc1cc4a74add50443a4f97d5e6a3dd10c9ab726e905fbba6032da4c711bda76cf313b243dad8e69e4372d0cf7f824f80c6f334f5d8c49b7e6260ac55ec3b1c76e2632c262d1d742e196313c846eee718f71c0259a2fc9c6bf03d3e777201e9a8f96cdccd35116120d41ee39b272f175284b31d59cf71d4bc352cb31220c6387d3db74dedc53147eb52b7f601247abbac6d0312094f74d6a22d5aff1aab23cca951b6c9cd1d6b88d63af241147b40ca9d02df3b16c278982fd1de523f0c624125eb262019c221bce6f585243077988f04d67083de312af4758457d0bef118a1c862407897e019f2b9f4754e8fe919aedacdbf2d606cf0593147689f95994c7345d260812bc9de1be3cfb5fc23d87aeeca06c282788c059e6f0d72beb1161c0cba4487f28b0c8920310a3db5bb6af0c734e86bd093ed4277ad74b07450be8764d520feb340b4704d36f4019f3963c1188f05e09a0653ec09920db35c9ceb5e484e977dc7a54f771a3a569462ac48302b7acfb68cd876641efab8cf51680309e904688c9c224b34591bccdb21d2bb3d9cd941b228481b8831b26792e871d43fc1154284c3fb708685443b96469175abfc05c734246bb174ac5188b02969ef407f8965ed414cb495636a2f1670b63ee44b5ff501f1ba221300edfdbe07f825da1323938bbc3ea45c533b287d63e3625b931679a240f9487f01a52eabc381286cbd64cf2972bd57dc8ad7693bc7d430ad6d930d5df41bd2d747abb603f8ad0310de6d9e759eb60d3e75c42eff93d8641e586a8af4bd9fd24b314f85d4ccc03ca7ceae93a58587f4179c8980f086963ba644a1b91ee9e7dd55db36f5cc35b9e70a5716cf123b57cdd7493b366c6412ae0bdb488cf8982b4b1b07e0ec3088f68833c619428740c43c2d0f449c5554ef03b1d09b519f7d754b301492333a3ecbfeae1e5be39de85c478e4ca75e88f7003e3fc5793cb866406c04734c9bdcb1f46717181cda3753dacf4d53638c1e8c9fac7437a1f895470b87894930f3c832282f47f2199fa5dc3169e8992ad6316d0b22bd8bfc06f9fa37c8e3df50ffdaae215b993a03e408bf1b3cc61b48ff2172d978f61303e1b618eb7348468fd11a947ab4d4f85dc1e3acf00091db00fb0e892f0feb568a0c339c55ba5bb5abb606df687ba22be2b3d50b4bff63e9f7c35995960e3d19221d65e55ac2b3c7a8956efcb414b6fe7284b0c7cec636e12233b0afed6c53837ebe787cc27636e9b9ecdb6248df1673d4372f86a9e7032ef2fbdaca63f48335423aacb6b12d9c7d47297d6d6517d966a58cc0f3b3228ad079ff5ceaa30ed65d4918ed133058245bbd445a5b4cdd1fce52ad6c3302d6c99bef1f987cee629ecae568ae812c6ddc9c4bbd9377369ea9d74e227ab30fc222c3ae6c33daf889947a4b3df292f5c6353b8f3f61c732d0fef9298c42f624a16d439e92734fce4e6075c301c6f201921f79917df9f804a4b527f38cc568a90310afe81ecbd71ef91de91d5e5c9ccf9860eb428615d9a3d3d063d4a56d72e92c91aa75b4cdfdda6302b4bf96162878d5b85ec702d3522546094ca28f5cb09922cc9d3a8025d9ac2413abc4362ba799812cf3b903a48d6ba5853fa3bb42fc7e3846fb16c942fed9c6d834d8580061b0e3f1e8353ecd3d9cadcbc120afe6ccb8e67e8d782191ab7c866869233af61761d92fe2c78f324c1ab15c0b4596628f417a1b3c609d98c84a4ceb4784dfd19c69be98274768c6a85b8ff82a4c841b4a5e31c2b20f679662fb22dabb05afc459a4c5da27ae69ac1d24178bef3d995f16bc7e89641ff99b7b7665b8c1f60ad9403c604df047cdebc85ea86586315d90ec4b731a3e8aeac2b6d4172516b67edc8d3d192eddfe284673aab0bec423fdbae0817ae427eb9f39238297f09b99496f038bf07557bce1bcec52cfc66db9a87e9ef8314ade82337f01c779264ffb79152b816d870ddcd9d46f0e3ec27aa6c39defb7d28e5a64d682681b987b2c3f4bdb2ac9ac3536785c32d21370463474c4629fed566d72662a5d4ec376cb1edaaf2cde033e23401bc064362ef7295e7fda4b701c0589cf00041be0fdb044f3e17387e649fb072a3412080324b77e02e38c12e9e09a3fb50eb16d69a94d53a7f4adf9ce7bc782fb15867a4f1add6223146186ab290c92fdc2b684409c67851dd458346dc358cf4d9cd4a10b19fc40850360507613f702d44777837881f7333aaaa4698684f9b384ede1d88f7a1b3d496588333a96fbb4b643cafb2de251c456b1cab586bd519c673bc4b702b43744fcf7990d1761ade6df27292947efc4fe0183aff45644b302ef756b3d8a6bda4b1e4598912ce4be86d68271ccfddc0334b400e14716e0372732aa7116df49efde7eeb20bc97f8b760f34713ecb70ed1fd25b7a800a024dad65b6a88379fda090f334b5b7bc08f07b1d0ab65123c107f1c7e86e88e62613347ba9c85f019c15744a669d0972524a7a122d3b4d7d753a2d7d35b87f851899d4b54e748af2ace3fb13526957f9bf48f79b79192ca18dd38e8ab910d8a71cb461c983efeec9a5ce211fb26635bea06db8e1323f711afc29fb5dc2d641dfafbc48e533efff804f6d8ef8ed61d7fb76bd8901b74237399123d73947a48160daadf0db40fd68ee540afd4c1e4fdd6c985b56b7284d725741488bb3048a43592ef157e2c2a83e04c697187ed963ee049c5886414baa8ff70d52ef55dbc3fcb364d7b7761bbbd3c4eeccf0ec4e4e25cd1fa87f81c8f85c463d4ccb6466b1c0f20c345c3b64bec397d1dcefa58e73ade1f727a4018ad394e3ca76ee90b447baa38f7d836546226843530ad7292e13d5df94c9006b2b622d53f9cad27efb701d19dab38f0c733db5cc18f897ef4ec273c85f7e3b1aeaed95658ce7513107d26f480dda87682e978664b1c4ebabbe14317dbfe3c53622db04fbd84d8021abf55eff0016c213dd2956152e89c07a4137aa46ac6fa826d317f1285cc31a58d9f77f7cac3aea33c28ec40ece6dac4d14661f15e464b6738cd2e4a9d25e29fddfca95bd830cd80c475107a2e102f9a5da713f9dde04bba9f606983f5659863f92f4b9c4faa71e0bd42c61f1571a368fd1d0ad3d1945884976616b62f2d8ef8c983fff804e868261de9aa845fe55cc187823ff51c35f6e7a4e08308af5348b6b839894f2de21e2af93088cbbd5cd91c68a7df5de3944171876d0901d22b4416dbae3dd940eb1f7b5877db159558eabd45da0ad04bf49d07e026e80f2b9f3beadb07c66280d6be1a1796794d7945f412061ed0592ffcc06bd7d2b941a1fba0b5b04d23f3005b9c55225f87a240b97d751b98c1aafc4e2be948e8bb11e759629b1a92f3d46c5cac89a4713e5e3781b24d7fa3f454ae31a775e9f7f5131efbe355dc6700cc831034e26350dfe879ec67fd197c3d31a491d2237ef55adfc572e3263c82f7d9527f2e7ce8075736726b54fb94b10e5fda28e6596e9b6aec5a7ae61b3ced273b6c03f22c0957fe6856dc1a10fdbcb0459df836401107b05b3d3ebd170be442afce6b198563a8fd98c4446edea48b13fff2c195d5ad6da90b3fe7fed7475865945ec493b50ffe72ec52657fef0f7f66fd48ce4fc2459e28f42b5b728f86415f4d6d667813de41fcd6b1f41cdb5c6509209e56d8a5a240141443d2474a4f1a8dc78281d66c00a49d3bc6f1e6f8191166d5676c93e8d536098c718b7883a252cfc6367738dddb4fe01cdb7eeabd7294127d6cf7af6d318d8a3f8f4fe8bccb461e10bb5099f7141632a5b4376c9c2ade06d3aa1a2f89ef0b72db12b01d4b9caf431354f4db20fceacb511a607cb246b876815396f3738c3d7ce81e501bbfaf4227812cf6e7f5d5ad23367c40f174e59adc2190a58d5dfa1ec7f030087f06d792b97b89b46ec6774e4adadeb49f116d8ddc130bfb2bb1af6c15196624864538ca03c3f60d1c03b7e714d9c93d40857a8df1378e357a34416df3443cd71aa5e55927b12487d57650b5e96d509b5f1bbf9fbeac925916cd36ebdbf2cb2eb42fa3f85badb73292d9bf037e42b3f75cfb9e63be0186e1d94eab755eccfae7a3f8f956a70d3af4c755b3a659866d3365cc7c87a1daad364f5d2d797ad2be9ae2d56ad6d7db5f8de33f7fa6cd712d50973c250b5106f2d3adcf40fecf40fedf2a90ff0293a963f907632a139360ed0135f270d913e1606359649c56e143b272af5948d60be2ac61171f54cf204c2fe50e3028bf8e3fd86bb87486b6f5129d5442950d609b7bbaa88e7a5259af476379728653b5b99f483b672c50b6a52e1d0bc90883c40a3cf468a55fc9e4b2f263350f1c1f62f274a1531d1c8bc4a0127c5fccbda90389e17ed81fa6c5586b7f1028aaa855a56eaa4a7b2d450c2ee6cfa945090dcfe437aea56ffdf92a549642ee21d92f4730b0862446adb7dfdae6a8c0fc157da0d257694f360e3886a6887928fc9e257d978e85f301888d181e576cf48131f700b7f073f6d15f1a6bc720f6c62a67500c36d8c7bd24ef192c61e6897ef95b8c2bda21bde4caef334b887c66b8c17d5bc3cdcca4d764ce54a832c2e2ca73946beaf1f5df32f0ea6f8934b7017a9f8a74e19377a7ed594e61fffc00eaa99eec11f69d04b244059626205dcc67485ee360dc5d06324c3c84b3452ec7658c728ef3ccdd525c66edc118eb7afcf3a8d9a7407b9670d68f9dc00db6df9c8c7bc6ac334536364e9fe68b3e5327ee6e4694fe3042186fa9153990885e7a6a037d7e6b1f635c2d513a1b4b5145f1225d1c935b3c5b54d1274c14911bba66b00a642ef592e0ac2fe273d1b18ffcd571850d1a2d4eab15e3d8f5037a6f51ad2ff512bf25c6c24955625c1d1297da21d506b5d32a7de8b7eafc3f15eff413a2bbbdf6de827f65c539e59fc717e95aacf7ead1071ac2ead02aedf4ccb0d5a4fdb46f1c5c398802d9606c0b62ba15efc91439ddda90af2a117a8c03fd6590bb964e39269bd9604ffb4c51dd9294f921bccd689cdd8755389c5439b62b0f705b1ff2eba24262998f8af8dcc203ec3210852de20701a3a601d281123e56443bac7580ba027259dee91a8dcfe81c0b9ecae09c5dda07d112f1a500481cd9bf345f56061e8c85fff54011933059854aa2e2ea9876c2b31630588cf391de0e708c4f59e2e9b2bc7918eefc8a77167a06cd177524ba5b525ea9ccf115223f91b2e6f8cbb26468ec247fa193cffa51f0f6dca95a861dd1a3bbac2a51be428b41add32d8b77e58e49e4c6d15e8aeb925055ac432d5f4293e69f2b1d90e69fad51b3f4921ef9490003a9d80f0fabd0c2b45123cfe08b751b35d7f2e03106ce5328f700a2cbf9337ad02c2d55d2a11cd753dc88ebabc7935d194f65f7b2e84eb33c59ee5802da17d0338d4df0b0c2791da30457048f3d26aaced235be3769f0a4531e8af67de1db6c29a2baae799b1fffecbe7771a5f7301c2cf6a903220ac7385284b7280f02ff3c0e8213fe71692cf5f9ecebd08b692c77fdb2a49b1c6dbd84cf9b7b00dbc2c7dd39ce6789034a91b15f8cf1134839f8ac29a1da5c93b150f28792af503886e0b822edf93ccff828b77564439af5718efe253e8acf035a375c41f6edb963795f964273657ee3316a732f62fb8b220e4347e6372a330a1b3c13627e1a7793e31cca824e058fa87252cecf58bd3ffb150fcf6d935b3ae3eec690616f04319d689ffa887966e02f3acf81f171f3dc39e25f70df42a177cc0f6e92f998a7183294310e942ed2e854a6c7356efc2bd0817c87e7d657497cb4c84ddda9b3b62d6d35457ba66f1c2ed0e678dd097f3cc289641c677b89d0aab787b6a56e1c4bbd88af2fd1bfd1df11b6be804b478149efea3137f125a99cae5b118ee3762cf555fc45f0b0f4ec54ed95e5bf07575dc71638b7918cf394ef9eefdb0b584b65905ed2fdebcceb64cf4cfb46e6f7dee03b97f7dfd97a3a7d35724daec6cf7fbdb3d7c3719b8991bfc58fcecfc3657e8bf62d8e85052a2ce54bada3d4be89a7b87b189673904b2caa911ccd87553828fa28684df09fd5c0a332bd75fa06cef332197dee4b78fe1bc7d4a812835e94416fe8a567f2a9b1d635ee20e7fa129f22fa71d61e2c6b9bcae99920e33fb1d110cc496eb37858852a20ef78451f763dc0bf38a6b12bf9cabbe600d3ad0732e858c2dbb8e91abf45fa4b89199a25578b1b3f7015fa84dfbac080be6ce4a773b974264ff6e7096fbd4156de4adf233e74cb9aeb385efa02ad3f70cdcb7728cb0bfbf49cdf8eae9ff3068d06b6e9bcd8d35aafadf4e1ba6c6ec57f9b7a5571e3494b118317dc87c1c36a0cf125ba7fea901fad435eb2bf9d63796d61ff25f533ad377a17863fe30f97f0ecc286fc5f5fcedccc87f554efbd864b1bbc25ef5e91c3379d61acdb9667fe365c9e99af60f21bf0c1ade3cac03bc635d27f0e9fdd24af7c79b47c87be7513a67cbb8f0f9421f876b17de483f055dfc0259be0f53ddb18df35dbeaedb2641758daca0112e55841347b45d7ade228a6ef927755dccf4f8cad8a4bb905ab5c5aeb49c22f9c3359fb4bce0db6cb35edd5ef384322f197a9db59ef4f196b5cbd4f3ac610a44f5cc7059dcb6bb6ce6b32bd2177f9b7e4f933be393291328b29f677b729d78b7aa346431795b2f36724eae80a8b82cec5b876ada64dac79762e8e0794b592b4676bd7c43c2af4d13907068ebd2d6a9e36d6ac3c0fc7f256257527c2a739150e16b54fe3d4fe50fbcd6ef48330599bf4adf2cf068faf587843e6820ff389985ae69734ff9774ddd16652fab61363312d6a76bc1fc7ff0a8cab420718ec53dc5d0de255faf8b06287e2e2fbbfb09f2feca1ee627690f85bc689f32e4cfab97ae72b637d638c3b9cb3dcbce6243176681f3ba4e661abb82ae4edb37e790c9bc7f04d5de13dd87c6197b2f04876feb29bd3d6fe4b9c66ebd7a3efca4665c81dc7b265c41da0d936db616996be21f08e6bb7288667ce02ef481cd26b717774abd36ed77177ede3d2ee1f573ef734f08ea5b8768766da3707de1553bc39f0ae6eff8ed2ee7c87a769aa0abcc3cb51c6dd517fb5b0bbd328bb9f08d5aabf388fc7bb3cc274117e0fdc975dbcfcc34d82167bfb70bfb97584dff78b9dd7717fff78c764fea70a1024bbfd383e70b9cabec4cb75e642380bbe789bec8bbb7563e87a70f6255e7ef136310cbef8ae1fcd9a9184ffb843aff8a31c79b8426ff90c2cfceb0416968cb30a232414fe858cfcfbcb66f9c7d1e5edebe8edf0ea6b0f553763b6a9f78558b30ccdb5c1475efa4442ac3fee66cc134edc6eb7b90ec7b3d4cd9cbe98e2cd9cbe6e7f7b883592ab0ca86ba5e375f81d2ef1a811c39508ebbac16788f57f1127bcca9e9a45d37b5b3b819b0150231f848f3e302845a4a118a63ddd1086131a3eeb3d38fe619d27708989b650446531a37469dadbff300dbe3736545e8985ceb328c0990c29e5a1470ace52d278b2804ffa615516f2220506c835ef5160e9db8119402f31725218820d75b983af8527973f8da2f24ae8f2ff91b95f97054af1a518a290e1c02a92f87812d8d47b14138375cc61f823eefeedb1af4367dccddc7137b3c7dd78c6ac3713995f96c59a4fafdb1e24fbad932b69d9c770ce26c3d1aae9a07b0a4c3ac206210bd186b4f39646669b1ae589dd6c606abbc0c4414cb8702fa129477ba6f4e4011d3e5b543818d3b848d2b5dfc95a9d0494c6825f29307d8d5caa6245d0ce85d835d9f3b9bcf67cc3d03318d365c1c90d29b249c5d86013f32701b20a0ee655503f855171606994c7a89103a61b627ca76252145ba2ec62ff1cb53771f1a68d9f181452605d93dffc98f4f2a11c8656b71acfda03fec6930de0983b5e49e0c6493ab122ed4a835d1600231e24fbd46770305ba50cfe18ab2717bd04d02e2f7b192ba1d56d0672181b4712b6aec951d860701cb4b4704d9d1d88dd856d5285f1e5a47d35a72a107fe3cb06f4e79806e83952307a82dea947deb80ed02f0b582b676b72d140d908dae5d62e7a97c96695e1e140685ebfebc27bc2d52f567631132a6fe9fd63b6473cff1d40e9ea93b57afce63de20c3d01f43d68dfb3f4b7164df16dd0069d8fbc56866b7180fd955764322d06d0d7afc86cb568d0aa2e7da9a678f55e99ebedafebc567cf743a7c8be21a7af16f728ff8275afa444b57d1d25576730b645acd152910a60b4e9d2cfc96221de545317659b324a7978ec9413fc1b5aa364a4fdf1675aea18320d368356fc21c5c934e14388f31f2020a859e896b8e8576320d4ff2382271d184630a7fe12e8a471b8c4225d6db7acf184d7abc6351ba3a5d52b1d2df7714d960d13b10bc2130a2fb37e5815d05b20115914e15918e14118bd3a10782f2becc2b3e1f0ed7067a1ca570261b73e541f99b3aef3d1ec5cd238834c5b0088939d24ec6b0671ec83b0c43fcbe81fd5ff87e89071abdfb086e2151e8994676f577594dbdf8da18af7dff06acaae33a9a319cd7beaf20c6a908bf0e599456f34e8b63b8846b9e903a270f54598ba80995ae41b77aae22ce298bc5f057dbbbf1c9f203ff66a1df685b1945e8d63b8d222dbad36e9d9bbfff55a308f3cb8c220ca0698667e8db8d22648ab71b45aaf6ef308ae03bed3ab55184a49a7f8af94f31ff1b8bf90683f9ef14ecc46ea1430709cc71379ef6f421d21f952230e8d899aea74e3f5c2ba2be73c46eebc7b8bb3949c2489d58807ea11be3e4b33a58040b3db52f2c06509be3a2a816cd3fc59d2dd2857d79d4d485778ec83d783284de521f21c179f21e2c8815916e06adacf158f1d8b8890d22e89906ff3c210991f8bd0b387ab6a856f9be11d8a78ec951d565511703c8840b81d897928a8fec332540d820f06133fa1aebe88c407b0888986aea2523bc6e47a0678c043a2ec47de5f70270007d8e0b252e9db7c1c9b5b6c7f69d0309441c129b41dc3db517946b706463f1e551a82cb376201b992fef71e137050abd092e82ad107b48bfb6879c03179ad8fece41e4dc918738615d89bbf98f398bdf3358ecb70e55168dd21636cd1f025987fe52216dff1c1b4690fc91b8ebf7d82ece9e28c10c4d31ec3bd10c0fb80efff155743eee9ef65334d3e119aac3d0b73bf38b29de8e66aaf6ef40336d9aa23a359a210bf109673ee1cc6f0d67ce18cd27a841a066624aa4ea0d164c454471ffb231df2bda2a0f2b5e11b94a48560e1ed9d81740281c3623b4717ff5f3830517110042234093d98503600ab495436ebc2f6e9daf7f6b00a0babd6c40ffe436fa0284ac7d300d07563392714f40964c478533a0bc5d1fd1b31edb0500568fbb0649757b013a967a19889540e295acd97f939567e18de9928e0dabcbfed074cc9133a123e055d0ec2a48c2d9288503a9a4eb1120aae865929be014720bdc6e30efbe62c19116d84129c3833326569f732755d341558d67e798463eb08cdceb0f790290aab53bde37c7cf450ebe99484f7d30cdb0432aa6693fd95f708e1dadfbf15c657e3930718563fe1c9c35e9492c7fcd67c94dc6f4c2b594e2597cab4a01fe8ec69a7a09a45c535a379c68fc19e85c14ef5aec539f19c58ac84975155cedb4cfd222c61796b08567f0d579c3d5ecc56ba0b3312ff8ef029fbbd5cbe27de0f3f889127c76e877861771340f7e81218dfb75863486eab401dbb93d8e14cf90ba3d8eb46a7f3bf4e428d0a6d81a7ae265f8449e9fc8f377479ec75ce63f0b79de1ce24490c3c241923139974c4d93529dbf03173371d7406d3f695aab515f25c11bdfbdc78cf6161abc8446ab5c99d7ead6fd7bc2a4f46c60e17bc2df3497393295e1daf8c0c81162b9908b990d4c9c835d209702e51f230cc663d417c75288396d4ec5050adc0c1fae9bd36c53078ea552aea5c10a419ea1ba6b3e40624a2b105cd394865070399ec2a4d67c36ddba26fb3388aa117a4472f77ee01aaaf6d691e9e7928683a5bef5cc5d389cb37fbb60e66b8c4d7df6197de1586a1962f7f867a0a917375ebe074b1db7af9014f786150f871ed1ec3dcddd53fcb7769b026dbac57f64a036c7d2e023ad78e7a1472ccdb01dee7ae811c331145f07761753bc1e7a74b5fd2ba147a7cf509d16d56ad7508afb34e27d42a9df1e4a1d33994f20858154e5577add7ce7cb23128bbb3cf6e7b9a61d3a56af005bdd5db308c3c7fa0df70767dccd8e8a5414a641f4ec05f31f7a6f0dd8f0fbb4b56b1a9b40faed805659c8e82d73db8576c7c150d84f3aa653a7881727051660ef02685a14f1d4b7f82bb3c052d7241e3aa31c93dee1cbba9b7eefb8bbd14d6ee1cbb8d8f1e1b48f7798aed61ef0b332e8ea34afa02e12776e1e3b0354755bca03f4bad9d635b9349061e489f4dc0374e65aa32ca8cc81dad633e934908a793c287ffb119fe73854fdff7b4c5ae18bfbec2edd9b41d849fbca970aa8f63bed595c876a73ed0f3768b57e5962346039a6cd0170bb418b4cf1768356d5fe1d062d966af38d7439b2109f30ec1386fdd630ec84cdfc67c1b05b3da99e992151170e2c0c374efbd9faf29e7600dcf8bb3473819efa7137f33114e27a8e25ac3d0656ba7ef3fe2c31a15f1c19e68a48ef2ede0987efd577521fc02dae679e7fc4f37a54d63ef177ab479bb93c27bcb6f96a83ef798cdf9ecb6097ceafcc197b9a06004384a2bec994a4c8fdd498df7e4f758f1e89e9bebc667d751b24f0e09a3cb937e3f4febd0441eed1c5678feffceb84034be77c791a3a71372bec68f89eef81b90895e5706b897a5b97b4e741bcca46d4de98d2ba345d525b5c0fb14c59ab6c68d836d57e1e0bfed32e4d10d4bce1bec0ad22d2c5fd9a76383009ccc477b8e11c05f530a07748dd9858d45ed5a9688ad34f993f790ce81dcca891a6c75fbc8f6780a0603fe31569d7fec8753064e3108874f53e7c47c4a2f4a8aaa5377bebe44a3863a8d07a583f8a8bbd3059f8bc6372cf8631dc18862e0f26e1498ae49f18f688796e755f5ee617b59c1a76dab50f9a510524d703db75abbbf9e88363a9e035d5c395f9ccb1842890c312e29fac3deaf7b247f972fb02c6d7f703a65eb2f8b3f22d96ab60f6fe74cb8b4fd597ffbde1393eb377f2ed0ee87cbcbdf3e390f685544bb645f1570b539cdb22c9146fb77756eddf61efec502cdf6e37eefefb741d7f02eddf1d685f6435ff9d70fb1d6996676540ff5a9529701f47e990180218b7a7559eb73f9ff35517ad1540fb4a25845702eae61ed8af1d440b46d904b2b6c3259ec5a6bb54db7af21eda8cbef5637cd53ce598f4e2c47ab7f664631ec87c3e48e86d80b31ff428e80fafbecb338d0ca76ed23c864ed7fb23e536fda5b17badbf2021191d83c4491d73bf40f3b662f67f6f481dfd33dcae6970330ea99bd639136ce72d3b1f05263473cff0f780fac6d334c5b2fc479af9389ee669f4ea5f073e3a6de67a0228d7a1381ec97ed2be9ce1d5b8b557da5f071fa7cf501d966371cdc471631d3ed1c727faf8add147cd60feb320c75fdcd17a949770b363f4b53c84d713423ffe7d7fddbc879d235eb2725c70c432c26260bd991cfa66de835d38c48f7211ce734c8e0b501d27f7be12095738c3c5a31c8533477d334aeed4417f5c900cada9d27004af2fec85db72208ec6f981fd7e787ec4cd49b94565f303b168e1fd5144f0ddead84667ebd5c45d4658588c463908c616ce6ff4fe3fc91a96067fac7d17cede01408f1f286128ae0dfd1e1b18dd625bedce4766ee121bd8c795e1be0043db4c87e36fb6811553bcd90656b7bfdd0646b36daa4db52a188ad7e113857ea2d0df1c851e73994f2cfa19f4f79b04fd5dc390174c7abe3cbae4b57b6f76c59c60c7d1ed58eb94b6afe4c37e3496bd905ffb2a76fd17f2767fb65f1c653030f5b40ca4bc5e9cf74ab6c84f147db990a982ce040986bc12a8e8cbfa5996882276ff1493e5cb2a9965d16cb3be1d399e3d52d5b0e3de28558b2d9880be675af754fb1bdba6799e6ef31f6ac1ec745aed5fe93e655b14cd5eaf54db6158ae55b52f67f88a05f36afb572c98a7cf749816c3b175093beeb352ed2774fceda1e3199bf96fadeaaf5d0e0504c428e6277ce658facab18cc3205f6dfca5b176ace1f570bc226c470ccb92b56cf8238e28c79ce2b0aa81d9bb64dcc83d40c3408e60609515cc16a55f37ff11dbfb7f57d538bfaf6e3dd3a05cd9a0fdfc981e6f84501dd3ee788e57c3a8ca2c8641d22c127299c6c76323e5686fabe6ff4a695ce20386b3be0efd848b3c6c40dcf14a125141bf7bc9205780a34b3709181bdb54d7a4400b9da231f8f2e25d3712f88cbef51369e98c39ca077a3c581e85985d33f01d0299cf6d338083a57e7064e5f86684b17218caa33f2b942cdd24e9edf0a7d1b84a9465c1fb8c6680012daa4d7fb4d18cffa5be5b9e6eb3eccd46b3628a371bcdeaf6b71bcd00cdd17c07d489b2681d3e91cf27f2f9bd914f83c37c1acc3e0d66bf8dc18cf6acee5b8ed99336ff5a76ecbf567e24dafa8cfedb64d6fa7d23f3c5b7b36a8b7624a3f69ac109b591fe2d59b1591c783763ad66e3ca4109de34321da7c3b66896673b1f696522e9b01f771fd2597db736cd745ad4edf5dd8a29de9c0e5bb77f473a6c9b032c5d07e9e375f8c45a9f58ebb7c65a4d0ef389b5fe44acf51ecc73a520f07f0676724c0e28bde087de334463eaf31e2e9cab6fbda5be714c63f163d22bb0939e3ab272437aa0b10962a13fa17469048dfe0f133bd8161ee0282f31a0f340ed0bfb17e59a7afc66a09c41d2892b5bd4b1ed270afa46e5a823b72d6837e23015facc5181df0b76a529af2cf5d465eccb9867ac16015d98364591dbe362c2b6a5aff0f8181d7a96b0b62d1d2aa23ad27b3715d5c598cc07fb6d90d3fbc034f2d9848a719160fc4ef519ff46f3e4b7f1dbf82c90e1c2be50f1e4d5c2c3c5fc5e0f402bc6f3e7979bcbe267e8aea377c0baa3f6d54d97f41b56b412d931cc3dc37d6b512cdbeaf0dc07233baec3d31f89ec0045b7aa4227548b6b711d967905d9018ae6aa5b28cb29be82ecaeb57f0dd99d3c0300cdb51a375dd29f56b44f64f7fb23bb2326f31b813b0cdcd40bf53094f0b9bf0b1d2b4a03b1bbc70e3c9c44380aa7d09818122f8ea6dac0905461444df922f112272a2a92eefe18eb6d435ab506631e06f270ebc9fcdc36f7946bd2108387a2af494f7f9ef4a0385d4863d3e0472343572e39520b0724e59acedab6b4d553ac0f75891f8ea7ea7864a88e450b82d10b1fc5c498bb72271c986ca8321ae725c37010b31b45f41f318078a0168f7d3df5cc69e8337063e78b47c7d4565e2e6cdd5d0a6d106d1551c7897f7fbe93f1ba41eba2114b3ea60916cac91eedb3b0026e4d272211f86151b1bf7e8e44a7ff390699c5f61d92bb6e5c39bf5afc3b9d5fad36cb7ce8fdd4d8f7f57152fb42d40f6801f6f622b16486d7a37eaeb77f87ef8be519a6618fc1cbf029b53fa5f66f2eb56b06f39f29b28938a3378a181c6626375744763388bb4b71a951b635e25531c22259e91351899ebf552c3ab291b82677a4dfbe5a9abf2f2c7ef642a80fbad9f2fdfdfc75131429d7ccc0ad41e3810c5f82f8edb24de5cd953fe9eb0a6f2ab56fa1bda7d37e4e62908abddc88212a7ea7af5cd824763fc23f16bee11f2bcb5135e052065e4de41b9ffbbe08dd5f0fc8c66dfec4aab1f93a9b25c1ebf0ab6c54f9c1aafbc201cdb6d90e4bb3f4559b09734f33f780fad6a23a9d568bbb50a29f08a3d7c017ddeab4db15f8624af0c5b419ba437d5ce0518766da80aa0a34b43a548b6159ea6ae0d169fb7286d74d2657db5f075f14d3c2097905f86a75581a50d8cc32ae16a3c45ed45f0d7b9d22ad9f90d6f517e790ecf208d345f83d705f76f1f20f37095aecedc3fde6d620effbc5ce6be8f78f774ce67f2a8c48f6fa31445caeb22ff1729db910ce822fde26fbe26edd18ba1e9c7d89975fbc4d0c832fbeeb47b32698fcc71d7ac51fe5c8c3157acb27b6fc0b61cb826b56589250f81772f1e2df6febd9cb36f6676febd517da570935f49bc9d8a03da1e97b1adc33cc37c0312c4333cc475ac459ba4531ccaf8c2b051ccbb5ae67d48076a7cdd57108e514afead6d7db5f67ef67cfd00c45f4fd71bd0ebf856e5d81846bba75d5e053b7fe2fe27f979852ad5e9bb4b1712d3d10173af48196bb9640c2f02d0cfd913a93dac0286ba414964c387746a9e02c75e8cfe9c3ccd270aa40204bb983d4739983413fd8fac9bafeaeaf42cfe429674c277ece51b6a52e1da45a2fb5c3c0dc6f3d90d1be587dff28c26c82d423d7d4a0356a5a7e69d44f3e9bee53cf8458459c9846e6312a379161e65a8525f7e8bb7335b1e1ea7f984a861e983c655b342f2eb4916148b4c7e811b17cd77fd7cf1d850f6055733ce5c78e292d4648cdee07a9d3d757135362d133d77e23fdc31fd3c4889c9e1a7989ceb8a64eb93d3a9a9d85273469007f188c9a06b221da26b708005c0472a4d95684daa9131a9b27e0b5f7bdf2ec118d0ada3d4d28479df4a6fc70d2dd0d1fbabb6177352f7e7b98d0aaf4c3841b2781681f71e282accb94d68511b546cf504f0f53469b771f45681c1c8b84ac18585546f43658c7d426689f0444bd3ead047eb01935f5fbfa61c0a8b407718a4bb537aa2ad34b6d75e421802af4193d22a120e9d8c16aae4a917d7df45b7b06aa308aba9d29ad032071fe0eff4ed6bb7e6ee298437e38310ecd3da0cbf080536a0c0dadd9c4358317d7dcaf112da7a80f4953752a5327bd1def259de3bd14a6ded8e40e812cad3d897a3480b4096463315a2e78cf32d681bcc80244b3b83e236fa9a444decc3e158a5b158a6f9f8ac2ef2128fff9ff010000ffff010000ffffebacc37bfa290100`)))
+var _ = pkger.Apply(mem.UnmarshalEmbed([]byte(`1f8b0800000000000203ed5d5973e24ab2fe2b27fa7566bab502ea88fb00180418b0d924a18989135a3008c43266e7c6fcf79b99555ad86cdc63f774df719ce3681b4aa55ab232bf5ceb7fbf04b3a7f9f2cbf7fffd320c56a3b5fbd59b4fbf2d82d9d073167f0b66deda7556f3e76fab60bdf89b17ae97abc13336be0be09f2fdf7acbc1f3f2db68edcc86e3c01939f36fc3f9b7e5b3f7edf6cefefaa53a5dcc9f578fce6a045dbee5c1a6331dc023c90777730ffe865fbacef370b0ba3ac2c564f8cd779eb7c1ec4f67ea6794db87fbd581dedbf339f57db173f8bee1ac3c98cbdfbf7cfdf28fbf7ee9ac9c1086b97a5e0ff81fed81b39ccfa087d97cf547305bc267e1c0ffc35daffe70364e103a6e3880cfe18320f4fff01c6f34805ef5793908074bec1747f467342278e53fa245846f67eb308495182ce2dfbb83e52a7e38f9e8e489c6dc5fe338fff7cb0fec44c30966d114df9334f4390ceb7d7a8307be4ea133ecd480ce02da01f1aba87cf9d7bffef5d72f4f6c7dde700ebe7f5b0da68bd0590d96dfbcf9ec2918e2e3789ef05f7fb082ada41e679c4e599bbf7e590607f85b52337ffd0223c25f4525abe4145111e9933f57013d200992f03741f99b2c7445f9bb9cf92e285f354d561525a766fe22e4be0b02f4162cfff471c9d9ea2ff7f4cabbc1e6cb773193cd662549a4cd1de0dfb96c168e4d18cc265fbee7806866305451ceca623627fcf54b2f80b5560568afe36f127cd4f6b123f8e5d1f1fff486f33f05a03fe1aff41f505d1e47ba5c0c3c7c6707ff11d55c4e92142da3c28b96f8892c8b303d41c8c02237aeb757a2f6f10ca17df18ded0bc1f36a74e9195590335a2e1b3d93cd288a9ccd49323cd34976a310cebdc992e65b08276c9b1441836fcaa133645fe88319fd5b5f2e9ce701fddae2bfe2c2fc838869f4c9256fe792b74fe6939bfe16dcf4af5f16346998fc64f863d47ace5aa157df5939d102e3899bad9277248fd200fe0d26fe0d68ea79357566ce10e8613f0d5fe6ea67ad23fe2e6a193962f00af2d20b9c5d10bb621618ed7745f9aa64b382985304f58cb33f39e1f215d60e4c36978b59bb18b176352b6781b7bf1b6bcf4819599062360a024bd01445d3aeb17678bf945362561d4ff11a6b7fa1fd75d67efa4c260723ca6a316b671b11f1f6dcafc6dbcf49fd84d9a77878f4fd390b4f186ec25ad949e11c9313d131cb4c3342d6fa93f3fd6a9cef9c1fc5acf08b2ded36fd6979f9309c0fabc5c6d0d04761b5d25cf5ad9ae0e8dac12f1646eeb435ec0962af572c1c6c539dd85675e85bcdd02e16a6ae5c1dba6679e1160b6b576e2fec6938ee5bed852ba9873af6097df995c2932789236fd69ebb72537808f23357d7c67d73c7fe1e57c5da36696bcf6a1bb73b1fd6a4f2d2867140ffebbee987dea4100ef4f2cad377617daaededbd775f8531b9567b6b4965d1d7474f382e6faa8e5cb397a916fd91ab1b7b5f17610ee5198c7b560cf243188be095b5916fb5e7965c1879725376e5da04c7e5e9e58327fb1b6fdaa2b6d53def5fae859e652cbca9f1e4ebbbc3435010bc991116d3eb566e2efb66f3b95af1c3fe74b471a5e5d0306a8d3a6fe349bb515f5a3df5adc2c2928dbd7b077384391461be309630da8356a730b14d7be49b3ba15aa9857dc958f853634f639f1a63db0c25980bccaf36e907229b63510cd93e54eff9b87b8ede1bba7a39b0cddda1aad75418d7dc319bf3aa1eaefb92b6724c7556d5d91a7b7b5887696f68cb389602eeade04a7e086b1a7a81326cc37a577543e99be2d6d57b4bfe8e158ed1b10a4bbb5360e3a8348649dfe1baaadb1b2f288cdd8a71f0e1fbc75e69d8071a8ade0bfbb2b2bb6203682674a74db5a38733a75880fd3096769ead471fc605342af4a5118e6be1ceda9baa5e82f50cd740bf6155f7f7d07e4bebcc7fb7a49a0a7be4d62463ed07deb2066d3db90d74d00c6bfbed0c682f047a04ba12e96fbf126eed0eb663cfe367ce540b6aa210ada7e5e8c61adfeb4e0d397a4f55d7a6a9355cc0cf18f66804739dd09aebe5f5a5f5aec31cfb6628c0df02ef7f699b65386ff919df83275f2a2ffca237f4f51cccd558e39ee09e423f0b5f0f47e7674e59277b1f1e60bf47834e2174674d11c6047fb7e77dab351c989a08e30fe16c5fdc3f1bf71adac267075c9f78aefc795c476fd682b3971fc3fec299807d807302fc02ceda0ee73cc5bdc6b9c3f8e6c82f809f403f355897e6dc365b30270dce23fb0ec63d443aa3be664da1caf7dd9d6a8263da8bbe54867fb5f5d1fecafec8b15a700660bef0d338140ef159043a7103b656b0367b4f6e9df5856b037b85fc2cd98ba23ff3a69a08bc01690ce8c386353e5aff513407bece1b3b28ac6dcb1b727a3a1caf519b78a463d516eed48bd715bf677b9ca7fd8af61bf65500da90fae616e775705b73b6c6a570cae888d14e551fd1d87db339b6ade6c19e194bb732813362e0bc4fe7b4b7e13c037da4fb9fc138367c0ef07d33441e8f7be07614e4a9979ec954ef9a2b5ae30ae357de818faf28a00c80f339ccb03ef3c334df827328db666dee4ada73dd5c8dece96a5437cb13e083d07795f79147beb106ba5efbc8bb2bed3dfc7bbd7f680ff394900fc3f885bad95c02ef3feaef8857eaed00dec9ce9ec5f834ef6b5505f9807b1af15fa22938ebfe780e6b027cab28c6fcebcafccef9707e7e9fbcbf7680bed93c88df01df976319b172a4f2d4914aabbe09345381750d40aed13ba2362033606d809667b4e6347f81d16459c3fddbb8153bb4419ef8959a68b7e663762e0a70ee9196fcb95f696f1f82dca6d1cdaf1b45e5501ff7338df170fb7057ddf8e3d286d32f8c411dd992b107790cef498d573a5f3f683326f9ab97c78ea88d41e64f1dd3e773c5756dcea1bfb54df4ec8da333c29ec3f91907e88f7830ca5e5bafae581b94bd6c1f405e6ddc508335d5fee9e0380e0b9467cb87688d90275aed258e11dacf06f272589fc033a6b87127206fa7c6d2dbab1c07102e183e5942dc3feca9e2dfd1b3b027ab6cb5a8deb992b882b914dca9baf175c62fa00f386f22e22290a3a206efcd0ef6f9750bf83a8c6ddd827902af033a0a37eeacb1b6816efa726d01fb897c19df8934a57815a0c5cad17b1a7db316ba1578c61a6d41566a4f1d9877a52d7877f34dfd50921b81b2ad8f61dfba73b5713739d4f78d4d6a2defeb9dc2a5355cd9a63872e0ac138fa1df77c774847b54c1b3df7e42f9e04a3b097873faec0d914f60bf83ca4869c1cfa0a8c23ec3fa0dffe77fbe7ca812ed868e3771e7bbd715e8a39691f29cd5c4977567f96f92d21533df05f9bb90fd9acd283959d4b2efa93b33b3a8f481bab328e44455bdaa3b9f992cd914afeaced7dbbfc12c9a15d48c92897567da874fd5f95375fe3d55e723de92a8cdc0f2273eb259c64681b58f04545d1bb391c2600489a62dc0c980c10ffc3e7f1fc155cedee3bfa1ed0ae1b02b01fb2df965233f4fda95b52daac9d6a1a60c52cf7800c3fa0c7e90782768128f259f89ff861fd734509d0715b7d0ea8acd1efbae20f4e50289045069c6fee5bea10d1f77a5b0f1ee9a0750ad408d52436ffc4a7b12afcd6db21ed847590491af923a2d17405436c3a3ef01d6a018b44d32130456b07aececabe9751240541e8ba8f8f3662cde8efb4418a1022c2635e8c993560b585384650b7b3681bd01980f6290afc9c193464fa06ee29aec2fee65a53df6f2f1e738dfa3b5f62c03c46f4822d5d3013a48bda3ef415c87830ac06b19d597daa02736abf56e15609910f031804ad5fce831902907d7b83b09ef3afbb8efe05a3bc368967b41dc2ede73a08bbd2bc17e06f94cfcd90b7bda2b850fed7d3e735f5e0daa65e1f9be93de5fa4115247706d5a5d41ad548bed6cc3128297d6f021647d55eff2db46d49f1e8e5d2bb52ed13801d603ac5ca5d7cf01f8046a258e4ff4b6f1e7a02e8a27f40baa9169c3dad64280de4847b81e822b01e44418390db7cd62f583a191ef2c47eedc79f6912b7d5d2d5ec147e7cd630fb224bd0692d0752c82b0fe2e895f05403392ac6594f774308822c088ec473a18b28a94bbea3b565598108e9203183ec3ab18e96af3eb10e9f411212b485a36711ce3267c22a44f84f47b22a473ee92c024b4741b200e3c09b46260bb8dd602d9a5e498685d6b106b4eb457d4a8412be696962e5a1e2c51e322710adae98489be5516347c6eeda93de0b3a05d47daaaeae93d14e90b57e7f0406f2f3ca91cb8ba51b201b291860e63b1a7e5258845dec69838567bd49feec2d8b21f700b8a8e5abd518b2d82e56648300361c85d83c32e804615e6f5488beb3e403a3eaf926d1596ae1c961cab061abe36e95bcd797faa8dbc69fbf0d1e26205076afdec0d6e951767ed238101acf56d5ab52a0939597a77a55af9508774065e72b352cd6628dcac5427ed6f57aa554183314ab1c8a06df814199f22e3771519670ce6459931617cdb28013f1d7992066a5c338c2d983af7f65e910bb65e1680d76e7cab36b6ad37c91c616015b00dcaac15f3561907fedda82fc11864544541ad90476a2c2fd0e2ce55f0fa5ec3fe6bbd8aa83dcc607c21c889595b7b6a3119e14ba1e0949aa052b443902f8bbe499ee529fe6bf3368ed52c45dee0d3efd013ddb240b5bf3b916795f6d213c8236e815c9bb784f21d5aeecf9e3f5ae739f7aab417be8ef232f6c27cb03a337c769e9c99f3359805af0ba7d3c69164ca4982fa465d46cd6472ef6af065bacc87064b61b0927cb32ec36678b32e1337bf5d9791b39220abb16062bb10492649f9144d9fa2e9b7124da7ec25914bd57d637cf5a7e4effbc0a3dd6969d8e226489037cc1b1814ca51d452b5f8421fdbf918bd926d0bcd4b2017748aaa98f7adc6d0d68d69df3296189de149391ec1a1add1ebef4e0d16adb32f88ee943c9f204bc89bbf70a7fea15a09377e07a301548179fd3142670463f5317a694c665db9b940dda75a4153a8117afb3c936b525beccbe4c15c821e1582bc001da6b0c20883e278390459bdb5247163ebbde16387e4e4c4379be431a5bef524ea86cb69ea076495e856285a62532d8d1e7b62fba1d513cbe4759f86f05e95de81e3f1a6e1283d3698f71ee42b8c9945efc03ba6477de0330145e284ae0532bc35cfa6c76149cc0bfcd8c94f0642f2dc13465bbcb4c7977eca05d8e3d1e1c57dbdb8d70bd743cf72a5e9e37ef4f077b687b20338c4ee1466deb43c456f7c556f8e30aac38779c3e7826d8a5ba6df1a873a46e459cd03f3506f5964158fdcf1cc1dc8f1de01231faac5d1c2a68898b250a5c820e3609bad05bc9be1a34e5e63f809745c7db719f440679e895a5d42ddb7748fb4592db531fa6d8ce318745e1de7d295fc03f6efeada0ce8e94affbb8d2d376eebbfe2c39c618e80775c092336428c28580e304a49a2e8188cd0596294098f3adb02bdccd0f48fe380f78a3646815d1b8b5c58fa26bc63c6c7037db83a466344eb06676bd61e617f0cf3d90bc24e2cfa89473f60944fb8049c89e76fe4172f8d1fc735a1a8271e25247a3ccad2974668b7187a3363cdfa55eed1a40e6782f69822c8ee84e1405eaedbbab185b555db7ab8079cbb396d87e70fe6fd36badc17ba18ed83e6fdb79e8562b83ab0676b8ca6713c3a46856c87f70c0f0f315a945c5a95c6a2385e25a6f322ec097791e1b3144da98740e7e5895d41575183a2b0fab826744eb618d1b0f5611d01432fdd62217018ed6f304aca655182a137b501a703661fafc8456649d85f75f848679d4548d2788ab027724da8723e8a383a71bdc1e77ccd7b18e106589e785b99ced9d6376b4bc7c4a8b9f29845f8b537140114301d839f35367e1eb185516c91bca068d70e4596014df9078c60b2756dd537c375f4de8e51d0187f2a74109fd33961631dc3f8f6e85a49bd17cf3a45d6c29a867d936878e2c23a000fde60d41fe85163e037133c478ea960bf2de853429e0b7cb3d40d1b183d456bcaa316051fa37ce0ac3e60d4d154dbc3ba3ea5c719e91fc9fed922d2bb5731409708f13cc15ed059411de9fe418679cbed2790934b7cbed619a5e8213c60b4127c168d7101fa5e78cfddac1eee03b4a9539452a1e14a1ed08111605416b9bee436ee39460aa22ef8843cc8a677239d71771fc93eb68768a7c3675de4c3207fe1ac6ebce98aa2f6884fe7e7598f45f78c2d3e6efa1ce9b65210413e2dfa6c2ca13b25dd6968cb8b8507cfc19a92fcb2243fe358799493910e86ed892e316a944590e6a7557d15024f035eb20b610ef4b925913d13e9360bebbef7cbdab36d4d381dbff18c97701ee50074e2f047e416c90bb3067ab841e7bc6d225631f6d5626d35b09a3bb7585dc2efe4b2b6d1cdbbafd2b9afcee03b3d14ec6e2300b9b5f08b1c9b6074a834da386613e74b3a37d283279797a0bb868ded3ccb22d161adef4afbe63e8e863a340e79099e61e70c6950ae8d800ed77d58278a040f707f70bf5a406f49a437b4253aa5a8e6d3b14a2ac814e42ff1bc26a083330c126c611e5124782f202c52ac45aee415456216abf0aee6c12d6b2bc04f6c5c207361cd967ccff5d3f5e0586e09b4047b33c4b3b7c4885e7fda23be416b6e689c4e9ba18fb6e6a94773c2f68f2939599fb603a2edb7d1c5b007fc81e459907febb3f7a68cb69026dabfcf6802f8f4c69b4d60cff301c9c60ed1c79ef8d89e7ebf463729acd81a3a2053ab533a0701971dcc8f10d099267b7f2c77a621652944e100b0be5b1e9987eb1f8d29c3f921606c38f3801fe0dcc5727c601a02f64763eece31da92af4f01e8a1caf842714be33adbaf880e3b8509daac2cb64ff7146d8bf33ecc5363db2d1ca9877d4ca82f3cdfe842d77b28ff877d928155add1adca44f74565f730ee1f9a87c912f8e006e8ece0588bf0b15bda368abb497f2cc6b4d0cf238e11553c4b0f4161e6ee45c085db15c8548a5cad4bec3b58e7831b2473075eb747be0fe734ac4f3dc05628138dfdc32c39432d0c41280e778db18763823359ca340e8d6d33986c523ce2892248cd3cce3dd95b983f9dcfb138429a8ffa7c34011fe059bf1301c7019e249cdf1a12bfbf13c4e65d638f7896670d00ff8673a693fea1a5ce58d68b69a0168784104f89f610e8806825d9d331e24607f402ea9bf8acb671a430a52f2467cc227a677d74cc3ed3c750ce77123cceb21f485e1de2f37d42cf91cc7c409927ad16b6a1f13095dea99ce826fdee1636d159b8b2914f45ba21868decf34a73dcda36505e45730b35c0277668a11ec7f49ea8cd0fc80eccc4d9a92807e15d6fe71352ea7951b84fe34da07fc25a9cee28ecc4232c569ed9782e65d00d81a7c067887b0e1899ebedd514dfc38ca002eaa8a03fe4f7cd2262206c4ff8e6c1c5ec0cc0d9b6dede53d43cac3fcf4a0809e354da7b8c6a06acb68c746b583fa6b703f6f759243cd359795bdcff04ab23e66c02ddab6867c6bd06fa1f05ae54dedb1cdfc3f37be4fd405b22e0d3433d28d4d8be155086a888d7dc4a61ea9b5be223bed558e33b5a5c5f47bc8274033a38cf6240bccbf00cfb3df275f68ed77308f490fadb020c4767057029d05994c90498b619725cc968af43bf27f28f61eb31f076c48234468ee7d1d69dda0b35f4f34c87f2788604ec0bd753f3f3147f5cf818eea3f7b9ee1aeb608b7af41e965583fc67826b015810f64503da2f189d4e01648021739a60d930b3c9b9ee85723aea3b887cba3d0c4b5a1056649815dfb38922b601db820cc23e5bb05f65d409708c98dd22b2bd21de1ea2deed526607cba2606d42c2c898b540b610e89fe10ac0f918de471916180ad714d8bec5fe82e335647b31b3ad16667209ac3dda8748d61e8a53cca6693e033edc78a116f9aa0fc8eb782600d27e0578db0c69a205fdba1590cd66136d006b876c4d4dcc24123cd45fa448976d87b0fea0db62364f422b4ecceb9a18ce456bdd87fd70f03bd4d7816fdac037312bcb3a3aeb9a08f865610386fb416c32063d6181fade0fe9a5c45343616010cf49b2eb40b6baa8bf502602e20df4c5c03ac37c8ef473e0a7a0476106d7538c2bf9b3ccce95f075d64e19a6320997dc3612201f73d04e2151765888346277284308332d91a7815e435945091d14b9de8c7d825c63180ae54c9bceb2b74dc6967a279e6be075e2c21d120dd0f9e1e711cea1cf6c04635a1ba0c5f69323192ad24d4ff4f6a6d9948d52183c588b6d57d0e6ae5838744d8699d1df043802ed3b789660bd30e3a5463a66df0a51ef62df855af25da8d177d87f739b92ad7292017284df0d8df006ea3f4c2e1b18ca88b82d207d0e6534daac623dba9cfabe4ae38cf10093db12f90e992f8f742b38a32bca2a85f1c46d0d2dd5f64457a4b3855987ccff18f9fd28ab12b317a10fd051e0f7c213f0d9583706cc0458b385d803f07b2dc90c8433043204f8c704e43fe9644f51df8017a96f4b3eea1bcecec2f5008f789526c8b6f6c1c24c9f4a73ddb728fb0e784313b02ccb368b6404cdcf1a096c9d774f913e6a93eecac26841a75750b6c23a2e01dbc4fd51966a6c9b29904e9e7e7fddd40027221da87b9c2bcc61edecd5857bf2ced433c9de9599deec49439e6117b569ad610fd07e03fbba043acdddff801dcb40faf9218cc26892ad6d4a9eb273cfce8e0f7a7c9db0bc8ff263cfb32f611d413eea9a0c6bb8b025e415f963f95a62dfa3de0167879dcf80616b2ec378f628e050b93683f3bf184ccbf13ea2bdd3957da42f90e19c5775927de4985688300a7cc6ec4ee73653c4dc30468565b3b24cac08dba04d24c523e11c032f071d83fb3380c6813f363a48b7280bb5a73e61537f6d4988c18ec7d34ef0e9dea56c5d906bfb78ce329cc525f242265735b44d619626cb68e4fd223fbe473b7f12cb8519c4812ba3de86f6ec18b7d05adf237ec7398b1a9fa7cad60c6d37cc5761226e8878458b641f6596927d116997f34aca22e57b0f7cc358da2817f9e76857e2bc7e86b28ffdad3ea1ef86ec15ba813c60c9f047c2eb182d2432c3e84d58f625ecd73d66755bb5e76a007c23c8a3ed6ce6545a01ce29c6fa650df56bce0381574d973f72460a805bd603d0850167125f778af99b6c407d6a0b58227e9ef93122de4299bf92aaba801530b39cc74a90ed30853bd3facdc143ac67daea39bd4576549e592b8d008bef487e46b65596590b7de3b8d087a4534634fac5189dc1d9616726d6e59a64b72c21bd2a44039e5c08fb523885b3c1e994f65f047e25d2decc882e091b103dcc703ef0bca8b1f620bf8c6918fa28b77e00e3000d1ed08efc03fb80b6bc451ff4a9876bb8fead63a17e0b8d76a9dcbac54608188baa29d4a73bcceaf5416e733ba83149db8649cf9dda0b58332683e1bb3af0ab7e1af74ad8477e8dbcc0fd111db5dc6c1a65e2cf65b287931ff5b6f5649521846b749ad821f5dd067914b355ccf7cd94bd116d53c8b77ca48d22fd7d66c345ac95fefb2425e5e89de41f30b4586fc535e3782f9fe8516ada5e720ffbc1b290d1bf385ec9b6b9031c25022667fc08f8f4fa4446a5cee25b6d023bc0fb3e618edb6c00d09ee9fe65875700a8a2df7ad644de7c6a273c0cac26cccd0b40572e25320978be1e3fc374c722b799a32e87b66d8eaf984c83bd016c8d3ca1aa8be87b5e30bd1e6d47e4476576ae620d6d60077efe99ad711afbb759ffc4bb8d05ab8c910f40cf02fec6c7ad1b816f7af43beccfd465f64d19f8f99a6cc7186f85bc8a7e6fee1d2eebf0ef133b4ef43cd0e28efceeb8eff4d93665434ead1b9b4b8fe999ad858bb2a81e7f6f201d2c6dd0f7dc4807031a065d6f097d325e37057c50dc829e0e748dfa4f841f796510ca9486efd19e063895558c003dc3c1fe8740a75cdf25fa8cdaa4eddddc767b799ce4cff68bd38b73c5fd1fd239ee60c5991a6004d0a9301e400f2729db2eb3e754dae1a0423ad7317de88b0362b4f438694ef9c8178834c5cf3dd98622cc845883d66acf32bc7b73d0b11658318055ff403ff16ee333dd398e03bf3fb3cd717eccf4a327f475113d1eebeacdbe358ce6baf6993e036bd54f553ba1753cb32536baf96d03d39f405785f92996b48333c96c01a9efe277407f19d42361de53ae731e50277459d58ce505dbe2fe01d314cd32da7076d5bb6dae7a575580d6f7cdaedd6c0df97b81af0c28cd6e04bc0cf9273c97e7364cd367711d6c3d27c88b06d7d7897d6f68d1ba9f606943f1f4704ff25f2fab1eabc641b4c2c63fe271a3b8ffb640eb08e343bc34b0c8be3439e227771e9c357134281fe9aa8c5fed55ce87fc9f7a8e52f4d9e57c10f1ba80b2c5d9b3f8541ef710cb07e0939c96639b836857f24b4a1984f7e259f451af282a64bb867387fb0fb21a75b72dafc492d0166b8b7ecdf6d6051ac418a4c8e78e7da35d01ed8bc9b848e6a5e515d34be4f080679dfb81d1b7a7d6b9ee837bd1378d15da5cd85965f2b581a97968cb37fd79f459335a47b6be6b7817c8ae26ca7961d0e17b526eaa1eed5b41e89bc0d74b685b8de7b48cfcbe7006c91f5fa33e7dc93c14fc547c0cf68dcf939ff547f075d700fc54627ef544df25b9719baf15e96cd67ee23ef403dad8ac56e253261d3eb2518c57a083d70258d79587f693e9966c40ae55a6ca1fe98a5b75a69f735bd4896f43e2710040fb0ffb37cfb994e4b5b4869db0f9d80d98dcf46ec4607096619eb50df0f289b757ffe92156692d2ee2c9c4077f397629b6bf571a3fb27f2ce707ab89150b95d8965c1243e0d78bbedcb809ef20bfb52df4a761dc4759429ec6ed5268a3ae1ae536d048b9d5e9140cdcb3ba54de3a1d8a37a76700cbc5bf934da294d82408e3f278035ea907e4d796d2bdbd693826db4f422b4729d1c776ffc41693aaf803fc30249f8bcfec4251ded390cb94c8deb0b6e3781b5aab78bc2cbecf07dc55203b16c60c9a52bc7e6bc4af9e3e02598af86489b8764229cbfb738c0df2eb806dbc0ada4d4385fc79680f2da67c40416f8ef1596887ea47bec74e8895e87e00d7b2b9a34d2c1ddfd98dd6f6267ac6b5451b1ab7bf32fbca066d8f2c86a570940746f60d8a8143bbbb0d7aa5306c27187f6d5b2d904789cd13792e9c8de8acb35892c37c538fdb9430855cf8d8f8fdc5f37c3a588d06ebe56df96517da4751fc99cc6b19c9cadf24ad2b2adfd5ec7755fe2ac9b2a6e43299cc3b46f16b995c56ca89ef57cd5a5464252b4731f33959c866b29a70b5e4e949fb788a57ab595f6f7f358efffc99acaa66a4a4e429db8828905fcc7c06f27f06f2ff5681fc17984c12cb5fef08abe2d4073c5d1bb954f6a480d5ba401619a755f85056ee9a187bb42f140729bb783d7e06313d600cc9c0d8c928fe60d7a4d21968ef6ab34aa8ba017dee445e1df5a4b25e4924797286539b63906d5bf4c3031ec28a89a8a3b258017806ed1a5c2647951fe379507c88a9895ca73aa00f99fcd684eff9dcd33a10c6f254402f62634dfc4112afa21697ba892bed6165b98bf973355e42032ba381dcdf60c98dea0cedaa225514f5ad068b512b818c375b1cf3c7eb033a7c4dc4f8768aa1e1310fdcef19ad2fac05e503301b71785cb111642dda712618fb011807fd7a0c83463983457f4d3eee197b4f7d16aee0b9e8bb802adaa15e72e57bd0bb461ee017eadb6aa00d7cc9e62c0c6b726172e539d003dbc1f5ef56d28bdf4dcb63c03458810f75e193772f601f04f2cfd7c3f6a23ddd21f6ed628c0cec550175314f66798d75583b58b3a98b38bba8eea98cd11e6df5db591173143ba4eb694fad749f05d1b50a67fd603c36d96f4ec63d9081c3e9800b31569af5b9b083fcba25b4ef5a88f1664d9e0389eb8536b0f6f8d63e3a542db17c36165e45f1e2ba602c11e682b23ec369b5a8361cd0297d5dc51c99b3be98cfa54d3ef217c7354cadd1e4b45a31c5ae1ff0bdbc5a1fda4833c5a070529598aa4352a91d566db0795aa50fbf8bcfff037f27f005d2dd5e7a2fe75f2b7e4eb5a7cec575e5fb3dbff7a42662f5d08aecf47223935efb1e9c7347f7d12626f7ad90d68dbf07fa5c6cfaa116572244df9c37f3d1c629d8a6b2ea4b3bd19379754b56e687f136237576efe6a057c439b67338c318d7b2e41512a37c54e4731357526668b7427ee0cb35cc751962c5de6ab13f4c7480a4027254dee9da1a9fad33560f96296717b0fb68867cc997ca2aa35fe071bc32303cf34fe0a54f51a5cceab446d53101d32ba04b2b84f3516f9728c6272af17459dedc35b65ecc3bb99e216abc8e447ec3ca2b4539be68b328afd2e38fca92e1d859fe426e3fa8809af9eadc8544861dad477e1657a27c612dea894e37e3efdac39a93dc38a2a520290915c73a24f265688ada53ac03c2ef562b5d7a09fd9c7ee897393d00ad58b43620c70c8def5b2bbd97078cff233d90d300aecbf9336d3f5d5a2a5a87685cf04c12d7978c6775653cb1ddcb1273e9f264585117e922847d5d63c551cceb684da92278e0caa3f82c5de37bdd144f3ae5a148f7dcb789e5b096096ff3821fa57b872abd0f81bfec1636d02ec5380a8cb754efb04aa9ef9ff08f4b6349ce67a51dba814872d78b4abae9a30dc6cea569806ce11dac2cdddeb8818ff1078877648cdfb2e9ac5587b5f49e740a117f88f88a403104c7156987aff351157d41e5418572f42ff1513a0fb86f5441f6f5b993bc8f4aa13918532ad7d2b448f617ac386cc37735ac529df0cc90f86980f920e91c4abe4e9c47c43929e7672ca1cf4accc3f7b05e3358dbb5a187a55648eb247ac27bcc7325fda2f3ac1bef37cfad5dfc05e9362c948ef9c14d329f780aac8f4e38b07c718d4e657a90e0c65f611dd86734b74a8dc54717d59ed3b3976853eb21cd005eb9b036c7fbcef8e3114e64e338a325b656a55d88fe5c98d3457c7d69fd53fd1d61eb0bb8b4e59be23619731a5fb2cae96d6b4471dcf0fe17f117c3c3e5273b6e5f9dfd6770d5756c41b98d6c9ca77cf79c6e2f602dd0b5402fc9ff3af33aa119c0cb2baff40adfb94c7f67fb097d60ee71829f7fbdb357a2b84d58dfd7f8d1f979b8cc6f916e291656aa85917c497494c43701eb73684473d0232cda64399a80b5eabc0fbed60cff59293c8a31091583f2bc4cb93df6ca34ff35e6d54618f4a20c7a452f3d934fa9bd4e70073bd797f814d38fe11db3c4a6727a26d8f84f6c340c73b2db2cf0961989bde3057dd8c1386bdb34b6115f79d31cc205e62f85786bc4abb8e91abf45fd25c20ce992abfcc60faa423fc5b8712304bd697f3a974b67f2843e4f78eb0db2f2d6f53de243b7ec799be2a52facf53bee79f48eeaec029d9ef3dbd6f5739e5aa37adfb49ffbbd44af8df5e1a46c6ecc7fd37a15bff1046d86cfd487a185f118824bebfea943beb70e79c9fe768ee59b93fe2fa99f354bad3761f8c56b3215f1ec04fefef5e5cccd7cb8bd68975ec2a529deb2cf5f91c3379d61d26da3337f1b2e5f992f60f21bf0c1ade35a496f1857ab5dfa3879e5e9add91bf4ad9b30e5eb7dbca30ca1dbc5d097307cd13770c926789d6653e3bb665bbd5d966c81cee736c6e55b78dbd9755d378ea3e8bd49dec5713f3f30b6382ee516ac7269afbb536d629fc9da0f393764974bdbabdf70868acc5f56db0c4a3f65ac41fcbef23186607d521d173c97d76c9dd7647a4aee6aafc9f327ba3912f4504be6f49d4fcb755e6fd448e9a2e5d5f93365e1e80a0bbece7c5c584734b189a5cfcec5f14851ada4e693b54d639e1ac6c5611d248abde5354f537b169d8763795b637527860f6301646fe2d338b53f247eb31bfd2070ee59dff04e43a32b165e91b9d2bbf944f0da8768cdff2d5db7b5ee46beeda931e9f19a1d6fc7f11f81716b21d086026b33af07f3c5fddd5c691427dffe0d7abe4043f9c9e050d66e1927e55d98e253fcce17c6faca18b794b39cbee6046b1a001ddbace661865f15f2fa59bf3c86f5fdf0555de12dd87cd28f64e191ecfcb09bd396de73b0582d5f8ebe8b1a4521772ade7ccd22ee2451c92a394554c41b02efd46c469035f92cf08ec521bd1477276672d96c1277973d2eed9efbb0c03b4550b33978cbcd81777c8a3707de25eddf50da5dcb69a228c48177b41d51dc9df0ab85dd9d46d9fd40a856f2c1793cdee5112e265832fc791bccfe74a67e46b97db85f9d24c2efdbc5ce93b8bfbfbf6132ff88030419b51fc707cee6ab3f82d9123e0b07fe1fee7af587b38143e8b8e1003e870f82d0ffc373bcd1201d49f8f72ff88a3fa391c3d0fef11958f82b0516468c330e23642bfc818cfcdbf31a483e7d79fb72f47a78f5b587e29b31b3c2db42ac155954b392f2ee21d6d247717a1803705a4d116ee6f47c8a3773faa4fded21d628576529a9954efbf03b5ce29120862b11d64983cf10ebff224e78953da58ba6973618245a97b018e0f0dec3e224452c80b128b58d42a32b864fed52d879b4ce13b88ad3e6a45aac4e0642bbdc2bed1e4d432b758c9a560d0ab9a762211ce8a150bd2bb182b342b9d39d840f6d503a78212f5660805df38ec5093675d30fd139c90a4328c3b69ea36be1d9e54fad51742574f4d3c2c224bc40295d8a512cac28b08a253e9e0436c138a686629b8de16390ffcb3d7c6677f22b077efa9d7c80c6c4aeaecda262cda7d76dd7a798ac585d447d34c6cab4d19aa71d740fbe298ec820841794f076ee0c13fa9b825bccafea6673eb9b14c444857bd99aaaa26b961fd0b8835750d73b221549baf63ddbab9380d2a0e0c50a4ca5c92e55b146617f8f459795f3b9bcf47ccad00363890a4eae59914d2120834da09d04c8562998b78afd70a362dd8239a3414feaad99f15d085851ecb2d0e7f473d4dea4e24d6b50180554603121f7b15bda37f4e1d0cac7e3c104dbb58b01f6e656ab62c0db341754cbdbc860b7c222c3754c949329982d56061f3bb5938b5efcb01f5df6d2a9c23bd2811c786d7b6183819f6430380e5a9a38661b93aa417914b8f1e5a47d3ca738101fd6c008bd31ad013ec70a4677f19ded91db4902f4a302d6d5b33db968a04c05edaa4b07df8581bc91e1e1c0d63c79d785f70ce71facec12138a6ee9fd73b0439eff06a074f5c9443d7ef51e7159ec4ae27729fb5d11bf664441cb4a006edef35a1935a34aca475e9129676449bc7e456626234a99f8d297788a57ef95b9defeba5e7cf64c2ea7650435a5178b9f68e9132dfde668e92abbb90532612eb15fe84dd45a77e265f0fe81545e94dc8f6a96ecc5998d716253aa55b5ae9630df98ea5c87364226cc214fe78297a8a6ab8a76520e8586ae4935c7000ef48627791ca3e2240dc7aada85bb28eefb526b580ddad976c968754b9a6d09ed5a6f2604d5ca2e87b965f80e84370c46e4ff52bd53e620364298e7027e46d52289d3862bf9d17d99577c3e2ad506ba6f2d000e1ae3ea5df52fb53140b174dc3c42a41ec1221473ac9d4eb067eceb5b82215ec520ff17dd2f7127e2bb8fe0168a42d7847eae7dafd7166e706d8cd73e7f055625711de918ce6b9fc710e354845f872cd54cfa4e8b63b844354f589d138056bc16511a2a5d836ec95c8b9453161487fff3332080e77b370bfd54dbd8288229ce6f328a64c45c3623bfbb5144fe30a308887851d664f176a3089be2ed4691b8fd1b8c2274a75d2e318ab054f34f31ff29e67f63319f6230ff9d829dd92d30911d0b99e5835ea9dd40fdb1ca03838e9de9ed855d192ee1dfad5dcc671e3bf9f54912065e68147a5c37a6e4b3245884845ead5298d4c3e6988aa28240c68247a80b7b7a2bad0b43ffa0a3ea2116556aa1e03c790f0962d89374d0ca92c64a6353bb7d098bb40290e8b284487aef246c413f99e87d2d69b7401b437c59d4c500b2c28540ec4b49c547f69908206061b8515f6e2f4947c7e063042226167e6ed1be1d819e0e0a742ac47de57b0e38a4f6980a25ceecd7c1c9b5b6c7f69d030b446c309b41903fb517447b70646381351c56a13f008478f902157eab868552978a6057993da492d843ce818bc86c7fe720726ceb0d4a58af06f9fde358a1f7d427f08c10158d6a4efaa206e3c684e42a6bfb736c18fef4cfa9b37c8bede2ec8908cc8882acbc11cd68920afaf6bba319e5c3d04c4e93859c2cdeeecce753bc1dcdc4eddf8066b2a220e41234c336e213ce7cc299df1ace9c319a4f5083a0a66b9659d51b124c3ca2b872d998eff2b6d5bbb9562daab1908c1d3cbab1e34068d8484768537fc9f30008460c80a015a2bdea7307404fc29b60e8c67b7eeb7cf25d0a0025edd16170721b3d072158597158b7d2918c3b06b27471c49d010f7df62e5ccf646c17005832ee042425ed618fadda652016018917b266ff43569e89db11a3754c595d7687b4638e9d8936022fbe6657411265a3700752b4ae4780285e2f93dd045765b7c0e12d882f5870ca137250026dda1d66f5397752a51d54f178b6b609efb28cbd5b69680c20c57b774c37c7cf8d6cba9908ab53f656e4900a44d19bee2e38c78ef6fd78ae584dc8a40ac7da39384baf27b3fca59f6537198b783b1f7f966e55e1e0ef68ac0084f116a4f232e544d3ce40e784bf6b82d6b35600e7b69c54c16d9ef61959c4346e099bb886169f37aa665fbc063a53f30aff53e0733b7f9ebc0d7c1e3f1181cf9cf8c6f02255d4a40f30a4a91f674803e4999594dced71a43443e1f638d2b8fdedd05315a4aca024d093b6e113797e22cfdf1d791e7399ff5fc8f3e61027861cb0562348ae73c994362925f93be16450dca650db0f9ad612d4174bf0d4676f31a3bd86062fa1d13857e6a5ba75ff99302990da16dd13feaab9ccd6d10fa7edb1a62422960bb998302ecac1e6c885a3fc63848177e43e039a60e6b4b1107014b86edc5d37a7415bcccf161cab19c608f20cd55df30132531a477069531aa2e0683cdca4967e76b18179ff08a24a851eb1dcbd47aaa1da8735149fa235accff0aefb2d86c9fde582992f35b6da13bc0fef018e42ecee7f069a7a0686fe162c75dc3e4652aa7243e811202951fd2e685fb359800062467bcf406d5511a5f7b4e29d871e29a2ace4d4eba147b22a0b5a12d8cda7783df4e86afb17428f4e9f11721921934da094fa69c4fb8452bf3d943a66329f408a8054ec577ad97c873e298ac59d1dfbf3d047675b250eb6f2db741186f7f51be265dcf9d551910a6e1ac4672f98fff0bd0960a3f735970e26a7967f3ba01515327acddc76a1dd713014f9493be2c2e6f1e2acc04258ba009a263c9efa167fe5cab76a4b160fbd029a15b7745977daef8d0528f0427b9d8a1d1f4efb7883e90acd5eab28e8ea34af202912776e1e3b0354495bbce86c996e0b9f2f7c3d1cb945346b892b38a32b3f3607c2f3a6b8f0cb7c1e77d5bf3c06f9eb63f9cf98b486cfce9333736e066127ed635faa2464df68cf52734256cdbebb412bf36189d192a2ca5955926e3768b129de6ed08adbbfc1a0a508592d952ec736e213867dc2b0df1a869db099ff4e4faa6bae50d40deb16c18dd37e369ebe136d295c7bdbc5ca91da0b2fc8af3c82426a09eb6cb87218ebfae9fbb38a53f119e6ba8775db5ebc130eebb3c9f6c293c20dd533dfbfc7f378cf1cab7d8277e6f5e5cb73a2bdddcfd774cf63f0fa5ceadbc5f8ca9cc9d384f7db0144e0f54d7a2c45ee87c6fcfa7be27bf4584cf7e53dc37b1aa7e10153ebe8de8cd3fbf7f08ed769ebe2b3c777fee5802eda2adeab63c3be733b1addf35d3727c3eaacb1b18a4063e5e6533d98af5ac2cee889409b336143f510a394b5d88646b6a9ec53a7e03d6c1753849a37dc17b8011ae0f76bf6e1bd0c66d21d6e94a3503bd4c52daa1b5d4bd8d5dac2a847e9a7f24f1e03be436ea5d2f4b48bf7f1d4110a56561a40bcec7bee83a1c37b8a62fc3eba23621279546b91371bed6ef03e6168dd2def8b935d01f89806bceac9301a6bc368ebf5eef02445f227863d12cf8defcb5b79bc9653ca4ebb84cf5251052cd783ecbaf1dd7ce201eff07849f57074bcd3b10067761841fc93bdc77e2f7b942fb7e7303eb91f10e639f959f9163300ac6f4fb7bcf85472f99ff0467ba796cd49b9f7b777663e32d552c9089a70bbbd934df1767b67dcfe0df6ce9ca068d96ceaeebf4fd7f127d0fedd81f64556f39966f94a9ae55919d05fab3205f571940e4910c0b83dadf2bcfdf99cafba682d3fec5fa984f042401d88f1dd124b3cbb32068235b754e2b99876978228d77721409b8d17d055f368459c9c58ef96ae8e57036bb027e2c6a7ec072ca7dcb8fa2e0c66a4d44d5123e874bd3f566ed39b19db97faf3a72ca3a38e652acddd04e76d05ca3f6f481dfd196ed7857f330e499a2639134aee353b9f207545f9bbac011cf88a45fb14109bef093e345113f1d51f073e7259f97a02a89a13540d653f6b1fcdf06adcda0bedaf838fd367849ca22a5433b193da874ff4f1893e7e6bf49130984f47eb4f74b41ee525dcec187d290fe1e584d0f77fdfaf9bf700f3b864e5b8e088c51b90ad5793435fcd7be87387f8512ec2798ec97101aae3e4de1722e1b833bc7894a370e6a84f47c99d3ae88f0b92e19e56538ee0e5055ab82d07e2689cefd8efbbe747dc9c94cb2b9b1f98458be88347f0ddead8c6b3f562e22ef469c9304e84b1dcf98defff49d6b085ffe7d20349fd06007afc400443a936f45b6c606246c964739977b781e53e128666e59caadd6c03e353bcd90696b4bfdd06262a59212b6462184afbf089423f51e86f8e428fb9cc2716fd0cfafb4d82feae61c80b263d0cc0bbe0b57b6b76c59861c7d6ed58eb746d5fc8877d6f2c7b21bff645ecfa6fe4edfe68bf14655037a1dff16bc579af648bfc40d1970b992a78265830e49540454f6f9f658940bf3fc564f93c9f0e56a3c17a793b723c7b24ae61a78a37583025f1bb9cf92e64bf2a5951d3c4acf6ae16cc5c2e93fd48f7a99211e0e7ba055356d44cdc3e9ae10b16ccabed5fb0609e3e9393014d2a49093bf5b352ed2774fceda1e3199bf96fadeadfbc1c0a2831a39837c5b09ef6dcb68c0386f7793363695b8debe1783c6c07d68a97ac55868fc108406f8fc2aaea66e9927163ef4a62e8eba3d0b7a20a6693c8afbb7f0cfabbff54d5380cfbc36bf900c888defe783d5e09a13a5ebbe3395e0da38ab218d007991409b9bcc6c76363e5686fabe6ff42695ce6030e07401fde54c50c09004b785bc048f02bf94b06390e8e2edd2460acfb666dc90ab4880b1c83a74fde742381873e63bc25a103c0486a07f5d95188d93503df01fdbe783b437dd63ed87af5f866844ef5d0d05b3f2b946cb19e2e6e873fa9c671a2ac22bdcd6826c95246c88aef6d34d33ed4770bc844516e369af129de6c344bdadf6e34934455d4725292288bfbf0897c3e91cfef8d7c521ce6d360f66930fb6d0c66a26be55f73cc9eb4f9f7b263ffbdf223a30d6097df26b3d6ab182baff87a562d6fc7326aaf199cb04df93f9215bb0a7cf766ac956e1c3b2825f16de9b01951d1949cf6eee9b0d98fabef9615e55c46b8bdbe1b9fe2cde9b049fb37a4c36655491193207dda874facf589b57e6bac95e6309f58eb2762adb7609e2b0581ff7f6027a013a95af21fdb25a368f43ccda5c2b9ed0d6089b56d1a93c76e8963a7f6c2d6ab37a407c2fc8342a50bfbde0a8dcaa3490eb6892b0126981a2160ab1db77f09b097c1ab8172064b278e6d51c7b69f915f3162471dbb6da179230eab859e7c54e0f7825d09ed6bed8523f72f639e4e8d0774d1daf022b7c7c584fb567b4ee3431b9b5558a29db65aacb5daa59b8aea1226f3a4ddc6df8b3bdf34f603c066542498de597ba2ef448d7dd7791d9ff9702efa172a9ebc587898cfefe500343e9e9f5f6e6e153c850e30d1db61dd51fbf8a64b51ba0dd9c9f27759fd9a11142593d3d47746766a4e13df13d94902de28c50b9d081935a3e614f9056407edd5f816ca688a2f20bb6bed5f427627cfc0bac0b0929b2ec54f2bda27b2fbfd91dd1193f98dc01d01b7da857a18d5e153650be06ab4f08bf91d39f02889b035ec8546d7286bc556af5937cab5424be8693cf1921215612ece63a79d35caf34cbda385beded8803019f7cd1d80003124f0c0fbea96da4fdd5258ec4dca1d008dad96d1ae5e72a47207243c6f2fb16ec843d06eb4cb5aa3d3ab755a460d3053a1609486782df9d8d173c3baa90c6b725375a78d613d50d6d5a2774f00e24e98dc57da0bd7ec0d3d395cf7f7937bdb6ccedd7d61e36c17615f1a6d003452e2dfcf77325e37685d3462e9c76b424279ba433a1bc6c02ded4464027fc82bf627cfb1e8f49f6390996cde20b993c6b1f32ba3bdd1f995c92a72eedd7d5fe24746fd481949b9bd482c9ba170bbef2b6eff06df97a2c972ca1e43dbf029b53fa5f66f2eb51306f3ff536433712682f8f30f035385f72aeb7a909f15674da16fb5b45a714422b95a61a2129fbf552c82c8c2d24e47faed8ba5f92b85c98f5e08f54e375bbebd9f5f374111f66d25dd1a34eeebe1b31fbc5eb629bab9f2077d5dc39b4aed5b487b6dd1dbb318244ecba91822febd78e5c2a662fe3dfc63c357fc635139aa145c82f57e2991af73eefb62ebfe724036b5f9895563f7f0c9d47f197e458d623f587c5fb8242a59258795ecafda4ce4eff0bf247ccd08b95c26a35e28d1cf84d14be04bcce4b2d9187cc911f892b300ca04e11d4d26725612e2020d999c9091154550ae9b4c8edb4733bc6e32b9dafe3af812e40c25e471f09581d5960432b374e2cd88b097f0ab61af53a4f503d23af9e01c925d1ee16232fce63bcfdb60f6a733f533caedc3fdea2420efdbc5ce13e8f7f7374ce61f314664b47e0c1167f3d51fc16c099f8503ff0f77bdfac3d9c00974dc70009fc30741e8ffe139de689006937fff82aff8331a390ced1f9fd8f297c2969c6bc65892adf0077271feef5758a54de00d5ed7ab2fb44fe24ac5d7546b29db15c5efa204baf1574995155994e5f7b4882b624690e58f8c2b955445cd5ccfa891b2b9ac9ac4214453bcaa5b5f6f7f9dbd9f3d23c21815351557fabbc43ac420e19a6e1d37f8d4adff8bf8df25a694a8d7a668ac4195f58b68e1959aa0d6165818be45d01fd599455f32a21a29dc92198eedd6a2606309bbb18825e3285500a0fbde46f55c5743bf82b7932d93cf2ab5d03535500fc4a9b75741a5a8cd6c54ad67cd03e650bad24ac4d035fef97d315c75513d72cc6668b5d2965fb4d86afb416fb700b59c54c42ea867a012a95d3dc4cb229825f7e8b3733531e5eabfeb958db60f63eba38577d26c19465974e5f68859be93bf93e78ec20748d5ecf4b48e0d6a570bd5ec8a0faa737bde35cb0a3e73ed3bd67ff8d89b1a23bb541b813a2b3b665b704ae26870169e905e83f0d1c03421dd28f6613ebe144e7c7dd4ec5b236c57eb8a649e08afbdef8567afad51af6f359f3d818f2bc44ad1ab43dc67eaef97d688efc54357b06bdd12a9e40d072ff328a98fed49d868d3bdd2eaf167566a1db82a7ddc4f4f6b74f3dbc61dfc9caad102a39196a075a0ef854bd5a7436110bdb7dceca1d7e4b15bda36ef8662f3d0df16870b03699ccdcb40151dc75946d3410f68c09bb5b48b15c8f9d9f1f6a0629bea13cbdfe667a517e57cab07277f1466d2b149fd66a60ea00334e9ec7d666638fa0e54ec78fea97623b7e28f0656837dcfe8247e0ede3f86b989de364d3b986b5d0e5cdde8e23af5a470e648e1d2a57d6d621fdd4ecfaf777abbc6a3a96e4e43644c215ca3e7c6d5777e31f447a0d68740bbeaa3298a6ea5bda85bb866d5f8dc7f601a8f379f3d05c36fe17cf83c5fc1275ff183d731d785f611e6a2dbe65e496216814801017d5794af4a362b88394550dfd39f8111a0d98f2c8023688aa25d2f80939561159424c3984ff12ae6badefe3ae63a7d06746a4dcb262a35edc3ef80b918095e875cf1f79f88ebbf01715de74809e03ab537de337be47070c4a441d0969b8bc1d4886de69e1466ec0e5d9581c2baeb588b30256c791c206b6bebe5855b995c284682d771b4a9c005080c26944168b7d15e6a35b5270208f0ee12baa68dc393058248e27d83904c840c7b0f08d7038c6b6d4bda33ef7fed92dbdb587995b6ca3f1bbb7241f52b35d19d36f1dd47e0cb95c42dd53196e3318ee3cfb6d17c8c65df0a539f5fb097579a22f90522a1cdd68a8439b68131bf2a8818b3187c5ae06eb5c07dfdb4acfd1e7cee5fff07dffb45842b310100`)))
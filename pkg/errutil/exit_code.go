@@ -0,0 +1,109 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errutil
+
+import (
+	"errors"
+
+	"github.com/joomcode/errorx"
+)
+
+// The exit codes of the commands, the scripts can tell the failures by them.
+// Keep them stable as they are documented.
+const (
+	ExitCodeOK = 0
+	// the failures not covered by the codes below
+	ExitCodeGeneral = 1
+	// the cluster to operate on doesn't exist
+	ExitCodeClusterNotFound = 3
+	// failed to connect or authenticate to a host via SSH
+	ExitCodeSSHFailed = 4
+	// the cluster is deployed but some instances are not healthy
+	ExitCodeUnhealthy = 5
+	// interrupted by Ctrl+C or SIGTERM
+	ExitCodeInterrupted = 130
+)
+
+var (
+	// ErrCancelled means the execution is cancelled by Ctrl+C or SIGTERM, the
+	// command exits with ExitCodeInterrupted with it
+	ErrCancelled = errors.New("operation cancelled")
+
+	// ErrClusterNotFound means the meta of the cluster does not exist, the
+	// command exits with ExitCodeClusterNotFound with it
+	ErrClusterNotFound = errors.New("cluster not found")
+)
+
+var (
+	// ErrTraitClusterNotFound means that the cluster doesn't exist.
+	ErrTraitClusterNotFound = errorx.RegisterTrait("cluster_not_found")
+
+	// ErrTraitSSHFailed means that the host can't be connected via SSH.
+	ErrTraitSSHFailed = errorx.RegisterTrait("ssh_failed")
+
+	// ErrTraitUnhealthy means that the cluster is not healthy.
+	ErrTraitUnhealthy = errorx.RegisterTrait("unhealthy")
+)
+
+// exitCodeErrors are the sentinel errors with dedicated exit codes
+var exitCodeErrors = []struct {
+	err  error
+	code int
+}{
+	{ErrCancelled, ExitCodeInterrupted},
+	{ErrClusterNotFound, ExitCodeClusterNotFound},
+}
+
+// exitCodeTraits are checked in order, the first matched one decides the code
+var exitCodeTraits = []struct {
+	trait errorx.Trait
+	code  int
+}{
+	{ErrTraitClusterNotFound, ExitCodeClusterNotFound},
+	{ErrTraitSSHFailed, ExitCodeSSHFailed},
+	{ErrTraitUnhealthy, ExitCodeUnhealthy},
+}
+
+// ExitCode returns the exit code of the command failed by the error, it's
+// decided by the traits or the sentinel errors in the chain of causes
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeOK
+	}
+	for err != nil {
+		for _, e := range exitCodeErrors {
+			if err == e.err {
+				return e.code
+			}
+		}
+		if errx := errorx.Cast(err); errx != nil {
+			for _, t := range exitCodeTraits {
+				if errx.HasTrait(t.trait) {
+					return t.code
+				}
+			}
+			err = errx.Cause()
+			continue
+		}
+		switch e := err.(type) {
+		case interface{ Cause() error }:
+			err = e.Cause()
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			err = nil
+		}
+	}
+	return ExitCodeGeneral
+}
@@ -0,0 +1,44 @@
+package errutil
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/joomcode/errorx"
+	"github.com/pingcap/check"
+	"github.com/pingcap/errors"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type exitCodeSuite struct{}
+
+var _ = check.Suite(&exitCodeSuite{})
+
+func (s *exitCodeSuite) TestExitCode(c *check.C) {
+	ns := errorx.NewNamespace("test")
+	notFound := ns.NewType("not_found", ErrTraitClusterNotFound)
+	sshFailed := ns.NewType("ssh_failed", ErrTraitSSHFailed)
+	unhealthy := ns.NewType("unhealthy", ErrTraitUnhealthy)
+	other := ns.NewType("other")
+
+	c.Assert(ExitCode(nil), check.Equals, ExitCodeOK)
+	c.Assert(ExitCode(fmt.Errorf("failed")), check.Equals, ExitCodeGeneral)
+	c.Assert(ExitCode(other.New("failed")), check.Equals, ExitCodeGeneral)
+	c.Assert(ExitCode(notFound.New("cluster not found")), check.Equals, ExitCodeClusterNotFound)
+	c.Assert(ExitCode(unhealthy.New("cluster not healthy")), check.Equals, ExitCodeUnhealthy)
+
+	// the traits of the causes are found
+	c.Assert(ExitCode(other.Wrap(sshFailed.New("connect failed"), "failed")), check.Equals, ExitCodeSSHFailed)
+	c.Assert(ExitCode(errors.Annotate(sshFailed.New("connect failed"), "failed")), check.Equals, ExitCodeSSHFailed)
+	c.Assert(ExitCode(fmt.Errorf("failed: %w", notFound.New("cluster not found"))), check.Equals, ExitCodeClusterNotFound)
+	c.Assert(ExitCode(errorx.Decorate(sshFailed.New("connect failed"), "2 of 3 tasks failed")), check.Equals, ExitCodeSSHFailed)
+
+	// the sentinel errors
+	c.Assert(ExitCode(ErrClusterNotFound), check.Equals, ExitCodeClusterNotFound)
+	c.Assert(ExitCode(fmt.Errorf("%w: test", ErrClusterNotFound)), check.Equals, ExitCodeClusterNotFound)
+	c.Assert(ExitCode(errors.Trace(fmt.Errorf("%w: test", ErrClusterNotFound))), check.Equals, ExitCodeClusterNotFound)
+	c.Assert(ExitCode(ErrCancelled), check.Equals, ExitCodeInterrupted)
+	c.Assert(ExitCode(errors.Trace(ErrCancelled)), check.Equals, ExitCodeInterrupted)
+	c.Assert(ExitCode(errorx.Decorate(ErrCancelled, "2 of 3 tasks failed")), check.Equals, ExitCodeInterrupted)
+}
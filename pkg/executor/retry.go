@@ -0,0 +1,131 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"golang.org/x/crypto/ssh"
+)
+
+// The defaults of the retries of the SSH operations failed by transient errors
+const (
+	DefaultSSHRetries       = 2
+	DefaultSSHRetryInterval = 2 * time.Second
+)
+
+var (
+	sshRetries       = DefaultSSHRetries
+	sshRetryInterval = DefaultSSHRetryInterval
+)
+
+// SetSSHRetry sets how many times an SSH command failed to connect or a file
+// transfer failed by a transient network error is retried, the interval is
+// doubled after each retry
+func SetSSHRetry(retries int, interval time.Duration) {
+	sshRetries = retries
+	sshRetryInterval = interval
+}
+
+// the errors never fixed by retrying, e.g. the command exits with non-zero
+// code or the authentication is rejected
+var nonRetryableErrors = []string{
+	"unable to authenticate",
+	"permission denied",
+	"no such file or directory",
+	"process exited with status",
+}
+
+// the errors of broken or unreachable connections
+var retryableErrors = []string{
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"i/o timeout",
+	"no route to host",
+	"network is unreachable",
+	"eof",
+}
+
+// the errors of failing to connect to the host or to complete the SSH
+// handshake, which happen before the command is run
+var dialErrors = []string{
+	"dial tcp",
+	"ssh: handshake failed",
+	"ssh: rejected: connect failed",
+}
+
+// isRetryableError returns if the error is caused by a transient network
+// failure, so the operation may succeed if retried
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := err.(*ssh.ExitError); ok {
+		return false
+	}
+	if ne, ok := err.(net.Error); ok && ne.Timeout() {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, s := range nonRetryableErrors {
+		if strings.Contains(msg, s) {
+			return false
+		}
+	}
+	for _, s := range retryableErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDialError returns if the error is a transient failure of connecting to
+// the host, the command is never run in that case so it's safe to retry even
+// if the command is not idempotent
+func isDialError(err error) bool {
+	if !isRetryableError(err) {
+		return false
+	}
+	if oe, ok := err.(*net.OpError); ok && oe.Op == "dial" {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, s := range dialErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// withRetry runs fn and retries it on the errors which retryable returns true
+// for, every retry is logged so that a flaky host is visible
+func withRetry(host, op string, retryable func(error) bool, fn func() error) error {
+	interval := sshRetryInterval
+	err := fn()
+	for i := 1; i <= sshRetries && retryable(err); i++ {
+		log.Warnf("Retrying (%d/%d) in %s, %s on %s failed: %s", i, sshRetries, interval, op, host, err)
+		time.Sleep(interval)
+		interval *= 2
+		err = fn()
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/pingcap/check"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type retrySuite struct{}
+
+var _ = check.Suite(&retrySuite{})
+
+func (s *retrySuite) TestIsRetryableError(c *check.C) {
+	c.Assert(isRetryableError(nil), check.IsFalse)
+	c.Assert(isRetryableError(io.EOF), check.IsTrue)
+	c.Assert(isRetryableError(errors.New("read tcp: connection reset by peer")), check.IsTrue)
+	c.Assert(isRetryableError(errors.New("write tcp: broken pipe")), check.IsTrue)
+	c.Assert(isRetryableError(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no route to host")}), check.IsTrue)
+
+	c.Assert(isRetryableError(errors.New("ssh: handshake failed: ssh: unable to authenticate")), check.IsFalse)
+	c.Assert(isRetryableError(errors.New("Process exited with status 1")), check.IsFalse)
+	c.Assert(isRetryableError(errors.New("scp: /tmp/foo: No such file or directory")), check.IsFalse)
+	c.Assert(isRetryableError(errors.New("unexpected output")), check.IsFalse)
+}
+
+func (s *retrySuite) TestIsDialError(c *check.C) {
+	c.Assert(isDialError(nil), check.IsFalse)
+	c.Assert(isDialError(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}), check.IsTrue)
+	c.Assert(isDialError(errors.New("dial tcp 172.16.5.138:22: i/o timeout")), check.IsTrue)
+	c.Assert(isDialError(errors.New("ssh: handshake failed: EOF")), check.IsTrue)
+	c.Assert(isDialError(errors.New("ssh: rejected: connect failed (Connection refused)")), check.IsTrue)
+
+	// the command may have been run when the connection is broken
+	c.Assert(isDialError(io.EOF), check.IsFalse)
+	c.Assert(isDialError(errors.New("read tcp: connection reset by peer")), check.IsFalse)
+	c.Assert(isDialError(errors.New("write tcp: broken pipe")), check.IsFalse)
+	c.Assert(isDialError(errors.New("ssh: handshake failed: ssh: unable to authenticate")), check.IsFalse)
+}
+
+func (s *retrySuite) TestWithRetry(c *check.C) {
+	defer SetSSHRetry(DefaultSSHRetries, DefaultSSHRetryInterval)
+	SetSSHRetry(2, 0)
+
+	calls := 0
+	err := withRetry("172.16.5.138", "command", isDialError, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("ssh: handshake failed: EOF")
+		}
+		return nil
+	})
+	c.Assert(err, check.IsNil)
+	c.Assert(calls, check.Equals, 3)
+
+	calls = 0
+	err = withRetry("172.16.5.138", "command", isDialError, func() error {
+		calls++
+		return fmt.Errorf("read tcp: %w", io.EOF)
+	})
+	c.Assert(err, check.NotNil)
+	c.Assert(calls, check.Equals, 1)
+}
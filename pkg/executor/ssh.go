@@ -15,18 +15,23 @@ package executor
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/ScaleFT/sshkeys"
 	"github.com/appleboy/easyssh-proxy"
 	"github.com/fatih/color"
 	"github.com/joomcode/errorx"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/errutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
 )
 
 var (
@@ -43,6 +48,9 @@ var (
 	ErrSSHExecuteFailed = errNSSSH.NewType("execute_failed")
 	// ErrSSHExecuteTimedout is ErrSSHExecuteTimedout
 	ErrSSHExecuteTimedout = errNSSSH.NewType("execute_timedout")
+	// ErrSSHConnectFailed means the command is not run as the host can't be
+	// connected or the authentication is rejected
+	ErrSSHConnectFailed = errNSSSH.NewType("connect_failed", errutil.ErrTraitSSHFailed)
 )
 
 var executeDefaultTimeout = time.Second * 60
@@ -65,6 +73,9 @@ type (
 	SSHExecutor struct {
 		Config *easyssh.MakeConfig
 		Sudo   bool // all commands run with this executor will be using sudo
+		// ExecuteTimeout is the timeout of the commands whose caller doesn't
+		// specify one, executeDefaultTimeout is used if it's 0.
+		ExecuteTimeout time.Duration
 	}
 
 	// SSHConfig is the configuration needed to establish SSH connection.
@@ -82,6 +93,110 @@ type (
 
 var _ TiOpsExecutor = &SSHExecutor{}
 
+// SSHProxy is the bastion host which the SSH connections are tunneled through
+type SSHProxy struct {
+	Host       string
+	Port       int
+	User       string
+	KeyFile    string
+	Passphrase string
+	Timeout    time.Duration
+}
+
+// String implements the fmt.Stringer interface
+func (p *SSHProxy) String() string {
+	return fmt.Sprintf("%s@%s:%d", p.User, p.Host, p.Port)
+}
+
+// sshProxy is used by all SSH executors if it's set
+var sshProxy *SSHProxy
+
+// ParseSSHProxy parses the proxy in format of [user@]host[:port], the current
+// user and port 22 are used by default
+func ParseSSHProxy(addr, keyFile string) (*SSHProxy, error) {
+	proxy := &SSHProxy{
+		User:    utils.CurrentUser(),
+		Port:    22,
+		KeyFile: keyFile,
+	}
+	if i := strings.LastIndex(addr, "@"); i >= 0 {
+		proxy.User, addr = addr[:i], addr[i+1:]
+	}
+	if i := strings.LastIndex(addr, ":"); i >= 0 {
+		port, err := strconv.Atoi(addr[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid port of SSH proxy %s", addr)
+		}
+		proxy.Port, addr = port, addr[:i]
+	}
+	if addr == "" || proxy.User == "" {
+		return nil, fmt.Errorf("invalid SSH proxy %s, must be [user@]host[:port]", addr)
+	}
+	proxy.Host = addr
+	return proxy, nil
+}
+
+// SetSSHProxy makes all SSH executors created after tunnel through the proxy,
+// it's disabled if proxy is nil
+func SetSSHProxy(proxy *SSHProxy) {
+	sshProxy = proxy
+}
+
+// CheckSSHProxy connects to the proxy, so that a broken proxy is reported as
+// is instead of every host being unreachable
+func CheckSSHProxy() error {
+	if sshProxy == nil {
+		return nil
+	}
+	config := &easyssh.MakeConfig{
+		Server:     sshProxy.Host,
+		Port:       strconv.Itoa(sshProxy.Port),
+		User:       sshProxy.User,
+		KeyPath:    sshProxy.KeyFile,
+		Passphrase: sshProxy.Passphrase,
+		Timeout:    sshProxy.Timeout,
+	}
+	if _, _, _, err := config.Run("true", executeDefaultTimeout); err != nil {
+		return fmt.Errorf("cannot connect to the SSH proxy %s: %s", sshProxy, err)
+	}
+	return nil
+}
+
+// decryptedKeys caches the encrypted private keys decrypted in memory, in PEM
+// format and keyed by the key file, so that a key is only decrypted once
+// rather than for each connection
+var decryptedKeys sync.Map
+
+// decryptedKey returns the key file decrypted by the passphrase, false is
+// returned if the key is not encrypted or can't be decrypted, in which case
+// the key file is left to the SSH client
+func decryptedKey(keyFile, passphrase string) (string, bool) {
+	if keyFile == "" || passphrase == "" {
+		return "", false
+	}
+	if key, ok := decryptedKeys.Load(keyFile); ok {
+		return key.(string), true
+	}
+
+	buf, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return "", false
+	}
+	pk, err := sshkeys.ParseEncryptedPrivateKey(buf, []byte(passphrase))
+	if err != nil {
+		return "", false
+	}
+	// ed25519 keys can only be marshaled in the OpenSSH format
+	data, err := sshkeys.Marshal(pk, &sshkeys.MarshalOptions{Format: sshkeys.FormatClassicPEM})
+	if err != nil {
+		if data, err = sshkeys.Marshal(pk, &sshkeys.MarshalOptions{Format: sshkeys.FormatOpenSSHv1}); err != nil {
+			return "", false
+		}
+	}
+	decryptedKeys.Store(keyFile, string(data))
+	return string(data), true
+}
+
 // NewSSHExecutor create a ssh executor.
 func NewSSHExecutor(c SSHConfig, sudo bool) *SSHExecutor {
 	e := new(SSHExecutor)
@@ -109,13 +224,35 @@ func (e *SSHExecutor) Initialize(config SSHConfig) {
 		Timeout: config.Timeout, // timeout when connecting to remote
 	}
 
-	// prefer private key authentication
+	// both the private key and the password are tried if both are set
 	if len(config.KeyFile) > 0 {
 		e.Config.KeyPath = config.KeyFile
 		e.Config.Passphrase = config.Passphrase
-	} else if len(config.Password) > 0 {
+		if key, ok := decryptedKey(config.KeyFile, config.Passphrase); ok {
+			e.Config.KeyPath, e.Config.Key, e.Config.Passphrase = "", key, ""
+		}
+	}
+	if len(config.Password) > 0 {
 		e.Config.Password = config.Password
 	}
+
+	if sshProxy != nil {
+		timeout := sshProxy.Timeout
+		if timeout == 0 {
+			timeout = config.Timeout
+		}
+		e.Config.Proxy = easyssh.DefaultConfig{
+			Server:     sshProxy.Host,
+			Port:       strconv.Itoa(sshProxy.Port),
+			User:       sshProxy.User,
+			KeyPath:    sshProxy.KeyFile,
+			Passphrase: sshProxy.Passphrase,
+			Timeout:    timeout,
+		}
+		if key, ok := decryptedKey(sshProxy.KeyFile, sshProxy.Passphrase); ok {
+			e.Config.Proxy.KeyPath, e.Config.Proxy.Key, e.Config.Proxy.Passphrase = "", key, ""
+		}
+	}
 }
 
 // Execute run the command via SSH, it's not invoking any specific shell by default.
@@ -131,10 +268,22 @@ func (e *SSHExecutor) Execute(cmd string, sudo bool, timeout ...time.Duration) (
 	// run command on remote host
 	// default timeout is 60s in easyssh-proxy
 	if len(timeout) == 0 {
-		timeout = append(timeout, executeDefaultTimeout)
+		if e.ExecuteTimeout > 0 {
+			timeout = append(timeout, e.ExecuteTimeout)
+		} else {
+			timeout = append(timeout, executeDefaultTimeout)
+		}
 	}
 
-	stdout, stderr, done, err := e.Config.Run(cmd, timeout...)
+	var stdout, stderr string
+	var done bool
+	// the command may have been run partially if the connection is broken
+	// after the session started, only the failures to connect are retried
+	err := withRetry(e.Config.Server, "command", isDialError, func() error {
+		var err error
+		stdout, stderr, done, err = e.Config.Run(cmd, timeout...)
+		return err
+	})
 
 	zap.L().Info("ssh command",
 		zap.String("host", e.Config.Server),
@@ -144,7 +293,11 @@ func (e *SSHExecutor) Execute(cmd string, sudo bool, timeout ...time.Duration) (
 		zap.String("stderr", stderr))
 
 	if err != nil {
-		baseErr := ErrSSHExecuteFailed.
+		errType := ErrSSHExecuteFailed
+		if _, ok := err.(*ssh.ExitError); !ok {
+			errType = ErrSSHConnectFailed
+		}
+		baseErr := errType.
 			Wrap(err, "Failed to execute command over SSH for '%s@%s:%s'", e.Config.User, e.Config.Server, e.Config.Port).
 			WithProperty(ErrPropSSHCommand, cmd).
 			WithProperty(ErrPropSSHStdout, stdout).
@@ -155,6 +308,10 @@ func (e *SSHExecutor) Execute(cmd string, sudo bool, timeout ...time.Duration) (
 				WithProperty(cliutil.SuggestionFromFormat("Command output on remote host %s:\n%s\n",
 					e.Config.Server,
 					color.YellowString(output)))
+		} else if e.Config.Proxy.Server != "" {
+			baseErr = baseErr.
+				WithProperty(cliutil.SuggestionFromFormat("The connection is tunneled through the SSH proxy %s@%s:%s\n",
+					e.Config.Proxy.User, e.Config.Proxy.Server, e.Config.Proxy.Port))
 		}
 		return []byte(stdout), []byte(stderr), baseErr
 	}
@@ -175,6 +332,16 @@ func (e *SSHExecutor) Execute(cmd string, sudo bool, timeout ...time.Duration) (
 // This function is based on easyssh.MakeConfig.Scp() but with support of copying
 // file from remote to local.
 func (e *SSHExecutor) Transfer(src string, dst string, download bool) error {
+	op := "uploading " + src
+	if download {
+		op = "downloading " + src
+	}
+	return withRetry(e.Config.Server, op, isRetryableError, func() error {
+		return e.transfer(src, dst, download)
+	})
+}
+
+func (e *SSHExecutor) transfer(src string, dst string, download bool) error {
 	if !download {
 		return e.Config.Scp(src, dst)
 	}
@@ -0,0 +1,132 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AuditRecord is the record of a mutating operation on a cluster
+type AuditRecord struct {
+	Time      time.Time `json:"time"`
+	User      string    `json:"user"`
+	Command   string    `json:"command"`
+	Cluster   string    `json:"cluster"`
+	Operation string    `json:"operation"`
+	Nodes     []string  `json:"nodes,omitempty"`
+	Error     string    `json:"error,omitempty"` // empty if the operation succeeded
+}
+
+// secretFlags are the flags whose values are never written to the logs
+var secretFlags = []string{"--ssh-key-passphrase"}
+
+// RedactArgs returns the command line args with the values of the secret
+// flags hidden, for both --flag=value and --flag value
+func RedactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	copy(redacted, args)
+	for i := 0; i < len(redacted); i++ {
+		for _, f := range secretFlags {
+			if strings.HasPrefix(redacted[i], f+"=") {
+				redacted[i] = f + "=******"
+			} else if redacted[i] == f && i+1 < len(redacted) {
+				i++
+				redacted[i] = "******"
+			}
+		}
+	}
+	return redacted
+}
+
+// NewAuditRecord returns the record of the operation run by the current OS
+// user with the current command line, err is the result of the operation
+func NewAuditRecord(cluster, operation string, nodes []string, err error) AuditRecord {
+	record := AuditRecord{
+		Time:      time.Now(),
+		Command:   strings.Join(RedactArgs(os.Args), " "),
+		Cluster:   cluster,
+		Operation: operation,
+		Nodes:     nodes,
+	}
+	if u, uerr := user.Current(); uerr == nil {
+		record.User = u.Username
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	return record
+}
+
+// AppendAuditRecords appends the records to the audit file in JSON lines,
+// the file is created if not exists
+func AppendAuditRecords(file string, records ...AuditRecord) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// ReadAuditRecords reads all records in the audit file in the order they are
+// appended, no record is returned if the file doesn't exist
+func ReadAuditRecords(file string) ([]AuditRecord, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var r AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
+
+// Audit appends the record of the operation to the audit file, the
+// failure is only reported as a warning as it should not fail the operation
+func Audit(file, cluster, operation string, nodes []string, err error) {
+	if aerr := AppendAuditRecords(file, NewAuditRecord(cluster, operation, nodes, err)); aerr != nil {
+		Warnf("Failed to write audit record to %s: %s", file, aerr)
+	}
+}
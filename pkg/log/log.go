@@ -14,37 +14,142 @@
 package log
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/colorutil"
 	"go.uber.org/zap"
 )
 
+// The output formats of the console log
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var outputFormat = FormatText
+
+// SetFormat sets the output format of the console log, text by default
+func SetFormat(format string) error {
+	switch format {
+	case FormatText, FormatJSON:
+		outputFormat = format
+		return nil
+	}
+	return fmt.Errorf("unsupported log format '%s', should be %s or %s", format, FormatText, FormatJSON)
+}
+
+// Format returns the output format of the console log
+func Format() string {
+	return outputFormat
+}
+
+// Fields are the structured fields of a log message
+type Fields map[string]interface{}
+
+// output writes the message to the console, as a line of JSON object with
+// the level, the timestamp, the message and the fields in JSON format, or
+// the message followed by the fields in key=value pairs in text format
+func output(w io.Writer, c *color.Color, level, msg string, fields Fields) {
+	if outputFormat == FormatJSON {
+		entry := make(map[string]interface{}, len(fields)+3)
+		for k, v := range fields {
+			entry[k] = v
+		}
+		entry["level"] = level
+		entry["timestamp"] = time.Now().Format(time.RFC3339Nano)
+		entry["msg"] = msg
+		data, err := json.Marshal(entry)
+		if err != nil {
+			data, _ = json.Marshal(map[string]string{
+				"level":     level,
+				"timestamp": time.Now().Format(time.RFC3339Nano),
+				"msg":       fmt.Sprintf("%s %v", msg, map[string]interface{}(fields)),
+			})
+		}
+		_, _ = fmt.Fprintln(w, string(data))
+		return
+	}
+
+	if len(fields) > 0 {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", k, fields[k]))
+		}
+		msg = msg + " " + strings.Join(pairs, " ")
+	}
+	if c == nil {
+		_, _ = fmt.Fprintln(w, msg)
+		return
+	}
+	_, _ = c.Fprintln(w, msg)
+}
+
+func zapFields(fields Fields) []zap.Field {
+	zfs := make([]zap.Field, 0, len(fields))
+	for k, v := range fields {
+		zfs = append(zfs, zap.Any(k, v))
+	}
+	return zfs
+}
+
 // Debugf output the debug message to console
 // Deprecated: Use zap.L().Debug() instead
 func Debugf(format string, args ...interface{}) {
-	zap.L().Debug(fmt.Sprintf(format, args...))
-	_, _ = fmt.Fprintf(os.Stderr, format+"\n", args...)
+	msg := fmt.Sprintf(format, args...)
+	zap.L().Debug(msg)
+	output(os.Stderr, nil, "debug", msg, nil)
 }
 
 // Infof output the log message to console
 // Deprecated: Use zap.L().Info() instead
 func Infof(format string, args ...interface{}) {
-	zap.L().Info(fmt.Sprintf(format, args...))
-	fmt.Printf(format+"\n", args...)
+	msg := fmt.Sprintf(format, args...)
+	zap.L().Info(msg)
+	output(os.Stdout, nil, "info", msg, nil)
 }
 
 // Warnf output the warning message to console
 // Deprecated: Use zap.L().Warn() instead
 func Warnf(format string, args ...interface{}) {
-	zap.L().Warn(fmt.Sprintf(format, args...))
-	_, _ = colorutil.ColorWarningMsg.Fprintf(os.Stderr, format+"\n", args...)
+	msg := fmt.Sprintf(format, args...)
+	zap.L().Warn(msg)
+	output(os.Stderr, colorutil.ColorWarningMsg, "warn", msg, nil)
 }
 
 // Errorf output the error message to console
 // Deprecated: Use zap.L().Error() instead
 func Errorf(format string, args ...interface{}) {
-	zap.L().Error(fmt.Sprintf(format, args...))
-	_, _ = colorutil.ColorErrorMsg.Fprintf(os.Stderr, format+"\n", args...)
+	msg := fmt.Sprintf(format, args...)
+	zap.L().Error(msg)
+	output(os.Stderr, colorutil.ColorErrorMsg, "error", msg, nil)
+}
+
+// InfoWithFields output the log message with the structured fields to console
+func InfoWithFields(msg string, fields Fields) {
+	zap.L().Info(msg, zapFields(fields)...)
+	output(os.Stdout, nil, "info", msg, fields)
+}
+
+// WarnWithFields output the warning message with the structured fields to console
+func WarnWithFields(msg string, fields Fields) {
+	zap.L().Warn(msg, zapFields(fields)...)
+	output(os.Stderr, colorutil.ColorWarningMsg, "warn", msg, fields)
+}
+
+// ErrorWithFields output the error message with the structured fields to console
+func ErrorWithFields(msg string, fields Fields) {
+	zap.L().Error(msg, zapFields(fields)...)
+	output(os.Stderr, colorutil.ColorErrorMsg, "error", msg, fields)
 }
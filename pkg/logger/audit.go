@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/pingcap-incubator/tiup-cluster/pkg/base52"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
 	"go.uber.org/atomic"
@@ -42,7 +43,7 @@ func DisableAuditLog() {
 }
 
 func newAuditLogCore() zapcore.Core {
-	auditBuffer = bytes.NewBufferString(strings.Join(os.Args, " ") + "\n")
+	auditBuffer = bytes.NewBufferString(strings.Join(log.RedactArgs(os.Args), " ") + "\n")
 	encoder := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
 	return zapcore.NewCore(encoder, zapcore.Lock(zapcore.AddSync(auditBuffer)), zapcore.InfoLevel)
 }
@@ -0,0 +1,143 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// The CPU architectures of the hosts, named as GOARCH like the packages
+const (
+	ArchAMD64 = "amd64"
+	ArchARM64 = "arm64"
+)
+
+// DefaultArch is the arch of the instances deployed before the arch is
+// detected, all packages were amd64 then
+const DefaultArch = ArchAMD64
+
+// NormalizeArch returns the arch of the packages for the output of `uname -m`
+// or the arch in the topology
+func NormalizeArch(arch string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(arch)) {
+	case "x86_64", "amd64":
+		return ArchAMD64, nil
+	case "aarch64", "arm64":
+		return ArchARM64, nil
+	default:
+		return "", errors.Errorf("unsupported arch '%s', only %s and %s are supported", arch, ArchAMD64, ArchARM64)
+	}
+}
+
+// InstanceArch returns the arch of the host of the instance, the default one
+// if it's not detected or specified
+func InstanceArch(ins Instance) string {
+	a, ok := ins.(interface{ arch() string })
+	if !ok || a.arch() == "" {
+		return DefaultArch
+	}
+	return a.arch()
+}
+
+func (i *instance) arch() string {
+	field := reflect.ValueOf(i.InstanceSpec).FieldByName("Arch")
+	if !field.IsValid() {
+		return ""
+	}
+	return field.String()
+}
+
+func (i *dmInstance) arch() string {
+	field := reflect.ValueOf(i.InstanceSpec).FieldByName("Arch")
+	if !field.IsValid() {
+		return ""
+	}
+	return field.String()
+}
+
+// HostArch returns the arch of the host, the default one if it's not set to
+// any instance on the host
+func (topo *TopologySpecification) HostArch(host string) string {
+	arch := DefaultArch
+	topo.IterInstance(func(ins Instance) {
+		if a, ok := ins.(interface{ arch() string }); ok && ins.GetHost() == host && a.arch() != "" {
+			arch = a.arch()
+		}
+	})
+	return arch
+}
+
+// SetHostArch sets the arch detected on the host to the instances on it, it
+// fails if the arch specified in the topology is different
+func (topo *TopologySpecification) SetHostArch(host, arch string) error {
+	topoSpec := reflect.ValueOf(topo).Elem()
+	for i := 0; i < topoSpec.NumField(); i++ {
+		if isSkipField(topoSpec.Field(i)) {
+			continue
+		}
+
+		compSpecs := topoSpec.Field(i)
+		for index := 0; index < compSpecs.Len(); index++ {
+			compSpec := compSpecs.Index(index)
+			j, found := findField(compSpec, "Arch")
+			if !found || compSpec.FieldByName("Host").String() != host {
+				continue
+			}
+			switch specified := compSpec.Field(j).String(); specified {
+			case "":
+				compSpec.Field(j).SetString(arch)
+			case arch:
+			default:
+				return errors.Errorf("arch of host '%s' is '%s' in the topology, but '%s' is detected", host, specified, arch)
+			}
+		}
+	}
+	return nil
+}
+
+// archConflictsDetect checks the archs in the topology are supported and the
+// same for the instances on a host
+func (topo *TopologySpecification) archConflictsDetect() error {
+	archs := map[string]string{}
+	topoSpec := reflect.ValueOf(topo).Elem()
+	for i := 0; i < topoSpec.NumField(); i++ {
+		if isSkipField(topoSpec.Field(i)) {
+			continue
+		}
+
+		compSpecs := topoSpec.Field(i)
+		for index := 0; index < compSpecs.Len(); index++ {
+			compSpec := compSpecs.Index(index)
+			j, found := findField(compSpec, "Arch")
+			if !found || compSpec.Field(j).String() == "" {
+				continue
+			}
+			arch, err := NormalizeArch(compSpec.Field(j).String())
+			if err != nil {
+				return err
+			}
+			compSpec.Field(j).SetString(arch)
+
+			host := compSpec.FieldByName("Host").String()
+			if prev, exist := archs[host]; exist && prev != arch {
+				return errors.Errorf("arch of host '%s' conflicts between '%s' and '%s'", host, prev, arch)
+			}
+			archs[host] = arch
+		}
+	}
+	return nil
+}
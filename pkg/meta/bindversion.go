@@ -17,6 +17,15 @@ import (
 	"github.com/pingcap-incubator/tiup/pkg/repository"
 )
 
+// RoleVersion returns the version of the role, the override of the role in
+// overrides takes precedence over the cluster version
+func RoleVersion(role, clusterVersion string, overrides map[string]string) string {
+	if v := overrides[role]; v != "" {
+		return v
+	}
+	return clusterVersion
+}
+
 // ComponentVersion maps the TiDB version to the third components binding version
 func ComponentVersion(comp, version string) repository.Version {
 	switch comp {
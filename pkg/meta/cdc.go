@@ -48,10 +48,7 @@ func (c *CDCComponent) Instances() []Instance {
 			usedDirs: []string{
 				s.DeployDir,
 			},
-			statusFn: func(_ ...string) string {
-				url := fmt.Sprintf("http://%s:%d/status", s.Host, s.Port)
-				return statusByURL(url)
-			},
+			statusFn: s.Status,
 		}})
 	}
 	return ins
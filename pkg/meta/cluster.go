@@ -14,12 +14,19 @@
 package meta
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/joomcode/errorx"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/errutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/file"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/version"
 	"github.com/pingcap/errors"
@@ -33,27 +40,157 @@ const (
 	PatchDirName = "patch"
 	// BackupDirName is the directory to save backup files.
 	BackupDirName = "backup"
+	// AuditFileName is the file of the audit records of mutating operations.
+	AuditFileName = "audit.log"
+	// DefaultMetaBackups is the number of meta backups kept in BackupDirName
+	// by default.
+	DefaultMetaBackups = 20
 )
 
+// maxMetaBackups is the number of meta backups kept in BackupDirName
+var maxMetaBackups = DefaultMetaBackups
+
+// SetMaxMetaBackups sets the number of meta backups kept in BackupDirName,
+// the older ones are removed each time the meta is saved
+func SetMaxMetaBackups(n int) {
+	maxMetaBackups = n
+}
+
 var (
 	errNSCluster = errNS.NewSubNamespace("cluster")
 	// ErrClusterCreateDirFailed is ErrClusterCreateDirFailed
 	ErrClusterCreateDirFailed = errNSCluster.NewType("create_dir_failed")
 	// ErrClusterSaveMetaFailed is ErrClusterSaveMetaFailed
 	ErrClusterSaveMetaFailed = errNSCluster.NewType("save_meta_failed")
+
+	// ErrClusterNotFound is returned when the meta of the cluster does not exist,
+	// it can be matched with errors.Is
+	ErrClusterNotFound = errutil.ErrClusterNotFound
+	// ErrMetaCorrupt is returned when the meta of the cluster can't be parsed,
+	// it can be matched with errors.Is
+	ErrMetaCorrupt = errors.New("cluster meta corrupted")
 )
 
 // ClusterMeta is the specification of generic cluster metadata
 type ClusterMeta struct {
+	// SchemaVersion is the version of the schema of the meta file, the older
+	// ones are migrated to MetaSchemaVersion on load
+	SchemaVersion int `yaml:"schema_version,omitempty"`
+
 	User    string `yaml:"user"`         // the user to run and manage cluster on remote
 	Version string `yaml:"tidb_version"` // the version of TiDB cluster
 	//EnableTLS      bool   `yaml:"enable_tls"`
 	//EnableFirewall bool   `yaml:"firewall"`
 	OpsVer string `yaml:"last_ops_ver,omitempty"` // the version of ourself that updated the meta last time
+	// ComponentVersions overrides the cluster version for specific roles, e.g. a patched TiKV
+	ComponentVersions map[string]string `yaml:"component_versions,omitempty"`
+	// PrevVersion and PrevComponentVersions are the versions before the last
+	// upgrade, they are the target of rolling back the upgrade
+	PrevVersion           string            `yaml:"prev_tidb_version,omitempty"`
+	PrevComponentVersions map[string]string `yaml:"prev_component_versions,omitempty"`
+	// Instances records how each instance was created, keyed by the instance ID
+	Instances map[string]*InstanceMeta `yaml:"instances,omitempty"`
 
 	Topology *TopologySpecification `yaml:"topology"`
 }
 
+// Origins of instances
+const (
+	InstanceOriginManual      = "manual"
+	InstanceOriginAutoScaling = "auto-scaling"
+)
+
+// InstanceMeta is the metadata of an instance recorded when it's created
+type InstanceMeta struct {
+	Origin     string    `yaml:"origin,omitempty"` // manual or auto-scaling
+	DeployedAt time.Time `yaml:"deployed_at,omitempty"`
+	Patch      string    `yaml:"patch,omitempty"` // the package patched to the instance
+}
+
+// StampInstances records the origin and deploy time of all instances in topo
+func (m *ClusterMeta) StampInstances(topo Specification, origin string) {
+	if m.Instances == nil {
+		m.Instances = make(map[string]*InstanceMeta)
+	}
+	now := time.Now()
+	topo.IterInstance(func(inst Instance) {
+		m.Instances[inst.ID()] = &InstanceMeta{Origin: origin, DeployedAt: now}
+	})
+}
+
+// InstanceOrigin returns the origin of the instance, an empty string is
+// returned for the instance created before origins were recorded
+func (m *ClusterMeta) InstanceOrigin(id string) string {
+	if im, ok := m.Instances[id]; ok {
+		return im.Origin
+	}
+	return ""
+}
+
+// InstanceDeployedAt returns the deploy time of the instance, false is
+// returned if it's not recorded
+func (m *ClusterMeta) InstanceDeployedAt(id string) (time.Time, bool) {
+	if im, ok := m.Instances[id]; ok && !im.DeployedAt.IsZero() {
+		return im.DeployedAt, true
+	}
+	return time.Time{}, false
+}
+
+// PruneInstances removes the records of the instances not in the topology
+// anymore, e.g. the destroyed tombstone instances
+func (m *ClusterMeta) PruneInstances() {
+	if m.Instances == nil {
+		return
+	}
+	existing := make(map[string]bool)
+	m.Topology.IterInstance(func(inst Instance) {
+		existing[inst.ID()] = true
+	})
+	for id := range m.Instances {
+		if !existing[id] {
+			delete(m.Instances, id)
+		}
+	}
+}
+
+// PatchInstances records the package patched to the instances
+func (m *ClusterMeta) PatchInstances(ids []string, patch string) {
+	if m.Instances == nil {
+		m.Instances = make(map[string]*InstanceMeta)
+	}
+	for _, id := range ids {
+		im, ok := m.Instances[id]
+		if !ok {
+			im = &InstanceMeta{}
+			m.Instances[id] = im
+		}
+		im.Patch = patch
+	}
+}
+
+// InstancePatch returns the package patched to the instance, an empty string
+// is returned if the instance is not patched
+func (m *ClusterMeta) InstancePatch(id string) string {
+	if im, ok := m.Instances[id]; ok {
+		return im.Patch
+	}
+	return ""
+}
+
+// ClearPatches forgets the patches of all instances, e.g. the binaries are
+// replaced by an upgrade
+func (m *ClusterMeta) ClearPatches() {
+	for _, im := range m.Instances {
+		im.Patch = ""
+	}
+}
+
+// RoleVersion returns the version of the role in the cluster, it's the
+// cluster version unless it is overridden for the role
+func (m *ClusterMeta) RoleVersion(role string) string {
+	return RoleVersion(role, m.Version, m.ComponentVersions)
+}
+
 // EnsureClusterDir ensures that the cluster directory exists.
 func EnsureClusterDir(clusterName string) error {
 	if err := utils.CreateDir(ClusterPath(clusterName)); err != nil {
@@ -75,6 +212,7 @@ func SaveClusterMeta(clusterName string, meta *ClusterMeta) error {
 
 	// set the cmd version
 	meta.OpsVer = version.NewTiOpsVersion().FullInfo()
+	meta.SchemaVersion = MetaSchemaVersion
 
 	if err := EnsureClusterDir(clusterName); err != nil {
 		return wrapError(err)
@@ -84,7 +222,19 @@ func SaveClusterMeta(clusterName string, meta *ClusterMeta) error {
 		return wrapError(err)
 	}
 
-	data, err := yaml.Marshal(meta)
+	// the sensitive fields are encrypted if the meta key is set
+	key, err := metaKey()
+	if err != nil {
+		return wrapError(err)
+	}
+	saved := meta
+	if key != nil {
+		if saved, err = encryptMeta(meta, key); err != nil {
+			return wrapError(err)
+		}
+	}
+
+	data, err := yaml.Marshal(saved)
 	if err != nil {
 		return wrapError(err)
 	}
@@ -94,21 +244,92 @@ func SaveClusterMeta(clusterName string, meta *ClusterMeta) error {
 		return wrapError(err)
 	}
 
+	// the meta is saved already, the backups are pruned next time
+	if err := pruneMetaBackups(clusterName); err != nil {
+		log.Warnf("Failed to remove the old meta backups of cluster %s: %s", clusterName, err)
+	}
+
+	return nil
+}
+
+// ListMetaBackups returns the meta backups of the cluster saved by
+// SaveClusterMeta, the newest first
+func ListMetaBackups(clusterName string) ([]os.FileInfo, error) {
+	fileInfos, err := ioutil.ReadDir(ClusterPath(clusterName, BackupDirName))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.AddStack(err)
+	}
+
+	var backups []os.FileInfo
+	prefix := strings.TrimSuffix(MetaFileName, filepath.Ext(MetaFileName)) + "-"
+	for _, fi := range fileInfos {
+		if fi.Mode().IsRegular() && strings.HasPrefix(fi.Name(), prefix) {
+			backups = append(backups, fi)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ModTime().After(backups[j].ModTime())
+	})
+	return backups, nil
+}
+
+// pruneMetaBackups removes the meta backups but the newest maxMetaBackups
+func pruneMetaBackups(clusterName string) error {
+	backups, err := ListMetaBackups(clusterName)
+	if err != nil {
+		return err
+	}
+	for i := maxMetaBackups; i < len(backups); i++ {
+		if err := os.Remove(ClusterPath(clusterName, BackupDirName, backups[i].Name())); err != nil {
+			return errors.AddStack(err)
+		}
+	}
 	return nil
 }
 
+// RestoreMetaBackup replaces the meta of the cluster with the backup named
+// backupName, the current meta is backed up before being replaced
+func RestoreMetaBackup(clusterName, backupName string) error {
+	if filepath.Base(backupName) != backupName {
+		return errors.Errorf("invalid meta backup name %s", backupName)
+	}
+	backupFile := ClusterPath(clusterName, BackupDirName, backupName)
+	data, err := ioutil.ReadFile(backupFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.Errorf("cannot find meta backup %s of cluster %s", backupName, clusterName)
+		}
+		return errors.AddStack(err)
+	}
+
+	cm, _, err := ParseClusterMeta(data, backupFile)
+	if err != nil {
+		return err
+	}
+	return SaveClusterMeta(clusterName, cm)
+}
+
 // ClusterMetadata tries to read the metadata of a cluster from file
 func ClusterMetadata(clusterName string) (*ClusterMeta, error) {
-	var cm ClusterMeta
 	topoFile := ClusterPath(clusterName, MetaFileName)
 
 	yamlFile, err := ioutil.ReadFile(topoFile)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, clusterName)
+		}
 		return nil, errors.Trace(err)
 	}
 
-	if err = yaml.Unmarshal(yamlFile, &cm); err != nil {
-		return nil, errors.Trace(err)
+	// the outdated meta is only upgraded in memory, reading it must not
+	// change the file, it's written in the new format by the next
+	// SaveClusterMeta with the outdated one kept as a backup
+	cm, outdated, err := ParseClusterMeta(yamlFile, topoFile)
+	if err != nil {
+		return nil, err
+	}
+	if outdated {
+		log.Debugf("The meta file of cluster %s is outdated, it's upgraded on the next change", clusterName)
 	}
-	return &cm, nil
+	return cm, nil
 }
@@ -0,0 +1,32 @@
+package meta
+
+import (
+	"os"
+
+	tiuplocaldata "github.com/pingcap-incubator/tiup/pkg/localdata"
+	. "github.com/pingcap/check"
+)
+
+type metaBackupSuite struct{}
+
+var _ = Suite(&metaBackupSuite{})
+
+func (s *metaBackupSuite) TestPruneMetaBackups(c *C) {
+	os.Setenv(tiuplocaldata.EnvNameComponentDataDir, c.MkDir())
+	defer os.Unsetenv(tiuplocaldata.EnvNameComponentDataDir)
+	c.Assert(Initialize("cluster"), IsNil)
+	SetMaxMetaBackups(2)
+	defer SetMaxMetaBackups(DefaultMetaBackups)
+
+	cm := &ClusterMeta{User: "tidb", Version: "v4.0.0", Topology: &TopologySpecification{}}
+	for i := 0; i < 5; i++ {
+		c.Assert(SaveClusterMeta("test", cm), IsNil)
+	}
+	backups, err := ListMetaBackups("test")
+	c.Assert(err, IsNil)
+	c.Assert(backups, HasLen, 2)
+
+	// the backups are restorable
+	c.Assert(RestoreMetaBackup("test", backups[0].Name()), IsNil)
+	c.Assert(RestoreMetaBackup("test", "../meta.yaml"), NotNil)
+}
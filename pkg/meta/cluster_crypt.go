@@ -0,0 +1,158 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+const (
+	// EnvNameMetaKey is the env var of the key to encrypt the sensitive
+	// fields of the meta file, a base64 encoded 32 bytes AES-256 key. Only
+	// the fields listed by sensitiveFields are encrypted, the hosts, ports
+	// and dirs of the topology are still in plaintext, and the SSH keys of
+	// the cluster are separate files not covered by it.
+	EnvNameMetaKey = "TIUP_CLUSTER_META_KEY"
+	// EnvNameMetaKeyFile is the env var of the path to the file containing
+	// the key, it's used if EnvNameMetaKey is not set
+	EnvNameMetaKeyFile = "TIUP_CLUSTER_META_KEY_FILE"
+
+	// encryptedPrefix marks the encrypted value of a field in the meta file
+	encryptedPrefix = "enc:v1:"
+)
+
+// ErrMetaKeyMissing is returned when the meta file is encrypted but no key
+// is set, it can be matched with errors.Is
+var ErrMetaKeyMissing = errors.New("cluster meta key missing")
+
+// sensitiveFields returns the fields of the meta encrypted at rest, i.e. the
+// deploy user and the credentials of the Grafana API, the topology structure
+// is kept readable
+func sensitiveFields(cm *ClusterMeta) []*string {
+	fields := []*string{&cm.User}
+	if cm.Topology != nil {
+		fields = append(fields, &cm.Topology.GlobalOptions.User)
+		for i := range cm.Topology.Grafana {
+			fields = append(fields, &cm.Topology.Grafana[i].Username, &cm.Topology.Grafana[i].Password)
+		}
+	}
+	return fields
+}
+
+// metaKey returns the key to encrypt the meta, nil if it's not set so that
+// the meta is saved in plaintext
+func metaKey() ([]byte, error) {
+	encoded := os.Getenv(EnvNameMetaKey)
+	source := "$" + EnvNameMetaKey
+	if encoded == "" {
+		file := os.Getenv(EnvNameMetaKeyFile)
+		if file == "" {
+			return nil, nil
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Annotatef(err, "failed to read the meta key file %s", file)
+		}
+		encoded, source = string(data), file
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(key) != 32 {
+		return nil, errors.Errorf("the meta key in %s should be 32 bytes encoded in base64", source)
+	}
+	return key, nil
+}
+
+// encryptMeta returns a copy of the meta with the sensitive fields
+// encrypted, the meta itself is unchanged
+func encryptMeta(cm *ClusterMeta, key []byte) (*ClusterMeta, error) {
+	encrypted := *cm
+	if cm.Topology != nil {
+		topo := *cm.Topology
+		// the fields in the slices are encrypted in the copies
+		topo.Grafana = append([]GrafanaSpec(nil), cm.Topology.Grafana...)
+		encrypted.Topology = &topo
+	}
+
+	gcm, err := newMetaGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range sensitiveFields(&encrypted) {
+		if *field == "" {
+			continue
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return nil, errors.AddStack(err)
+		}
+		sealed := gcm.Seal(nonce, nonce, []byte(*field), nil)
+		*field = encryptedPrefix + base64.StdEncoding.EncodeToString(sealed)
+	}
+	return &encrypted, nil
+}
+
+// decryptMeta decrypts the sensitive fields of the meta in place, it
+// returns if any of them is in plaintext while the key is set, i.e. the meta
+// should be saved again to be encrypted
+func decryptMeta(cm *ClusterMeta, key []byte, file string) (bool, error) {
+	var gcm cipher.AEAD
+	plaintext := false
+	for _, field := range sensitiveFields(cm) {
+		if !strings.HasPrefix(*field, encryptedPrefix) {
+			plaintext = plaintext || *field != ""
+			continue
+		}
+		if key == nil {
+			return false, fmt.Errorf("%w: %s is encrypted, set the key by $%s or $%s",
+				ErrMetaKeyMissing, file, EnvNameMetaKey, EnvNameMetaKeyFile)
+		}
+		if gcm == nil {
+			var err error
+			if gcm, err = newMetaGCM(key); err != nil {
+				return false, err
+			}
+		}
+
+		sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(*field, encryptedPrefix))
+		if err != nil || len(sealed) < gcm.NonceSize() {
+			return false, fmt.Errorf("%w: %s: invalid encrypted field", ErrMetaCorrupt, file)
+		}
+		data, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+		if err != nil {
+			return false, errors.Errorf("failed to decrypt %s, the meta key may be wrong", file)
+		}
+		*field = string(data)
+	}
+	return plaintext && key != nil, nil
+}
+
+func newMetaGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	return gcm, errors.AddStack(err)
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"bytes"
+	"encoding/base64"
+	stderrors "errors"
+	"os"
+	"strings"
+
+	. "github.com/pingcap/check"
+)
+
+func (s *clusterMetaSuite) TestEncryptMeta(c *C) {
+	key := bytes.Repeat([]byte{1}, 32)
+	cm := &ClusterMeta{
+		User:    "tidb",
+		Version: "v4.0.0",
+		Topology: &TopologySpecification{
+			GlobalOptions: GlobalOptions{User: "tidb"},
+			Grafana:       []GrafanaSpec{{Host: "172.16.5.138", Username: "admin", Password: "secret"}},
+		},
+	}
+
+	encrypted, err := encryptMeta(cm, key)
+	c.Assert(err, IsNil)
+	for _, field := range sensitiveFields(encrypted) {
+		c.Assert(strings.HasPrefix(*field, encryptedPrefix), IsTrue)
+	}
+	c.Assert(encrypted.Version, Equals, "v4.0.0")
+	c.Assert(encrypted.Topology.Grafana[0].Host, Equals, "172.16.5.138")
+
+	// the meta itself is unchanged
+	c.Assert(cm.User, Equals, "tidb")
+	c.Assert(cm.Topology.GlobalOptions.User, Equals, "tidb")
+	c.Assert(cm.Topology.Grafana[0].Password, Equals, "secret")
+
+	plaintext, err := decryptMeta(encrypted, key, "meta.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(plaintext, IsFalse)
+	c.Assert(encrypted, DeepEquals, cm)
+}
+
+func (s *clusterMetaSuite) TestDecryptMeta(c *C) {
+	key := bytes.Repeat([]byte{1}, 32)
+	cm := &ClusterMeta{User: "tidb", Topology: &TopologySpecification{}}
+	encrypted, err := encryptMeta(cm, key)
+	c.Assert(err, IsNil)
+
+	// the key is missing
+	missing := *encrypted
+	_, err = decryptMeta(&missing, nil, "meta.yaml")
+	c.Assert(stderrors.Is(err, ErrMetaKeyMissing), IsTrue)
+
+	// the key is wrong
+	wrong := *encrypted
+	_, err = decryptMeta(&wrong, bytes.Repeat([]byte{2}, 32), "meta.yaml")
+	c.Assert(err, NotNil)
+
+	// the plaintext meta should be encrypted once the key is set
+	plaintext, err := decryptMeta(&ClusterMeta{User: "tidb"}, key, "meta.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(plaintext, IsTrue)
+	plaintext, err = decryptMeta(&ClusterMeta{User: "tidb"}, nil, "meta.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(plaintext, IsFalse)
+}
+
+func (s *clusterMetaSuite) TestMetaKey(c *C) {
+	key, err := metaKey()
+	c.Assert(err, IsNil)
+	c.Assert(key, IsNil)
+
+	os.Setenv(EnvNameMetaKey, base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)))
+	key, err = metaKey()
+	c.Assert(err, IsNil)
+	c.Assert(key, HasLen, 32)
+
+	os.Setenv(EnvNameMetaKey, base64.StdEncoding.EncodeToString([]byte("short")))
+	_, err = metaKey()
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// MetaSchemaVersion is the schema version of the meta file written by this
+// version of tiup-cluster. Increase it with a migration appended to
+// metaMigrations once the schema is changed incompatibly.
+const MetaSchemaVersion = 1
+
+// ErrMetaTooNew is returned when the meta file is written by a newer version
+// of tiup-cluster, it can be matched with errors.Is
+var ErrMetaTooNew = errors.New("cluster meta too new")
+
+// metaMigrations[i] upgrades the meta file from schema version i to i+1, it
+// works on the raw YAML as the old schema may not fit ClusterMeta
+var metaMigrations = []func(raw map[string]interface{}) error{
+	// 0 -> 1: the schema version is introduced, nothing else is changed
+	func(raw map[string]interface{}) error { return nil },
+}
+
+// ParseClusterMeta parses the content of the meta file, which is migrated to
+// MetaSchemaVersion if it's of an older one, and decrypts the sensitive
+// fields. It returns if the meta is outdated, i.e. migrated or in plaintext
+// while the meta key is set, it's written in the new format by the next save.
+func ParseClusterMeta(data []byte, file string) (*ClusterMeta, bool, error) {
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, false, fmt.Errorf("%w: %s: %v", ErrMetaCorrupt, file, err)
+	}
+
+	version := 0
+	if v, ok := raw["schema_version"]; ok {
+		if version, ok = v.(int); !ok {
+			return nil, false, fmt.Errorf("%w: %s: invalid schema_version %v", ErrMetaCorrupt, file, v)
+		}
+	}
+	if version > MetaSchemaVersion {
+		return nil, false, fmt.Errorf("%w: %s is of schema version %d but only %d is supported, please upgrade tiup-cluster",
+			ErrMetaTooNew, file, version, MetaSchemaVersion)
+	}
+
+	migrated := version < MetaSchemaVersion
+	if migrated {
+		for ; version < MetaSchemaVersion; version++ {
+			if err := metaMigrations[version](raw); err != nil {
+				return nil, false, fmt.Errorf("%w: %s: failed to migrate from schema version %d: %v", ErrMetaCorrupt, file, version, err)
+			}
+		}
+		raw["schema_version"] = MetaSchemaVersion
+		var err error
+		if data, err = yaml.Marshal(raw); err != nil {
+			return nil, false, errors.AddStack(err)
+		}
+	}
+
+	var cm ClusterMeta
+	if err := yaml.Unmarshal(data, &cm); err != nil {
+		return nil, false, fmt.Errorf("%w: %s: %v", ErrMetaCorrupt, file, err)
+	}
+
+	key, err := metaKey()
+	if err != nil {
+		return nil, false, err
+	}
+	unencrypted, err := decryptMeta(&cm, key, file)
+	if err != nil {
+		return nil, false, err
+	}
+	return &cm, migrated || unencrypted, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	stderrors "errors"
+	"os"
+
+	. "github.com/pingcap/check"
+)
+
+type clusterMetaSuite struct{}
+
+var _ = Suite(&clusterMetaSuite{})
+
+func (s *clusterMetaSuite) SetUpTest(c *C) {
+	os.Unsetenv(EnvNameMetaKey)
+	os.Unsetenv(EnvNameMetaKeyFile)
+}
+
+func (s *clusterMetaSuite) TearDownTest(c *C) {
+	os.Unsetenv(EnvNameMetaKey)
+	os.Unsetenv(EnvNameMetaKeyFile)
+}
+
+func (s *clusterMetaSuite) TestParseClusterMetaMigration(c *C) {
+	// written before the schema version is introduced
+	cm, outdated, err := ParseClusterMeta([]byte(`
+user: tidb
+tidb_version: v4.0.0
+topology:
+  pd_servers:
+    - host: 172.16.5.138
+`), "meta.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(outdated, IsTrue)
+	c.Assert(cm.SchemaVersion, Equals, MetaSchemaVersion)
+	c.Assert(cm.User, Equals, "tidb")
+	c.Assert(cm.Version, Equals, "v4.0.0")
+	c.Assert(cm.Topology.PDServers, HasLen, 1)
+
+	// up to date
+	cm, outdated, err = ParseClusterMeta([]byte(`
+schema_version: 1
+user: tidb
+tidb_version: v4.0.0
+topology:
+  pd_servers:
+    - host: 172.16.5.138
+`), "meta.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(outdated, IsFalse)
+	c.Assert(cm.SchemaVersion, Equals, MetaSchemaVersion)
+}
+
+func (s *clusterMetaSuite) TestParseClusterMetaInvalid(c *C) {
+	_, _, err := ParseClusterMeta([]byte("schema_version: 99\nuser: tidb\n"), "meta.yaml")
+	c.Assert(stderrors.Is(err, ErrMetaTooNew), IsTrue)
+
+	_, _, err = ParseClusterMeta([]byte("schema_version: one\n"), "meta.yaml")
+	c.Assert(stderrors.Is(err, ErrMetaCorrupt), IsTrue)
+
+	_, _, err = ParseClusterMeta([]byte("user: [tidb\n"), "meta.yaml")
+	c.Assert(stderrors.Is(err, ErrMetaCorrupt), IsTrue)
+}
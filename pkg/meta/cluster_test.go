@@ -0,0 +1,19 @@
+package meta
+
+import (
+	. "github.com/pingcap/check"
+)
+
+func (s *clusterMetaSuite) TestPruneInstances(c *C) {
+	topo := &TopologySpecification{
+		TiKVServers: []TiKVSpec{{Host: "172.16.5.140", Port: 20160}},
+	}
+	cm := &ClusterMeta{User: "tidb", Version: "v4.0.0", Topology: topo}
+	cm.StampInstances(topo, "manual")
+	cm.Instances["172.16.5.141:20160"] = &InstanceMeta{Origin: "manual"}
+
+	cm.PruneInstances()
+	c.Assert(cm.Instances, HasLen, 1)
+	c.Assert(cm.InstanceOrigin("172.16.5.140:20160"), Equals, "manual")
+	c.Assert(cm.InstanceOrigin("172.16.5.141:20160"), Equals, "")
+}
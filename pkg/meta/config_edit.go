@@ -0,0 +1,136 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"reflect"
+
+	"github.com/pingcap/errors"
+)
+
+// globalConfig returns the pointer to the server_configs section of the
+// component, nil if the component has no such section
+func (topo *ClusterSpecification) globalConfig(comp string) *map[string]interface{} {
+	switch comp {
+	case ComponentTiDB:
+		return &topo.ServerConfigs.TiDB
+	case ComponentTiKV:
+		return &topo.ServerConfigs.TiKV
+	case ComponentPD:
+		return &topo.ServerConfigs.PD
+	case ComponentTiFlash:
+		return &topo.ServerConfigs.TiFlash
+	case ComponentPump:
+		return &topo.ServerConfigs.Pump
+	case ComponentDrainer:
+		return &topo.ServerConfigs.Drainer
+	case ComponentCDC:
+		return &topo.ServerConfigs.CDC
+	}
+	return nil
+}
+
+// GlobalConfig returns the config in server_configs of the component with
+// the dotted keys expanded
+func (topo *ClusterSpecification) GlobalConfig(comp string) (map[string]interface{}, error) {
+	conf := topo.globalConfig(comp)
+	if conf == nil {
+		return nil, errors.Errorf("the config of %s can't be edited", comp)
+	}
+	return flattenMap(*conf)
+}
+
+// SetGlobalConfig replaces the config in server_configs of the component
+func (topo *ClusterSpecification) SetGlobalConfig(comp string, config map[string]interface{}) error {
+	conf := topo.globalConfig(comp)
+	if conf == nil {
+		return errors.Errorf("the config of %s can't be edited", comp)
+	}
+	*conf = config
+	return nil
+}
+
+// InstanceConfig returns the effective config of the instance, i.e. its own
+// config merged over the one in server_configs
+func (topo *ClusterSpecification) InstanceConfig(ins Instance) (map[string]interface{}, error) {
+	global, err := topo.GlobalConfig(ins.ComponentName())
+	if err != nil {
+		return nil, err
+	}
+	spec, err := topo.instanceSpecValue(ins)
+	if err != nil {
+		return nil, err
+	}
+	var own map[string]interface{}
+	if field := spec.FieldByName("Config"); field.IsValid() {
+		own, _ = field.Interface().(map[string]interface{})
+	}
+	return merge(global, own)
+}
+
+// SetEffectiveInstanceConfig sets the effective config of the instance, only
+// the items different from the ones in server_configs are kept in the config
+// of the instance
+func (topo *ClusterSpecification) SetEffectiveInstanceConfig(ins Instance, config map[string]interface{}) error {
+	global, err := topo.GlobalConfig(ins.ComponentName())
+	if err != nil {
+		return err
+	}
+	config, err = flattenMap(config)
+	if err != nil {
+		return err
+	}
+	own, err := diffConfig(global, config, "")
+	if err != nil {
+		return errors.Annotatef(err, "instance %s", ins.ID())
+	}
+	_, err = topo.SetInstanceConfig(ins, own)
+	return err
+}
+
+// diffConfig returns the items of conf different from the ones of global,
+// it fails if an item of global is removed as it can't be done for a single
+// instance
+func diffConfig(global, conf map[string]interface{}, prefix string) (map[string]interface{}, error) {
+	for k := range global {
+		if _, ok := conf[k]; !ok {
+			return nil, errors.Errorf("item %s%s of server_configs can't be removed from an instance, edit the role instead", prefix, k)
+		}
+	}
+
+	diff := map[string]interface{}{}
+	for k, v := range conf {
+		gv, ok := global[k]
+		if !ok {
+			diff[k] = v
+			continue
+		}
+		gm, lhsOk := gv.(map[string]interface{})
+		vm, rhsOk := v.(map[string]interface{})
+		if lhsOk && rhsOk {
+			sub, err := diffConfig(gm, vm, prefix+k+".")
+			if err != nil {
+				return nil, err
+			}
+			if len(sub) > 0 {
+				diff[k] = sub
+			}
+			continue
+		}
+		if !reflect.DeepEqual(gv, v) {
+			diff[k] = v
+		}
+	}
+	return diff, nil
+}
@@ -51,10 +51,7 @@ func (c *DrainerComponent) Instances() []Instance {
 				s.DeployDir,
 				s.DataDir,
 			},
-			statusFn: func(_ ...string) string {
-				url := fmt.Sprintf("http://%s:%d/status", s.Host, s.Port)
-				return statusByURL(url)
-			},
+			statusFn: s.Status,
 		}})
 	}
 	return ins
@@ -131,6 +128,11 @@ func (i *DrainerInstance) InitConfig(e executor.TiOpsExecutor, clusterName, clus
 		specConfig = mergedConfig
 	}
 
+	specConfig, err := i.initTLS(e, clusterName, i.topo.ServerConfigs.Drainer, specConfig, paths)
+	if err != nil {
+		return err
+	}
+
 	if err := i.mergeServerConfig(e, i.topo.ServerConfigs.Drainer, specConfig, paths); err != nil {
 		return err
 	}
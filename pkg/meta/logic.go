@@ -21,7 +21,6 @@ import (
 	"reflect"
 	"strings"
 
-	"github.com/google/uuid"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/clusterutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
@@ -151,7 +150,9 @@ func (i *instance) InitConfig(e executor.TiOpsExecutor, _, _, user string, paths
 		WithMemoryLimit(resource.MemoryLimit).
 		WithCPUQuota(resource.CPUQuota).
 		WithIOReadBandwidthMax(resource.IOReadBandwidthMax).
-		WithIOWriteBandwidthMax(resource.IOWriteBandwidthMax)
+		WithIOWriteBandwidthMax(resource.IOWriteBandwidthMax).
+		WithLimitNOFILE(resource.LimitNOFILE).
+		WithTasksMax(resource.TasksMax)
 
 	// For not auto start if using binlogctl to offline.
 	// bad design
@@ -159,19 +160,10 @@ func (i *instance) InitConfig(e executor.TiOpsExecutor, _, _, user string, paths
 		systemCfg.Restart = "on-failure"
 	}
 
-	if err := systemCfg.ConfigToFile(sysCfg); err != nil {
+	if err := InstallService(e, host, i.topo.GlobalOptions.ServiceManager, systemCfg, i.ServiceName(), sysCfg); err != nil {
 		return err
 	}
-	tgt := filepath.Join("/tmp", comp+"_"+uuid.New().String()+".service")
-	if err := e.Transfer(sysCfg, tgt, false); err != nil {
-		return err
-	}
-	cmd := fmt.Sprintf("mv %s /etc/systemd/system/%s-%d.service", tgt, comp, port)
-	if _, _, err := e.Execute(cmd, true); err != nil {
-		return errors.Annotatef(err, "execute: %s", cmd)
-	}
-
-	return nil
+	return i.installLogRotation(e, user, paths)
 }
 
 // mergeServerConfig merges the server configuration and overwrite the global configuration
@@ -260,6 +252,16 @@ func (i *instance) DataDir() string {
 	return dataDir.String()
 }
 
+// InstanceResourceControl returns the resource control of the instance merged
+// with the global one, it's what the systemd unit of the instance applies
+func (topo *ClusterSpecification) InstanceResourceControl(ins Instance) ResourceControl {
+	rc, ok := ins.(interface{ resourceControl() ResourceControl })
+	if !ok {
+		return topo.GlobalOptions.ResourceControl
+	}
+	return MergeResourceControl(topo.GlobalOptions.ResourceControl, rc.resourceControl())
+}
+
 // MergeResourceControl merge the rhs into lhs and overwrite rhs if lhs has value for same field
 func MergeResourceControl(lhs, rhs ResourceControl) ResourceControl {
 	if rhs.MemoryLimit != "" {
@@ -274,6 +276,12 @@ func MergeResourceControl(lhs, rhs ResourceControl) ResourceControl {
 	if rhs.IOWriteBandwidthMax != "" {
 		lhs.IOWriteBandwidthMax = rhs.IOWriteBandwidthMax
 	}
+	if rhs.LimitNOFILE != "" {
+		lhs.LimitNOFILE = rhs.LimitNOFILE
+	}
+	if rhs.TasksMax != "" {
+		lhs.TasksMax = rhs.TasksMax
+	}
 	return lhs
 }
 
@@ -283,6 +291,24 @@ func (i *instance) resourceControl() ResourceControl {
 		Interface().(ResourceControl)
 }
 
+// InstanceNumaNode returns the NUMA node the instance is bound to, empty if
+// it's not bound
+func InstanceNumaNode(ins Instance) string {
+	n, ok := ins.(interface{ numaNode() string })
+	if !ok {
+		return ""
+	}
+	return n.numaNode()
+}
+
+func (i *instance) numaNode() string {
+	field := reflect.ValueOf(i.InstanceSpec).FieldByName("NumaNode")
+	if !field.IsValid() {
+		return ""
+	}
+	return field.String()
+}
+
 func (i *instance) LogDir() string {
 	logDir := ""
 
@@ -407,6 +433,11 @@ func (i *TiDBInstance) InitConfig(e executor.TiOpsExecutor, clusterName, cluster
 		specConfig = mergedConfig
 	}
 
+	specConfig, err := i.initTLS(e, clusterName, i.instance.topo.ServerConfigs.TiDB, specConfig, paths)
+	if err != nil {
+		return err
+	}
+
 	if err := i.mergeServerConfig(e, i.instance.topo.ServerConfigs.TiDB, specConfig, paths); err != nil {
 		return err
 	}
@@ -515,6 +546,11 @@ func (i *TiKVInstance) InitConfig(e executor.TiOpsExecutor, clusterName, cluster
 		specConfig = mergedConfig
 	}
 
+	specConfig, err := i.initTLS(e, clusterName, i.instance.topo.ServerConfigs.TiKV, specConfig, paths)
+	if err != nil {
+		return err
+	}
+
 	if err := i.mergeServerConfig(e, i.instance.topo.ServerConfigs.TiKV, specConfig, paths); err != nil {
 		return err
 	}
@@ -596,7 +632,7 @@ func (i *PDInstance) InitConfig(e executor.TiOpsExecutor, clusterName, clusterVe
 		paths.Deploy,
 		paths.Data,
 		paths.Log,
-	).WithClientPort(spec.ClientPort).WithPeerPort(spec.PeerPort).AppendEndpoints(i.instance.topo.Endpoints(deployUser)...)
+	).WithScheme(i.instance.topo.pdScheme()).WithClientPort(spec.ClientPort).WithPeerPort(spec.PeerPort).AppendEndpoints(i.instance.topo.Endpoints(deployUser)...)
 
 	fp := filepath.Join(paths.Cache, fmt.Sprintf("run_pd_%s_%d.sh", i.GetHost(), i.GetPort()))
 	if err := cfg.ConfigToFile(fp); err != nil {
@@ -643,6 +679,11 @@ func (i *PDInstance) InitConfig(e executor.TiOpsExecutor, clusterName, clusterVe
 		specConfig = mergedConfig
 	}
 
+	specConfig, err := i.initTLS(e, clusterName, i.instance.topo.ServerConfigs.PD, specConfig, paths)
+	if err != nil {
+		return err
+	}
+
 	if err := i.mergeServerConfig(e, i.instance.topo.ServerConfigs.PD, specConfig, paths); err != nil {
 		return err
 	}
@@ -671,7 +712,7 @@ func (i *PDInstance) ScaleConfig(e executor.TiOpsExecutor, b Specification, clus
 		paths.Deploy,
 		paths.Data,
 		paths.Log,
-	).WithPeerPort(spec.PeerPort).WithNumaNode(spec.NumaNode).WithClientPort(spec.ClientPort).AppendEndpoints(c.Endpoints(deployUser)...)
+	).WithScheme(c.pdScheme()).WithPeerPort(spec.PeerPort).WithNumaNode(spec.NumaNode).WithClientPort(spec.ClientPort).AppendEndpoints(c.Endpoints(deployUser)...)
 
 	fp := filepath.Join(paths.Cache, fmt.Sprintf("run_pd_%s_%d.sh", i.GetHost(), i.GetPort()))
 	log.Infof("script path: %s", fp)
@@ -1037,7 +1078,7 @@ func (i *MonitorInstance) InitConfig(e executor.TiOpsExecutor, clusterName, clus
 	}
 
 	// transfer config
-	fp = filepath.Join(paths.Cache, fmt.Sprintf("tikv_%s.yml", i.GetHost()))
+	fp = filepath.Join(paths.Cache, fmt.Sprintf("tikv_%s_%d.yml", i.GetHost(), i.GetPort()))
 	cfig := config.NewPrometheusConfig(clusterName)
 	cfig.AddBlackbox(i.GetHost(), uint64(i.instance.topo.MonitoredOptions.BlackboxExporterPort))
 	uniqueHosts := set.NewStringSet()
@@ -1168,7 +1209,7 @@ func (i *GrafanaInstance) InitConfig(e executor.TiOpsExecutor, clusterName, clus
 	}
 
 	// transfer config
-	fp = filepath.Join(paths.Cache, fmt.Sprintf("grafana_%s.ini", i.GetHost()))
+	fp = filepath.Join(paths.Cache, fmt.Sprintf("grafana_%s_%d.ini", i.GetHost(), i.GetPort()))
 	if err := config.NewGrafanaConfig(i.GetHost(), paths.Deploy).WithPort(uint64(i.GetPort())).ConfigToFile(fp); err != nil {
 		return err
 	}
@@ -1178,7 +1219,7 @@ func (i *GrafanaInstance) InitConfig(e executor.TiOpsExecutor, clusterName, clus
 	}
 
 	// transfer dashboard.yml
-	fp = filepath.Join(paths.Cache, fmt.Sprintf("dashboard_%s.yml", i.GetHost()))
+	fp = filepath.Join(paths.Cache, fmt.Sprintf("dashboard_%s_%d.yml", i.GetHost(), i.GetPort()))
 	if err := config.NewDashboardConfig(clusterName, paths.Deploy).ConfigToFile(fp); err != nil {
 		return err
 	}
@@ -1191,7 +1232,7 @@ func (i *GrafanaInstance) InitConfig(e executor.TiOpsExecutor, clusterName, clus
 	if len(i.instance.topo.Monitors) == 0 {
 		return errors.New("no prometheus found in topology")
 	}
-	fp = filepath.Join(paths.Cache, fmt.Sprintf("datasource_%s.yml", i.GetHost()))
+	fp = filepath.Join(paths.Cache, fmt.Sprintf("datasource_%s_%d.yml", i.GetHost(), i.GetPort()))
 	if err := config.NewDatasourceConfig(clusterName, i.instance.topo.Monitors[0].Host).
 		WithPort(uint64(i.instance.topo.Monitors[0].Port)).
 		ConfigToFile(fp); err != nil {
@@ -1282,7 +1323,7 @@ func (i *AlertManagerInstance) InitConfig(e executor.TiOpsExecutor, clusterName,
 	}
 
 	// transfer config
-	fp = filepath.Join(paths.Cache, fmt.Sprintf("alertmanager_%s.yml", i.GetHost()))
+	fp = filepath.Join(paths.Cache, fmt.Sprintf("alertmanager_%s_%d.yml", i.GetHost(), i.GetPort()))
 	if err := config.NewAlertManagerConfig().ConfigToFile(fp); err != nil {
 		return err
 	}
@@ -1403,6 +1444,7 @@ func (topo *ClusterSpecification) Endpoints(user string) []*scripts.PDScript {
 			deployDir,
 			dataDir,
 			logDir).
+			WithScheme(topo.pdScheme()).
 			WithClientPort(spec.ClientPort).
 			WithPeerPort(spec.PeerPort)
 		ends = append(ends, script)
@@ -21,12 +21,10 @@ import (
 	"reflect"
 	"strings"
 
-	"github.com/google/uuid"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/clusterutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/template/scripts"
 	system "github.com/pingcap-incubator/tiup-cluster/pkg/template/systemd"
-	"github.com/pingcap/errors"
 )
 
 type dmInstance struct {
@@ -61,19 +59,7 @@ func (i *dmInstance) InitConfig(e executor.TiOpsExecutor, _, _, user string, pat
 
 	systemCfg := system.NewConfig(comp, user, paths.Deploy)
 
-	if err := systemCfg.ConfigToFile(sysCfg); err != nil {
-		return err
-	}
-	tgt := filepath.Join("/tmp", comp+"_"+uuid.New().String()+".service")
-	if err := e.Transfer(sysCfg, tgt, false); err != nil {
-		return err
-	}
-	cmd := fmt.Sprintf("mv %s /etc/systemd/system/%s-%d.service", tgt, comp, port)
-	if _, _, err := e.Execute(cmd, true); err != nil {
-		return errors.Annotatef(err, "execute: %s", cmd)
-	}
-
-	return nil
+	return InstallService(e, host, i.topo.GlobalOptions.ServiceManager, systemCfg, i.ServiceName(), sysCfg)
 }
 
 // mergeServerConfig merges the server configuration and overwrite the global configuration
@@ -145,6 +131,14 @@ func (i *dmInstance) DataDir() string {
 	return dataDir.Interface().(string)
 }
 
+func (i *dmInstance) numaNode() string {
+	field := reflect.ValueOf(i.InstanceSpec).FieldByName("NumaNode")
+	if !field.IsValid() {
+		return ""
+	}
+	return field.String()
+}
+
 func (i *dmInstance) LogDir() string {
 	logDir := ""
 
@@ -0,0 +1,104 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/template/config"
+	"github.com/pingcap/errors"
+)
+
+// LogrotateDir is the dir of the logrotate configs of the services
+const LogrotateDir = "/etc/logrotate.d"
+
+// Enabled returns if the log rotation is configured
+func (r LogRotation) Enabled() bool {
+	return r.MaxSize != "" || r.MaxFiles > 0
+}
+
+// LogrotateFile returns the path of the logrotate config of the service
+func LogrotateFile(service string) string {
+	return filepath.Join(LogrotateDir, strings.TrimSuffix(service, ".service"))
+}
+
+// InstanceLogRotation returns the log rotation of the instance merged with the
+// global one
+func (topo *ClusterSpecification) InstanceLogRotation(ins Instance) LogRotation {
+	lr, ok := ins.(interface{ logRotation() LogRotation })
+	if !ok {
+		return topo.GlobalOptions.LogRotation
+	}
+	return MergeLogRotation(topo.GlobalOptions.LogRotation, lr.logRotation())
+}
+
+// MergeLogRotation merge the rhs into lhs and overwrite rhs if lhs has value for same field
+func MergeLogRotation(lhs, rhs LogRotation) LogRotation {
+	if rhs.MaxSize != "" {
+		lhs.MaxSize = rhs.MaxSize
+	}
+	if rhs.MaxFiles > 0 {
+		lhs.MaxFiles = rhs.MaxFiles
+	}
+	if rhs.Compress {
+		lhs.Compress = rhs.Compress
+	}
+	return lhs
+}
+
+func (i *instance) logRotation() LogRotation {
+	return reflect.ValueOf(i.InstanceSpec).
+		FieldByName("LogRotation").
+		Interface().(LogRotation)
+}
+
+// installLogRotation installs the logrotate config of the log dir of the
+// instance, or removes it if the log rotation is not configured any more
+func (i *instance) installLogRotation(e executor.TiOpsExecutor, user string, paths DirPaths) error {
+	target := LogrotateFile(i.ServiceName())
+	// not through InstanceLogRotation, the embedded instance is not an Instance
+	rotation := MergeLogRotation(i.topo.GlobalOptions.LogRotation, i.logRotation())
+	if !rotation.Enabled() {
+		cmd := fmt.Sprintf("rm -f %s", target)
+		if _, stderr, err := e.Execute(cmd, true); err != nil {
+			return errors.Annotatef(err, "execute: %s, %s", cmd, strings.TrimSpace(string(stderr)))
+		}
+		return nil
+	}
+
+	cacheFile := filepath.Join(paths.Cache, fmt.Sprintf("logrotate-%s-%s-%d.conf", i.ComponentName(), i.GetHost(), i.GetPort()))
+	if err := config.NewLogrotateConfig(paths.Log, user).
+		WithMaxSize(rotation.MaxSize).
+		WithMaxFiles(rotation.MaxFiles).
+		WithCompress(rotation.Compress).
+		ConfigToFile(cacheFile); err != nil {
+		return err
+	}
+
+	// the config must be owned by root, otherwise logrotate ignores it
+	tgt := filepath.Join("/tmp", "logrotate_"+uuid.New().String())
+	if err := e.Transfer(cacheFile, tgt, false); err != nil {
+		return err
+	}
+	cmd := fmt.Sprintf("mkdir -p %s && mv %s %s && chown root:root %s && chmod 644 %s", LogrotateDir, tgt, target, target, target)
+	if _, stderr, err := e.Execute(cmd, true); err != nil {
+		return errors.Annotatef(err, "execute: %s, %s", cmd, strings.TrimSpace(string(stderr)))
+	}
+	return nil
+}
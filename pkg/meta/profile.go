@@ -14,13 +14,16 @@
 package meta
 
 import (
+	"io/ioutil"
 	"os"
 	"os/user"
 	"path"
 
 	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
 	tiuplocaldata "github.com/pingcap-incubator/tiup/pkg/localdata"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
 	"github.com/pingcap/errors"
+	"gopkg.in/yaml.v2"
 )
 
 // sub directory names
@@ -30,8 +33,38 @@ const (
 	TiOpsAuditDir        = "audit"
 )
 
+// ProfileConfigFileName is the config file in the profile dir, it holds the
+// settings shared by all clusters
+const ProfileConfigFileName = "config.yaml"
+
 var profileDir string
 
+// ProfileConfig is the content of the config file in the profile dir
+type ProfileConfig struct {
+	// the categories of the status reported by each component, which
+	// display colors the status by, e.g. `tikv: {Draining: warning}`
+	StatusMapping map[string]map[string]string `yaml:"status_mapping,omitempty"`
+}
+
+// LoadProfileConfig reads the config file in the profile dir, an empty
+// config is returned if the file does not exist.
+func LoadProfileConfig() (*ProfileConfig, error) {
+	cfg := &ProfileConfig{}
+	file := ProfilePath(ProfileConfigFileName)
+	if tiuputils.IsNotExist(file) {
+		return cfg, nil
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, errors.Annotatef(err, "failed to parse %s", file)
+	}
+	return cfg, nil
+}
+
 // getHomeDir get the home directory of current user (if they have one).
 // The result path might be empty.
 func getHomeDir() (string, error) {
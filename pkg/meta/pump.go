@@ -51,10 +51,7 @@ func (c *PumpComponent) Instances() []Instance {
 				s.DeployDir,
 				s.DataDir,
 			},
-			statusFn: func(_ ...string) string {
-				url := fmt.Sprintf("http://%s:%d/status", s.Host, s.Port)
-				return statusByURL(url)
-			},
+			statusFn: s.Status,
 		}})
 	}
 	return ins
@@ -128,5 +125,10 @@ func (i *PumpInstance) InitConfig(e executor.TiOpsExecutor, clusterName, cluster
 		specConfig = mergedConfig
 	}
 
+	specConfig, err := i.initTLS(e, clusterName, i.topo.ServerConfigs.Pump, specConfig, paths)
+	if err != nil {
+		return err
+	}
+
 	return i.mergeServerConfig(e, i.topo.ServerConfigs.Pump, specConfig, paths)
 }
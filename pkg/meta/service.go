@@ -0,0 +1,101 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/module"
+	system "github.com/pingcap-incubator/tiup-cluster/pkg/template/systemd"
+	"github.com/pingcap/errors"
+)
+
+// InstanceServiceManager returns the service manager of the host of the
+// instance, the one specified in the topology or the detected one
+func InstanceServiceManager(e executor.TiOpsExecutor, ins Instance) (module.ServiceManager, error) {
+	name := ""
+	if s, ok := ins.(interface{ serviceManager() string }); ok {
+		name = s.serviceManager()
+	}
+	return module.NewServiceManager(e, ins.GetHost(), name)
+}
+
+func (i *instance) serviceManager() string {
+	return i.topo.GlobalOptions.ServiceManager
+}
+
+func (i *dmInstance) serviceManager() string {
+	return i.topo.GlobalOptions.ServiceManager
+}
+
+// validateServiceManager checks the service manager in the topology is
+// supported, it's detected on each host if not specified
+func validateServiceManager(name string) error {
+	switch name {
+	case "", module.ServiceManagerSystemd, module.ServiceManagerSupervisord:
+		return nil
+	}
+	return errors.Errorf("unknown service_manager '%s', only %s and %s are supported",
+		name, module.ServiceManagerSystemd, module.ServiceManagerSupervisord)
+}
+
+// InstallService generates the definition of the service from the config and
+// installs it on the host, i.e. a systemd unit or a supervisord program. The
+// generated file is kept as cacheFile locally.
+func InstallService(e executor.TiOpsExecutor, host, manager string, cfg *system.Config, service, cacheFile string) error {
+	mgr, err := module.NewServiceManager(e, host, manager)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	tgt := filepath.Join("/tmp", service+"_"+uuid.New().String())
+	var cmd string
+	switch mgr.Name() {
+	case module.ServiceManagerSupervisord:
+		if cfg.MemoryLimit != "" || cfg.CPUQuota != "" || cfg.IOReadBandwidthMax != "" || cfg.IOWriteBandwidthMax != "" ||
+			cfg.LimitNOFILE != "" || cfg.TasksMax != "" {
+			log.Warnf("resource_control of %s on %s is ignored, it's not supported by supervisord", service, host)
+		}
+		program := strings.TrimSuffix(service, ".service")
+		data, err = cfg.SupervisordConfig(program)
+		cacheFile = strings.TrimSuffix(cacheFile, ".service") + ".ini"
+		// the dir included by the supervisord of Debian, or the one of RHEL
+		cmd = fmt.Sprintf("if [ -d %[1]s ]; then mv %[3]s %[1]s/%[4]s.conf; else mkdir -p %[2]s && mv %[3]s %[2]s/%[4]s.ini; fi",
+			module.SupervisordDebianDir, module.SupervisordDefaultDir, tgt, program)
+	default:
+		data, err = cfg.Config()
+		cmd = fmt.Sprintf("mv %s %s/%s", tgt, module.SystemdUnitDir, service)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(cacheFile, data, 0755); err != nil {
+		return errors.AddStack(err)
+	}
+	if err := e.Transfer(cacheFile, tgt, false); err != nil {
+		return err
+	}
+	if _, stderr, err := e.Execute(cmd, true); err != nil {
+		return errors.Annotatef(err, "execute: %s, %s", cmd, strings.TrimSpace(string(stderr)))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"strings"
+
+	"github.com/pingcap-incubator/tiup/pkg/set"
+	"github.com/pingcap/errors"
+)
+
+// statusCategories are the normalized categories a raw status can be mapped to
+var statusCategories = []string{"up", "leader", "warning", "down"}
+
+// StatusMapping maps the raw status strings reported by components to
+// normalized categories, keyed by the component name and then the status in
+// lower case, e.g. `tikv: {draining: warning}`
+type StatusMapping map[string]map[string]string
+
+// Category returns the normalized category of the raw status reported by the
+// component, the lookup of the status is case insensitive.
+func (m StatusMapping) Category(component, status string) (string, bool) {
+	category, ok := m[component][strings.ToLower(status)]
+	return category, ok
+}
+
+// LoadStatusMapping reads the user defined status mappings from the
+// status_mapping section of the profile config, an empty mapping is returned
+// if it's not set.
+func LoadStatusMapping() (StatusMapping, error) {
+	cfg, err := LoadProfileConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	components := set.NewStringSet(AllComponentNames()...)
+	categories := set.NewStringSet(statusCategories...)
+	mapping := StatusMapping{}
+	for comp, statuses := range cfg.StatusMapping {
+		if !components.Exist(comp) {
+			return nil, errors.Errorf("invalid component '%s' in the status_mapping of %s, available: %s",
+				comp, ProfilePath(ProfileConfigFileName), strings.Join(AllComponentNames(), ", "))
+		}
+		mapping[comp] = map[string]string{}
+		for status, category := range statuses {
+			category = strings.ToLower(strings.TrimSpace(category))
+			if !categories.Exist(category) {
+				return nil, errors.Errorf("invalid category '%s' for status '%s' of %s in %s, available: %s",
+					category, status, comp, ProfilePath(ProfileConfigFileName), strings.Join(statusCategories, ", "))
+			}
+			mapping[comp][strings.ToLower(status)] = category
+		}
+	}
+	return mapping, nil
+}
@@ -0,0 +1,301 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/crypto"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+)
+
+// TLSDirName is the dir of the certificates, both in the meta dir of the
+// cluster and in the deploy dir of each instance
+const TLSDirName = "tls"
+
+const (
+	tlsCACert     = "ca.crt"
+	tlsCAKey      = "ca.pem"
+	tlsClientName = "client"
+)
+
+// tlsConfigKeys are the config items of the CA, certificate and key paths of
+// the components whose TLS is configured by us
+var tlsConfigKeys = map[string][3]string{
+	ComponentPD:      {"security.cacert-path", "security.cert-path", "security.key-path"},
+	ComponentTiKV:    {"security.ca-path", "security.cert-path", "security.key-path"},
+	ComponentTiDB:    {"security.cluster-ssl-ca", "security.cluster-ssl-cert", "security.cluster-ssl-key"},
+	ComponentPump:    {"security.ssl-ca", "security.ssl-cert", "security.ssl-key"},
+	ComponentDrainer: {"security.ssl-ca", "security.ssl-cert", "security.ssl-key"},
+}
+
+// TLSSupported returns if the TLS of the component can be enabled by us
+func TLSSupported(comp string) bool {
+	_, ok := tlsConfigKeys[comp]
+	return ok
+}
+
+// TLSCertPath returns the path of the certificate of the instance of the
+// component on host:port in the meta dir, the key is at the same path with
+// the extension .pem
+func TLSCertPath(clusterName, comp, host string, port int) string {
+	return ClusterPath(clusterName, TLSDirName, fmt.Sprintf("%s-%s-%d.crt", comp, host, port))
+}
+
+// caMutex serializes LoadOrCreateCA, otherwise the instances initialized in
+// parallel would generate different CAs on the first call
+var caMutex sync.Mutex
+
+// LoadOrCreateCA returns the CA of the cluster, it's generated on the first call
+func LoadOrCreateCA(clusterName string) (*crypto.CertificateAuthority, error) {
+	caMutex.Lock()
+	defer caMutex.Unlock()
+
+	certFile := ClusterPath(clusterName, TLSDirName, tlsCACert)
+	keyFile := ClusterPath(clusterName, TLSDirName, tlsCAKey)
+	if tiuputils.IsExist(certFile) {
+		return crypto.LoadCA(certFile, keyFile)
+	}
+
+	if err := os.MkdirAll(ClusterPath(clusterName, TLSDirName), 0700); err != nil {
+		return nil, errors.AddStack(err)
+	}
+	ca, err := crypto.NewCA(clusterName + "-ca")
+	if err != nil {
+		return nil, err
+	}
+	if err := ca.Save(certFile, keyFile); err != nil {
+		return nil, err
+	}
+	log.Infof("Generated the CA of cluster %s at %s", clusterName, certFile)
+	return ca, nil
+}
+
+// loadOrIssueCert returns the path of the certificate and the key, the
+// certificate is issued if it's not issued before or removed by rotating
+func loadOrIssueCert(clusterName, certFile, commonName string, hosts []string) (string, string, error) {
+	keyFile := strings.TrimSuffix(certFile, ".crt") + ".pem"
+	if tiuputils.IsExist(certFile) && tiuputils.IsExist(keyFile) {
+		return certFile, keyFile, nil
+	}
+
+	ca, err := LoadOrCreateCA(clusterName)
+	if err != nil {
+		return "", "", err
+	}
+	certPEM, keyPEM, err := ca.Sign(commonName, hosts)
+	if err != nil {
+		return "", "", err
+	}
+	if err := ioutil.WriteFile(certFile, certPEM, 0644); err != nil {
+		return "", "", errors.AddStack(err)
+	}
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		return "", "", errors.AddStack(err)
+	}
+	return certFile, keyFile, nil
+}
+
+// ClusterTLSConfig returns the TLS config for us to talk to the cluster,
+// a client certificate is issued by the CA of the cluster on the first call
+func ClusterTLSConfig(clusterName string) (*tls.Config, error) {
+	ca, err := LoadOrCreateCA(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	certFile, keyFile, err := loadOrIssueCert(
+		clusterName,
+		ClusterPath(clusterName, TLSDirName, tlsClientName+".crt"),
+		tlsClientName,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Cert)
+	return &tls.Config{
+		RootCAs:      pool,
+		Certificates: []tls.Certificate{cert},
+	}, nil
+}
+
+// pdScheme returns the scheme of the PD URLs
+func (topo *ClusterSpecification) pdScheme() string {
+	if topo.GlobalOptions.EnableTLS {
+		return "https"
+	}
+	return "http"
+}
+
+// tlsValidate checks all components of the cluster support TLS if it's enabled
+func (topo *ClusterSpecification) tlsValidate() error {
+	if !topo.GlobalOptions.EnableTLS {
+		return nil
+	}
+	var err error
+	topo.IterInstance(func(ins Instance) {
+		if err == nil && !TLSSupported(ins.ComponentName()) && ins.ComponentName() != ComponentGrafana &&
+			ins.ComponentName() != ComponentPrometheus && ins.ComponentName() != ComponentAlertManager {
+			err = errors.Errorf("TLS is not supported by %s yet, %s must be removed before enabling TLS", ins.ComponentName(), ins.ID())
+		}
+	})
+	return err
+}
+
+// serverConfig returns the server_configs section of the component
+func (topo *ClusterSpecification) serverConfig(comp string) map[string]interface{} {
+	switch comp {
+	case ComponentTiDB:
+		return topo.ServerConfigs.TiDB
+	case ComponentTiKV:
+		return topo.ServerConfigs.TiKV
+	case ComponentPD:
+		return topo.ServerConfigs.PD
+	case ComponentPump:
+		return topo.ServerConfigs.Pump
+	case ComponentDrainer:
+		return topo.ServerConfigs.Drainer
+	}
+	return nil
+}
+
+// userTLSConfigured returns if any of the cert paths is set in the configs
+func userTLSConfigured(comp string, globalConf, instanceConf map[string]interface{}) bool {
+	keys, ok := tlsConfigKeys[comp]
+	if !ok {
+		return false
+	}
+	conf, err := merge(globalConf, instanceConf)
+	if err != nil {
+		return false
+	}
+	for _, key := range keys {
+		var val interface{} = conf
+		for _, part := range strings.Split(key, ".") {
+			m, ok := val.(map[string]interface{})
+			if !ok {
+				val = nil
+				break
+			}
+			val = m[part]
+		}
+		if s, ok := val.(string); ok && s != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// InstanceTLSEnabled returns if the instance serves with certificates, either
+// configured by us or by the user in the topology
+func (topo *ClusterSpecification) InstanceTLSEnabled(ins Instance) bool {
+	comp := ins.ComponentName()
+	if !TLSSupported(comp) {
+		return false
+	}
+	if topo.GlobalOptions.EnableTLS {
+		return true
+	}
+	var instanceConf map[string]interface{}
+	switch ins := ins.(type) {
+	case *TiDBInstance:
+		instanceConf = ins.InstanceSpec.(TiDBSpec).Config
+	case *TiKVInstance:
+		instanceConf = ins.InstanceSpec.(TiKVSpec).Config
+	case *PDInstance:
+		instanceConf = ins.InstanceSpec.(PDSpec).Config
+	case *PumpInstance:
+		instanceConf = ins.InstanceSpec.(PumpSpec).Config
+	case *DrainerInstance:
+		instanceConf = ins.InstanceSpec.(DrainerSpec).Config
+	}
+	return userTLSConfigured(comp, topo.serverConfig(comp), instanceConf)
+}
+
+// initTLS pushes the CA and the certificate of the instance to its deploy
+// dir if TLS is enabled for the cluster, and returns the instance config with
+// the cert paths set. The config is returned as is if the user already set
+// any of the cert paths, e.g. in server_configs or the imported config, so
+// that the TLS of the instance is never configured twice.
+func (i *instance) initTLS(e executor.TiOpsExecutor, clusterName string, globalConf, instanceConf map[string]interface{}, paths DirPaths) (map[string]interface{}, error) {
+	comp := i.ComponentName()
+	if !i.topo.GlobalOptions.EnableTLS || !TLSSupported(comp) {
+		return instanceConf, nil
+	}
+	if userTLSConfigured(comp, globalConf, instanceConf) {
+		log.Warnf("TLS of %s is already configured in the topology, skip configuring it again", i.ID())
+		return instanceConf, nil
+	}
+
+	ca, err := LoadOrCreateCA(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	certFile, keyFile, err := loadOrIssueCert(
+		clusterName,
+		TLSCertPath(clusterName, comp, i.GetHost(), i.GetPort()),
+		fmt.Sprintf("%s-%s", comp, i.GetHost()),
+		[]string{i.GetHost(), "127.0.0.1", "localhost"},
+	)
+	if err != nil {
+		return nil, err
+	}
+	caFile := filepath.Join(paths.Cache, fmt.Sprintf("%s-%s-%s-%d-%s", clusterName, comp, i.GetHost(), i.GetPort(), tlsCACert))
+	if err := ioutil.WriteFile(caFile, ca.CertPEM(), 0644); err != nil {
+		return nil, errors.AddStack(err)
+	}
+
+	tlsDir := filepath.Join(paths.Deploy, TLSDirName)
+	if _, _, err := e.Execute("mkdir -p "+tlsDir, false); err != nil {
+		return nil, err
+	}
+	remote := [3]string{
+		filepath.Join(tlsDir, tlsCACert),
+		filepath.Join(tlsDir, comp+".crt"),
+		filepath.Join(tlsDir, comp+".pem"),
+	}
+	for idx, src := range []string{caFile, certFile, keyFile} {
+		if err := e.Transfer(src, remote[idx], false); err != nil {
+			return nil, err
+		}
+	}
+	if _, _, err := e.Execute(fmt.Sprintf("chmod 600 %s", remote[2]), false); err != nil {
+		return nil, err
+	}
+
+	conf := make(map[string]interface{}, len(instanceConf)+3)
+	for k, v := range instanceConf {
+		conf[k] = v
+	}
+	for idx, key := range tlsConfigKeys[comp] {
+		conf[key] = remote[idx]
+	}
+	return conf, nil
+}
@@ -14,9 +14,11 @@
 package meta
 
 import (
+	"crypto/tls"
 	"fmt"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -33,6 +35,39 @@ const (
 	statusQueryTimeout = 2 * time.Second
 )
 
+var (
+	// statusTLSConfig is the TLS config of the status probes, the probes use
+	// plain HTTP if it's nil
+	statusTLSConfig *tls.Config
+	// statusInsecureSkipVerify skips the certificate verification of the
+	// status probes over HTTPS
+	statusInsecureSkipVerify bool
+)
+
+// SetStatusTLSConfig sets the TLS config used by the status probes of
+// instances, the probes switch to HTTPS once it's set
+func SetStatusTLSConfig(cfg *tls.Config) {
+	statusTLSConfig = cfg
+}
+
+// SetStatusInsecureSkipVerify disables the certificate verification of the
+// status probes, it's meant for inspecting clusters with broken certificates.
+// It only affects the status probes and never the TLS of the cluster itself.
+func SetStatusInsecureSkipVerify(skip bool) {
+	statusInsecureSkipVerify = skip
+}
+
+// getStatusTLSConfig returns the TLS config for a status probe, nil is
+// returned if TLS is not enabled
+func getStatusTLSConfig() *tls.Config {
+	if statusTLSConfig == nil {
+		return nil
+	}
+	cfg := statusTLSConfig.Clone()
+	cfg.InsecureSkipVerify = statusInsecureSkipVerify
+	return cfg
+}
+
 // general role names
 var (
 	RoleMonitor = "monitor"
@@ -54,6 +89,20 @@ type (
 		CPUQuota            string `yaml:"cpu_quota,omitempty"`
 		IOReadBandwidthMax  string `yaml:"io_read_bandwidth_max,omitempty"`
 		IOWriteBandwidthMax string `yaml:"io_write_bandwidth_max,omitempty"`
+		// LimitNOFILE and TasksMax are not resource control of systemd
+		// strictly, they limit the open files and the tasks of the service
+		LimitNOFILE string `yaml:"limit_nofile,omitempty"`
+		TasksMax    string `yaml:"tasks_max,omitempty"`
+	}
+
+	// LogRotation is the rotation of the logs of an instance by logrotate
+	LogRotation struct {
+		// rotate the logs once they grow bigger than it, e.g. 300M, or daily
+		// if not specified
+		MaxSize string `yaml:"max_size,omitempty"`
+		// the rotated logs are kept at most, 10 if not specified
+		MaxFiles int  `yaml:"max_files,omitempty"`
+		Compress bool `yaml:"compress,omitempty"`
 	}
 
 	// GlobalOptions represents the global options for all groups in topology
@@ -65,6 +114,13 @@ type (
 		DataDir         string          `yaml:"data_dir,omitempty" default:"data"`
 		LogDir          string          `yaml:"log_dir,omitempty"`
 		ResourceControl ResourceControl `yaml:"resource_control,omitempty"`
+		LogRotation     LogRotation     `yaml:"log_rotation,omitempty"`
+		// EnableTLS enables the mutual TLS between the components, the
+		// certificates are issued by the CA in the meta dir of the cluster
+		EnableTLS bool `yaml:"enable_tls,omitempty"`
+		// ServiceManager is systemd or supervisord to run the instances,
+		// it's detected on each host if not specified
+		ServiceManager string `yaml:"service_manager,omitempty"`
 	}
 
 	// MonitoredOptions represents the monitored node configuration
@@ -123,6 +179,7 @@ func AllComponentNames() (roles []string) {
 type TiDBSpec struct {
 	Host            string                 `yaml:"host"`
 	SSHPort         int                    `yaml:"ssh_port,omitempty"`
+	Arch            string                 `yaml:"arch,omitempty"`
 	Imported        bool                   `yaml:"imported,omitempty"`
 	Port            int                    `yaml:"port" default:"4000"`
 	StatusPort      int                    `yaml:"status_port" default:"10080"`
@@ -131,11 +188,16 @@ type TiDBSpec struct {
 	NumaNode        string                 `yaml:"numa_node,omitempty"`
 	Config          map[string]interface{} `yaml:"config,omitempty"`
 	ResourceControl ResourceControl        `yaml:"resource_control,omitempty"`
+	LogRotation     LogRotation            `yaml:"log_rotation,omitempty"`
 }
 
 // statusByURL queries current status of the instance by http status api.
 func statusByURL(url string) string {
-	client := utils.NewHTTPClient(statusQueryTimeout, nil)
+	tlsCfg := getStatusTLSConfig()
+	if tlsCfg != nil {
+		url = "https://" + strings.TrimPrefix(url, "http://")
+	}
+	client := utils.NewHTTPClient(statusQueryTimeout, tlsCfg)
 
 	// body doesn't have any status section needed
 	body, err := client.Get(url)
@@ -152,7 +214,18 @@ func statusByURL(url string) string {
 // Status queries current status of the instance
 func (s TiDBSpec) Status(pdList ...string) string {
 	url := fmt.Sprintf("http://%s:%d/status", s.Host, s.StatusPort)
-	return statusByURL(url)
+	status := statusByURL(url)
+	if status != "Up" {
+		return status
+	}
+
+	// mark the DDL owner, restarting it triggers an owner election
+	tidbapi := api.NewTiDBClient([]string{fmt.Sprintf("%s:%d", s.Host, s.StatusPort)},
+		statusQueryTimeout, getStatusTLSConfig())
+	if owner, err := tidbapi.IsDDLOwner(); err == nil && owner {
+		status += "|Owner"
+	}
+	return status
 }
 
 // Role returns the component role of the instance
@@ -179,6 +252,7 @@ func (s TiDBSpec) IsImported() bool {
 type TiKVSpec struct {
 	Host            string                 `yaml:"host"`
 	SSHPort         int                    `yaml:"ssh_port,omitempty"`
+	Arch            string                 `yaml:"arch,omitempty"`
 	Imported        bool                   `yaml:"imported,omitempty"`
 	Port            int                    `yaml:"port" default:"20160"`
 	StatusPort      int                    `yaml:"status_port" default:"20180"`
@@ -189,6 +263,7 @@ type TiKVSpec struct {
 	NumaNode        string                 `yaml:"numa_node,omitempty"`
 	Config          map[string]interface{} `yaml:"config,omitempty"`
 	ResourceControl ResourceControl        `yaml:"resource_control,omitempty"`
+	LogRotation     LogRotation            `yaml:"log_rotation,omitempty"`
 }
 
 // Status queries current status of the instance
@@ -196,14 +271,18 @@ func (s TiKVSpec) Status(pdList ...string) string {
 	if len(pdList) < 1 {
 		return "N/A"
 	}
-	pdapi := api.NewPDClient(pdList, statusQueryTimeout, nil)
+	return storeStatus(pdList, fmt.Sprintf("%s:%d", s.Host, s.Port))
+}
+
+// storeStatus queries PD for the state of the store of the address, it's
+// used by both TiKV and TiFlash as the latter registers as a learner store
+func storeStatus(pdList []string, name string) string {
+	pdapi := api.NewPDClient(pdList, statusQueryTimeout, getStatusTLSConfig())
 	stores, err := pdapi.GetStores()
 	if err != nil {
 		return "Down"
 	}
 
-	name := fmt.Sprintf("%s:%d", s.Host, s.Port)
-
 	// only get status of the latest store, it is the store with lagest ID number
 	// older stores might be legacy ones that already offlined
 	var latestStore *pdserverapi.StoreInfo
@@ -248,6 +327,7 @@ func (s TiKVSpec) IsImported() bool {
 type PDSpec struct {
 	Host     string `yaml:"host"`
 	SSHPort  int    `yaml:"ssh_port,omitempty"`
+	Arch     string `yaml:"arch,omitempty"`
 	Imported bool   `yaml:"imported,omitempty"`
 	// Use Name to get the name with a default value if it's empty.
 	Name            string                 `yaml:"name"`
@@ -259,12 +339,13 @@ type PDSpec struct {
 	NumaNode        string                 `yaml:"numa_node,omitempty"`
 	Config          map[string]interface{} `yaml:"config,omitempty"`
 	ResourceControl ResourceControl        `yaml:"resource_control,omitempty"`
+	LogRotation     LogRotation            `yaml:"log_rotation,omitempty"`
 }
 
 // Status queries current status of the instance
 func (s PDSpec) Status(pdList ...string) string {
 	pdapi := api.NewPDClient([]string{fmt.Sprintf("%s:%d", s.Host, s.ClientPort)},
-		statusQueryTimeout, nil)
+		statusQueryTimeout, getStatusTLSConfig())
 	healths, err := pdapi.GetHealth()
 	if err != nil {
 		return "Down"
@@ -316,6 +397,7 @@ func (s PDSpec) IsImported() bool {
 type TiFlashSpec struct {
 	Host                 string                 `yaml:"host"`
 	SSHPort              int                    `yaml:"ssh_port,omitempty"`
+	Arch                 string                 `yaml:"arch,omitempty"`
 	Imported             bool                   `yaml:"imported,omitempty"`
 	TCPPort              int                    `yaml:"tcp_port" default:"9000"`
 	HTTPPort             int                    `yaml:"http_port" default:"8123"`
@@ -331,12 +413,17 @@ type TiFlashSpec struct {
 	Config               map[string]interface{} `yaml:"config,omitempty"`
 	LearnerConfig        map[string]interface{} `yaml:"learner_config,omitempty"`
 	ResourceControl      ResourceControl        `yaml:"resource_control,omitempty"`
+	LogRotation          LogRotation            `yaml:"log_rotation,omitempty"`
 }
 
 // Status queries current status of the instance
-func (s TiFlashSpec) Status(flashList ...string) string {
-	url := fmt.Sprintf("http://%s:%d/?query=select%%20version()", s.Host, s.HTTPPort)
-	return statusByURL(url)
+func (s TiFlashSpec) Status(pdList ...string) string {
+	if len(pdList) < 1 {
+		url := fmt.Sprintf("http://%s:%d/?query=select%%20version()", s.Host, s.HTTPPort)
+		return statusByURL(url)
+	}
+	// the store of TiFlash is registered with the address of its flash service
+	return storeStatus(pdList, fmt.Sprintf("%s:%d", s.Host, s.FlashServicePort))
 }
 
 // Role returns the component role of the instance
@@ -363,6 +450,7 @@ func (s TiFlashSpec) IsImported() bool {
 type PumpSpec struct {
 	Host            string                 `yaml:"host"`
 	SSHPort         int                    `yaml:"ssh_port,omitempty"`
+	Arch            string                 `yaml:"arch,omitempty"`
 	Imported        bool                   `yaml:"imported,omitempty"`
 	Port            int                    `yaml:"port" default:"8250"`
 	DeployDir       string                 `yaml:"deploy_dir,omitempty"`
@@ -372,6 +460,7 @@ type PumpSpec struct {
 	NumaNode        string                 `yaml:"numa_node,omitempty"`
 	Config          map[string]interface{} `yaml:"config,omitempty"`
 	ResourceControl ResourceControl        `yaml:"resource_control"`
+	LogRotation     LogRotation            `yaml:"log_rotation,omitempty"`
 }
 
 // Role returns the component role of the instance
@@ -394,10 +483,16 @@ func (s PumpSpec) IsImported() bool {
 	return s.Imported
 }
 
+// Status queries current status of the instance
+func (s PumpSpec) Status(pdList ...string) string {
+	return binlogStatus(pdList, s.Host, s.Port, (*api.BinlogClient).PumpNodeStatus)
+}
+
 // DrainerSpec represents the Drainer topology specification in topology.yaml
 type DrainerSpec struct {
 	Host            string                 `yaml:"host"`
 	SSHPort         int                    `yaml:"ssh_port,omitempty"`
+	Arch            string                 `yaml:"arch,omitempty"`
 	Imported        bool                   `yaml:"imported,omitempty"`
 	Port            int                    `yaml:"port" default:"8249"`
 	DeployDir       string                 `yaml:"deploy_dir,omitempty"`
@@ -408,6 +503,7 @@ type DrainerSpec struct {
 	NumaNode        string                 `yaml:"numa_node,omitempty"`
 	Config          map[string]interface{} `yaml:"config,omitempty"`
 	ResourceControl ResourceControl        `yaml:"resource_control,omitempty"`
+	LogRotation     LogRotation            `yaml:"log_rotation,omitempty"`
 }
 
 // Role returns the component role of the instance
@@ -430,10 +526,61 @@ func (s DrainerSpec) IsImported() bool {
 	return s.Imported
 }
 
+// Status queries current status of the instance
+func (s DrainerSpec) Status(pdList ...string) string {
+	return binlogStatus(pdList, s.Host, s.Port, (*api.BinlogClient).DrainerNodeStatus)
+}
+
+// binlogStates maps the states of Pump and Drainer registered in PD to the
+// status displayed, the closing one is going offline and the offline one is
+// removed like a tombstone store
+var binlogStates = map[string]string{
+	"online":  "Up",
+	"pausing": "Pausing",
+	"paused":  "Paused",
+	"closing": "Offline",
+	"offline": "Tombstone",
+}
+
+// binlogStatus returns the state of the Pump or Drainer registered in PD,
+// the process must be serving too unless it's offline, as the state stays
+// online in PD after a crash
+func binlogStatus(pdList []string, host string, port int, nodeStatus func(*api.BinlogClient) ([]*api.NodeStatus, error)) string {
+	status := statusByURL(fmt.Sprintf("http://%s:%d/status", host, port))
+	if len(pdList) < 1 {
+		return status
+	}
+
+	state := "N/A"
+	var nodes []*api.NodeStatus
+	if binlogClient, err := api.NewBinlogClient(pdList, getStatusTLSConfig()); err == nil {
+		nodes, _ = nodeStatus(binlogClient)
+		_ = binlogClient.Close()
+	}
+
+	addr := fmt.Sprintf("%s:%d", host, port)
+	for _, node := range nodes {
+		if node.NodeID != addr && node.Addr != addr {
+			continue
+		}
+		var ok bool
+		if state, ok = binlogStates[node.State]; !ok {
+			state = node.State
+		}
+		break
+	}
+	// the process exits once it's offline
+	if state != "Tombstone" && status != "Up" {
+		return status
+	}
+	return state
+}
+
 // CDCSpec represents the Drainer topology specification in topology.yaml
 type CDCSpec struct {
 	Host            string                 `yaml:"host"`
 	SSHPort         int                    `yaml:"ssh_port,omitempty"`
+	Arch            string                 `yaml:"arch,omitempty"`
 	Imported        bool                   `yaml:"imported,omitempty"`
 	Port            int                    `yaml:"port" default:"8300"`
 	DeployDir       string                 `yaml:"deploy_dir,omitempty"`
@@ -442,6 +589,7 @@ type CDCSpec struct {
 	NumaNode        string                 `yaml:"numa_node,omitempty"`
 	Config          map[string]interface{} `yaml:"config,omitempty"`
 	ResourceControl ResourceControl        `yaml:"resource_control,omitempty"`
+	LogRotation     LogRotation            `yaml:"log_rotation,omitempty"`
 }
 
 // Role returns the component role of the instance
@@ -464,10 +612,30 @@ func (s CDCSpec) IsImported() bool {
 	return s.Imported
 }
 
+// Status queries current status of the instance, it's up only if the server
+// is registered as a capture, the owner of the captures is marked
+func (s CDCSpec) Status(pdList ...string) string {
+	cdcapi := api.NewCDCClient([]string{fmt.Sprintf("%s:%d", s.Host, s.Port)},
+		statusQueryTimeout, getStatusTLSConfig())
+	status, err := cdcapi.GetStatus()
+	if err != nil {
+		return "Down"
+	}
+	if status.ID == "" {
+		// the process is up but not serving as a capture yet
+		return "Starting"
+	}
+	if status.IsOwner {
+		return "Up|Owner"
+	}
+	return "Up"
+}
+
 // PrometheusSpec represents the Prometheus Server topology specification in topology.yaml
 type PrometheusSpec struct {
 	Host            string          `yaml:"host"`
 	SSHPort         int             `yaml:"ssh_port,omitempty"`
+	Arch            string          `yaml:"arch,omitempty"`
 	Imported        bool            `yaml:"imported,omitempty"`
 	Port            int             `yaml:"port" default:"9090"`
 	DeployDir       string          `yaml:"deploy_dir,omitempty"`
@@ -476,6 +644,7 @@ type PrometheusSpec struct {
 	NumaNode        string          `yaml:"numa_node,omitempty"`
 	Retention       string          `yaml:"storage_retention,omitempty"`
 	ResourceControl ResourceControl `yaml:"resource_control,omitempty"`
+	LogRotation     LogRotation     `yaml:"log_rotation,omitempty"`
 }
 
 // Role returns the component role of the instance
@@ -502,10 +671,14 @@ func (s PrometheusSpec) IsImported() bool {
 type GrafanaSpec struct {
 	Host            string          `yaml:"host"`
 	SSHPort         int             `yaml:"ssh_port,omitempty"`
+	Arch            string          `yaml:"arch,omitempty"`
 	Imported        bool            `yaml:"imported,omitempty"`
 	Port            int             `yaml:"port" default:"3000"`
 	DeployDir       string          `yaml:"deploy_dir,omitempty"`
 	ResourceControl ResourceControl `yaml:"resource_control,omitempty"`
+	LogRotation     LogRotation     `yaml:"log_rotation,omitempty"`
+	Username        string          `yaml:"username,omitempty"` // admin user to access the API, default to admin
+	Password        string          `yaml:"password,omitempty"`
 }
 
 // Role returns the component role of the instance
@@ -532,6 +705,7 @@ func (s GrafanaSpec) IsImported() bool {
 type AlertManagerSpec struct {
 	Host            string          `yaml:"host"`
 	SSHPort         int             `yaml:"ssh_port,omitempty"`
+	Arch            string          `yaml:"arch,omitempty"`
 	Imported        bool            `yaml:"imported,omitempty"`
 	WebPort         int             `yaml:"web_port" default:"9093"`
 	ClusterPort     int             `yaml:"cluster_port" default:"9094"`
@@ -540,6 +714,7 @@ type AlertManagerSpec struct {
 	LogDir          string          `yaml:"log_dir,omitempty"`
 	NumaNode        string          `yaml:"numa_node,omitempty"`
 	ResourceControl ResourceControl `yaml:"resource_control,omitempty"`
+	LogRotation     LogRotation     `yaml:"log_rotation,omitempty"`
 }
 
 // Role returns the component role of the instance
@@ -620,8 +795,13 @@ func (topo *TopologySpecification) portConflictsDetect() error {
 		"TCPPort",
 		"HTTPPort",
 		"ClusterPort",
+		"FlashServicePort",
+		"FlashProxyPort",
+		"FlashProxyStatusPort",
 	}
 
+	// all conflicts are reported at once rather than one per run
+	var conflicts []string
 	portStats := map[usedPort]conflict{}
 	uniqueHosts := set.NewStringSet()
 	topoSpec := reflect.ValueOf(topo).Elem()
@@ -657,8 +837,9 @@ func (topo *TopologySpecification) portConflictsDetect() error {
 					tp := compSpec.Type().Field(j).Tag.Get("yaml")
 					prev, exist := portStats[item]
 					if exist {
-						return errors.Errorf("port '%d' conflicts between '%s:%s.%s' and '%s:%s.%s'",
-							item.port, prev.cfg, item.host, prev.tp, cfg, item.host, tp)
+						conflicts = append(conflicts, fmt.Sprintf("port '%d' conflicts between '%s:%s.%s' and '%s:%s.%s'",
+							item.port, prev.cfg, item.host, prev.tp, cfg, item.host, tp))
+						continue
 					}
 					portStats[item] = conflict{
 						tp:  tp,
@@ -675,7 +856,13 @@ func (topo *TopologySpecification) portConflictsDetect() error {
 		"BlackboxExporterPort",
 	}
 	monitoredOpt := topoSpec.FieldByName(monitorOptionTypeName)
+	hosts := make([]string, 0, len(uniqueHosts))
 	for host := range uniqueHosts {
+		hosts = append(hosts, host)
+	}
+	// keep the order of reported conflicts stable
+	sort.Strings(hosts)
+	for _, host := range hosts {
 		cfg := "monitored"
 		for _, portType := range monitoredPortTypes {
 			f := monitoredOpt.FieldByName(portType)
@@ -691,8 +878,9 @@ func (topo *TopologySpecification) portConflictsDetect() error {
 			tp := strings.Split(ft.Tag.Get("yaml"), ",")[0]
 			prev, exist := portStats[item]
 			if exist {
-				return errors.Errorf("port '%d' conflicts between '%s:%s.%s' and '%s:%s.%s'",
-					item.port, prev.cfg, item.host, prev.tp, cfg, item.host, tp)
+				conflicts = append(conflicts, fmt.Sprintf("port '%d' conflicts between '%s:%s.%s' and '%s:%s.%s'",
+					item.port, prev.cfg, item.host, prev.tp, cfg, item.host, tp))
+				continue
 			}
 			portStats[item] = conflict{
 				tp:  tp,
@@ -701,7 +889,14 @@ func (topo *TopologySpecification) portConflictsDetect() error {
 		}
 	}
 
-	return nil
+	switch len(conflicts) {
+	case 0:
+		return nil
+	case 1:
+		return errors.New(conflicts[0])
+	default:
+		return errors.Errorf("found %d port conflicts:\n  %s", len(conflicts), strings.Join(conflicts, "\n  "))
+	}
 }
 
 func (topo *TopologySpecification) dirConflictsDetect() error {
@@ -781,13 +976,71 @@ func (topo *TopologySpecification) dirConflictsDetect() error {
 	return nil
 }
 
+// sshPortConflictsDetect checks all instances on the same host use the same
+// SSH port, as the SSH executors are shared by the instances on a host
+func (topo *TopologySpecification) sshPortConflictsDetect() error {
+	type usedPort struct {
+		port int
+		cfg  string
+	}
+
+	sshPorts := map[string]usedPort{}
+	topoSpec := reflect.ValueOf(topo).Elem()
+	topoType := reflect.TypeOf(topo).Elem()
+
+	for i := 0; i < topoSpec.NumField(); i++ {
+		if isSkipField(topoSpec.Field(i)) {
+			continue
+		}
+
+		compSpecs := topoSpec.Field(i)
+		for index := 0; index < compSpecs.Len(); index++ {
+			compSpec := compSpecs.Index(index)
+			j, found := findField(compSpec, "SSHPort")
+			if !found {
+				continue
+			}
+			host := compSpec.FieldByName("Host").String()
+			cfg := topoType.Field(i).Tag.Get("yaml")
+			item := usedPort{
+				port: int(compSpec.Field(j).Int()),
+				cfg:  cfg,
+			}
+			prev, exist := sshPorts[host]
+			if exist && prev.port != item.port {
+				return errors.Errorf("ssh_port of host '%s' conflicts between '%s:%d' and '%s:%d'",
+					host, prev.cfg, prev.port, item.cfg, item.port)
+			}
+			sshPorts[host] = item
+		}
+	}
+
+	return nil
+}
+
 // Validate validates the topology specification and produce error if the
 // specification invalid (e.g: port conflicts or directory conflicts)
 func (topo *TopologySpecification) Validate() error {
+	if err := validateServiceManager(topo.GlobalOptions.ServiceManager); err != nil {
+		return err
+	}
+
 	if err := topo.portConflictsDetect(); err != nil {
 		return err
 	}
 
+	if err := topo.sshPortConflictsDetect(); err != nil {
+		return err
+	}
+
+	if err := topo.archConflictsDetect(); err != nil {
+		return err
+	}
+
+	if err := topo.tlsValidate(); err != nil {
+		return err
+	}
+
 	return topo.dirConflictsDetect()
 }
 
@@ -821,6 +1074,155 @@ func (topo *TopologySpecification) Merge(that *TopologySpecification) *TopologyS
 	}
 }
 
+// instanceSpecValue returns the addressable spec of the instance in the topology
+func (topo *TopologySpecification) instanceSpecValue(ins Instance) (reflect.Value, error) {
+	v := reflect.ValueOf(topo).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Slice {
+			continue
+		}
+		for j := 0; j < field.Len(); j++ {
+			spec, ok := field.Index(j).Interface().(InstanceSpec)
+			if !ok || spec.Role() != ins.Role() {
+				continue
+			}
+			if host, _ := spec.SSH(); host != ins.GetHost() || spec.GetMainPort() != ins.GetPort() {
+				continue
+			}
+			return field.Index(j), nil
+		}
+	}
+	return reflect.Value{}, errors.Errorf("instance %s not found in topology", ins.ID())
+}
+
+// SetInstanceConfig replaces the instance level config of the instance in
+// the topology, and returns the config before replacing.
+func (topo *TopologySpecification) SetInstanceConfig(ins Instance, config map[string]interface{}) (map[string]interface{}, error) {
+	spec, err := topo.instanceSpecValue(ins)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := spec.FieldByName("Config")
+	if !cfg.IsValid() {
+		return nil, errors.Errorf("component %s does not support instance config", ins.ComponentName())
+	}
+	old, _ := cfg.Interface().(map[string]interface{})
+	cfg.Set(reflect.ValueOf(config))
+	return old, nil
+}
+
+// SetInstanceDataDir replaces the data dir of the instance in the topology,
+// and returns the data dir before replacing.
+func (topo *TopologySpecification) SetInstanceDataDir(ins Instance, dataDir string) (string, error) {
+	spec, err := topo.instanceSpecValue(ins)
+	if err != nil {
+		return "", err
+	}
+
+	dir := spec.FieldByName("DataDir")
+	if !dir.IsValid() {
+		return "", errors.Errorf("component %s does not have data dir", ins.ComponentName())
+	}
+	old := dir.String()
+	dir.SetString(dataDir)
+	return old, nil
+}
+
+// CopyHostInstances returns the topology of the instances on host with the
+// host replaced by newHost, the labels and configs of the instances are kept.
+// The global options are left empty, they are inherited on scaling out.
+func (topo *TopologySpecification) CopyHostInstances(host, newHost string) *TopologySpecification {
+	part := &TopologySpecification{}
+	src := reflect.ValueOf(topo).Elem()
+	dst := reflect.ValueOf(part).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Field(i)
+		if field.Kind() != reflect.Slice {
+			continue
+		}
+		for j := 0; j < field.Len(); j++ {
+			spec, ok := field.Index(j).Interface().(InstanceSpec)
+			if !ok {
+				continue
+			}
+			if h, _ := spec.SSH(); h != host {
+				continue
+			}
+			cp := reflect.New(field.Type().Elem()).Elem()
+			cp.Set(field.Index(j))
+			cp.FieldByName("Host").SetString(newHost)
+			// the copy is a fresh deployment
+			for _, name := range []string{"Imported", "Offline"} {
+				if f := cp.FieldByName(name); f.IsValid() {
+					f.SetBool(false)
+				}
+			}
+			dst.Field(i).Set(reflect.Append(dst.Field(i), cp))
+		}
+	}
+	return part
+}
+
+// Exported returns the topology to deploy a cluster equivalent to the one
+// described by topo, the instances going offline are excluded and all
+// instances are treated as fresh deployments, user is the deploy user
+func (topo *TopologySpecification) Exported(user string) *TopologySpecification {
+	exported := &TopologySpecification{
+		GlobalOptions:    topo.GlobalOptions,
+		MonitoredOptions: topo.MonitoredOptions,
+		ServerConfigs:    topo.ServerConfigs,
+	}
+	exported.GlobalOptions.User = user
+
+	src := reflect.ValueOf(topo).Elem()
+	dst := reflect.ValueOf(exported).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Field(i)
+		if field.Kind() != reflect.Slice {
+			continue
+		}
+		for j := 0; j < field.Len(); j++ {
+			cp := reflect.New(field.Type().Elem()).Elem()
+			cp.Set(field.Index(j))
+			if f := cp.FieldByName("Offline"); f.IsValid() && f.Bool() {
+				continue
+			}
+			if f := cp.FieldByName("Imported"); f.IsValid() {
+				f.SetBool(false)
+			}
+			dst.Field(i).Set(reflect.Append(dst.Field(i), cp))
+		}
+	}
+	return exported
+}
+
+// RemapHosts replaces the host of each instance by the one it's mapped to in
+// hosts, it fails if any host is not mapped. The ports are not checked, call
+// Validate for the conflicts after remapping.
+func (topo *TopologySpecification) RemapHosts(hosts map[string]string) error {
+	v := reflect.ValueOf(topo).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Slice {
+			continue
+		}
+		for j := 0; j < field.Len(); j++ {
+			host := field.Index(j).FieldByName("Host")
+			if !host.IsValid() {
+				continue
+			}
+			mapped, ok := hosts[host.String()]
+			if !ok || mapped == "" {
+				return errors.Errorf("host %s of %s is not mapped", host.String(), strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0])
+			}
+			host.SetString(mapped)
+		}
+	}
+	return nil
+}
+
 // fillDefaults tries to fill custom fields to their default values
 func fillCustomDefaults(globalOptions *GlobalOptions, data interface{}) error {
 	v := reflect.ValueOf(data).Elem()
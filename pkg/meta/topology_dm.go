@@ -48,6 +48,7 @@ type (
 type MasterSpec struct {
 	Host     string `yaml:"host"`
 	SSHPort  int    `yaml:"ssh_port,omitempty"`
+	Arch     string `yaml:"arch,omitempty"`
 	Imported bool   `yaml:"imported,omitempty"`
 	// Use Name to get the name with a default value if it's empty.
 	Name      string                 `yaml:"name"`
@@ -91,6 +92,7 @@ func (s MasterSpec) IsImported() bool {
 type WorkerSpec struct {
 	Host     string `yaml:"host"`
 	SSHPort  int    `yaml:"ssh_port,omitempty"`
+	Arch     string `yaml:"arch,omitempty"`
 	Imported bool   `yaml:"imported,omitempty"`
 	// Use Name to get the name with a default value if it's empty.
 	Name      string                 `yaml:"name"`
@@ -181,6 +181,53 @@ tikv_servers:
 	c.Assert(err, NotNil)
 	c.Assert(err.Error(), Equals, "port '1234' conflicts between 'tidb_servers:172.16.5.138.port' and 'monitored:172.16.5.138.node_exporter_port'")
 
+	// all conflicts are reported
+	topo = TopologySpecification{}
+	err = yaml.Unmarshal([]byte(`
+tidb_servers:
+  - host: 172.16.5.138
+    port: 1234
+tikv_servers:
+  - host: 172.16.5.138
+    status_port: 1234
+tiflash_servers:
+  - host: 172.16.5.138
+    flash_proxy_port: 20160
+`), &topo)
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, `found 2 port conflicts:
+  port '1234' conflicts between 'tidb_servers:172.16.5.138.port' and 'tikv_servers:172.16.5.138.status_port'
+  port '20160' conflicts between 'tikv_servers:172.16.5.138.port' and 'tiflash_servers:172.16.5.138.flash_proxy_port'`)
+}
+
+func (s *metaSuite) TestSSHPortConflicts(c *C) {
+	topo := TopologySpecification{}
+	err := yaml.Unmarshal([]byte(`
+global:
+  ssh_port: 220
+tidb_servers:
+  - host: 172.16.5.138
+tikv_servers:
+  - host: 172.16.5.138
+    ssh_port: 2222
+`), &topo)
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, "ssh_port of host '172.16.5.138' conflicts between 'tidb_servers:220' and 'tikv_servers:2222'")
+
+	topo = TopologySpecification{}
+	err = yaml.Unmarshal([]byte(`
+global:
+  ssh_port: 220
+tidb_servers:
+  - host: 172.16.5.138
+    ssh_port: 2222
+tikv_servers:
+  - host: 172.16.5.138
+    ssh_port: 2222
+  - host: 172.16.5.139
+`), &topo)
+	c.Assert(err, IsNil)
+	c.Assert(topo.TiKVServers[1].SSHPort, Equals, 220)
 }
 
 func (s *metaSuite) TestGlobalConfig(c *C) {
@@ -511,3 +558,103 @@ item7 = 780
 	c.Assert(err, IsNil)
 	c.Assert(string(merge2), DeepEquals, expected)
 }
+
+func (s *metaSuite) TestExportedTopology(c *C) {
+	topo := TopologySpecification{}
+	err := yaml.Unmarshal([]byte(`
+global:
+  deploy_dir: "test-deploy"
+  data_dir: "test-data"
+tidb_servers:
+  - host: 172.16.5.138
+    imported: true
+tikv_servers:
+  - host: 172.16.5.138
+    data_dir: "/data/tikv"
+  - host: 172.16.5.139
+    offline: true
+pd_servers:
+  - host: 172.16.5.138
+`), &topo)
+	c.Assert(err, IsNil)
+
+	exported := topo.Exported("tidb2")
+	c.Assert(exported.GlobalOptions.User, Equals, "tidb2")
+	c.Assert(len(exported.TiKVServers), Equals, 1)
+	c.Assert(exported.TiDBServers[0].Imported, IsFalse)
+
+	data, err := yaml.Marshal(exported)
+	c.Assert(err, IsNil)
+	reloaded := TopologySpecification{}
+	c.Assert(yaml.Unmarshal(data, &reloaded), IsNil)
+	c.Assert(reloaded.TiKVServers[0].DataDir, Equals, "/data/tikv")
+	data2, err := yaml.Marshal(&reloaded)
+	c.Assert(err, IsNil)
+	c.Assert(string(data2), Equals, string(data))
+}
+
+func (s *metaSuite) TestRemapHosts(c *C) {
+	topo := TopologySpecification{}
+	err := yaml.Unmarshal([]byte(`
+tidb_servers:
+  - host: 172.16.5.138
+tikv_servers:
+  - host: 172.16.5.138
+  - host: 172.16.5.139
+pd_servers:
+  - host: 172.16.5.139
+`), &topo)
+	c.Assert(err, IsNil)
+
+	err = topo.RemapHosts(map[string]string{"172.16.5.138": "10.0.1.1"})
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, "host 172.16.5.139 of tikv_servers is not mapped")
+
+	topo = TopologySpecification{}
+	c.Assert(yaml.Unmarshal([]byte(`
+tikv_servers:
+  - host: 172.16.5.138
+  - host: 172.16.5.139
+`), &topo), IsNil)
+	err = topo.RemapHosts(map[string]string{"172.16.5.138": "10.0.1.1", "172.16.5.139": "10.0.1.2"})
+	c.Assert(err, IsNil)
+	c.Assert(topo.TiKVServers[0].Host, Equals, "10.0.1.1")
+	c.Assert(topo.TiKVServers[1].Host, Equals, "10.0.1.2")
+
+	// both TiKV use the default ports on the same host
+	c.Assert(topo.RemapHosts(map[string]string{"10.0.1.1": "10.0.1.3", "10.0.1.2": "10.0.1.3"}), IsNil)
+	c.Assert(topo.Validate(), NotNil)
+}
+
+func (s *metaSuite) TestHostArch(c *C) {
+	topo := TopologySpecification{}
+	err := yaml.Unmarshal([]byte(`
+tidb_servers:
+  - host: 172.16.5.138
+tikv_servers:
+  - host: 172.16.5.138
+  - host: 172.16.5.139
+    arch: aarch64
+pd_servers:
+  - host: 172.16.5.139
+`), &topo)
+	c.Assert(err, IsNil)
+	c.Assert(topo.TiKVServers[1].Arch, Equals, ArchARM64)
+	c.Assert(topo.HostArch("172.16.5.138"), Equals, DefaultArch)
+	c.Assert(topo.HostArch("172.16.5.139"), Equals, ArchARM64)
+
+	c.Assert(topo.SetHostArch("172.16.5.138", ArchARM64), IsNil)
+	c.Assert(topo.TiDBServers[0].Arch, Equals, ArchARM64)
+	c.Assert(topo.TiKVServers[0].Arch, Equals, ArchARM64)
+	c.Assert(topo.SetHostArch("172.16.5.139", ArchAMD64), NotNil)
+
+	err = yaml.Unmarshal([]byte(`
+tikv_servers:
+  - host: 172.16.5.140
+    arch: amd64
+pd_servers:
+  - host: 172.16.5.140
+    arch: arm64
+`), &TopologySpecification{})
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,316 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package module
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
+	"github.com/pingcap/errors"
+)
+
+// The service managers supported to run the instances
+const (
+	ServiceManagerSystemd     = "systemd"
+	ServiceManagerSupervisord = "supervisord"
+)
+
+// The directories of the service definitions, the program config of
+// supervisord is in the first one existing of the supervisord dirs
+const (
+	SystemdUnitDir        = "/etc/systemd/system"
+	SupervisordDebianDir  = "/etc/supervisor/conf.d"
+	SupervisordDefaultDir = "/etc/supervisord.d"
+)
+
+// ServiceStatus is the state of a service, in the terms of systemd
+type ServiceStatus struct {
+	LoadState     string // e.g. loaded, not-found
+	ActiveState   string // e.g. active, activating, deactivating, failed, inactive
+	SubState      string // e.g. running, auto-restart, dead
+	UnitFileState string // e.g. enabled, disabled
+}
+
+// Running returns if the main process of the service is running
+func (s *ServiceStatus) Running() bool {
+	return s.ActiveState == "active" && s.SubState == "running"
+}
+
+// Enabled returns if the service is started on boot
+func (s *ServiceStatus) Enabled() bool {
+	return s.UnitFileState == "enabled"
+}
+
+// String returns the state in the format of `systemctl status`, e.g.
+// active (running)
+func (s *ServiceStatus) String() string {
+	return fmt.Sprintf("%s (%s)", s.ActiveState, s.SubState)
+}
+
+// ServiceManager manages the services on a host, the service is named as the
+// systemd unit, e.g. tikv-20160.service
+type ServiceManager interface {
+	Name() string
+	Start(e executor.TiOpsExecutor, service string) (stdout []byte, stderr []byte, err error)
+	Stop(e executor.TiOpsExecutor, service string) (stdout []byte, stderr []byte, err error)
+	Restart(e executor.TiOpsExecutor, service string) (stdout []byte, stderr []byte, err error)
+	// Reload sends SIGHUP to the main process of the service
+	Reload(e executor.TiOpsExecutor, service string) (stdout []byte, stderr []byte, err error)
+	Enable(e executor.TiOpsExecutor, service string) (stdout []byte, stderr []byte, err error)
+	// DaemonReload reloads the definitions of the services, e.g. after they
+	// are restored, without applying them to the running services
+	DaemonReload(e executor.TiOpsExecutor) (stdout []byte, stderr []byte, err error)
+	// Logs returns the last lines of the stdout and stderr of the service
+	Logs(e executor.TiOpsExecutor, service string, lines int) (stdout []byte, stderr []byte, err error)
+	Status(e executor.TiOpsExecutor, service string) (*ServiceStatus, error)
+}
+
+// ServiceFiles returns the paths where the definition of the service may be
+// installed by the service managers
+func ServiceFiles(service string) []string {
+	program := programName(service)
+	return []string{
+		fmt.Sprintf("%s/%s", SystemdUnitDir, service),
+		fmt.Sprintf("%s/%s.conf", SupervisordDebianDir, program),
+		fmt.Sprintf("%s/%s.ini", SupervisordDefaultDir, program),
+	}
+}
+
+// detected caches the service managers detected on the hosts
+var detected sync.Map // host -> name of the service manager
+
+// NewServiceManager returns the service manager of the name, it's detected on
+// the host through the executor if the name is empty: systemd is used if it's
+// the init system, otherwise supervisord if it's installed.
+func NewServiceManager(e executor.TiOpsExecutor, host, name string) (ServiceManager, error) {
+	if name == "" {
+		if v, ok := detected.Load(host); ok {
+			name = v.(string)
+		} else {
+			cmd := fmt.Sprintf("if [ -d /run/systemd/system ]; then echo %s; elif command -v supervisorctl >/dev/null; then echo %s; fi",
+				ServiceManagerSystemd, ServiceManagerSupervisord)
+			stdout, stderr, err := e.Execute(cmd, false)
+			if err != nil {
+				return nil, errors.Annotatef(err, "failed to detect the service manager of %s: %s", host, strings.TrimSpace(string(stderr)))
+			}
+			name = strings.TrimSpace(string(stdout))
+			if name == "" {
+				return nil, errors.Errorf("neither systemd nor supervisord is found on %s", host)
+			}
+			detected.Store(host, name)
+		}
+	}
+
+	switch name {
+	case ServiceManagerSystemd:
+		return systemdManager{}, nil
+	case ServiceManagerSupervisord:
+		return supervisordManager{}, nil
+	}
+	return nil, errors.Errorf("unknown service manager %s, only %s and %s are supported",
+		name, ServiceManagerSystemd, ServiceManagerSupervisord)
+}
+
+// systemdManager manages the services as systemd units
+type systemdManager struct{}
+
+func (systemdManager) Name() string { return ServiceManagerSystemd }
+
+func (systemdManager) action(e executor.TiOpsExecutor, service, action string) ([]byte, []byte, error) {
+	c := SystemdModuleConfig{
+		Unit:         service,
+		ReloadDaemon: true, // always reload before operate
+		Action:       action,
+	}
+	return NewSystemdModule(c).Execute(e)
+}
+
+func (m systemdManager) Start(e executor.TiOpsExecutor, service string) ([]byte, []byte, error) {
+	return m.action(e, service, "start")
+}
+
+func (m systemdManager) Stop(e executor.TiOpsExecutor, service string) ([]byte, []byte, error) {
+	return m.action(e, service, "stop")
+}
+
+func (m systemdManager) Restart(e executor.TiOpsExecutor, service string) ([]byte, []byte, error) {
+	return m.action(e, service, "restart")
+}
+
+func (m systemdManager) Enable(e executor.TiOpsExecutor, service string) ([]byte, []byte, error) {
+	return m.action(e, service, "enable")
+}
+
+func (systemdManager) Reload(e executor.TiOpsExecutor, service string) ([]byte, []byte, error) {
+	// not through the systemd module, which lowercases the signal name
+	return e.Execute(fmt.Sprintf("systemctl kill --signal=HUP --kill-who=main %s", service), true)
+}
+
+func (systemdManager) DaemonReload(e executor.TiOpsExecutor) ([]byte, []byte, error) {
+	return e.Execute("systemctl daemon-reload", true)
+}
+
+func (systemdManager) Logs(e executor.TiOpsExecutor, service string, lines int) ([]byte, []byte, error) {
+	return e.Execute(fmt.Sprintf("journalctl -u %s -n %d --no-pager", service, lines), true)
+}
+
+func (systemdManager) Status(e executor.TiOpsExecutor, service string) (*ServiceStatus, error) {
+	// not through the systemd module, which lowercases the property names
+	cmd := fmt.Sprintf("systemctl show -p LoadState -p ActiveState -p SubState -p UnitFileState %s", service)
+	stdout, stderr, err := e.Execute(cmd, false)
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to get status of %s: %s", service, strings.TrimSpace(string(stderr)))
+	}
+
+	status := &ServiceStatus{}
+	for _, line := range strings.Split(string(stdout), "\n") {
+		kv := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "LoadState":
+			status.LoadState = kv[1]
+		case "ActiveState":
+			status.ActiveState = kv[1]
+		case "SubState":
+			status.SubState = kv[1]
+		case "UnitFileState":
+			status.UnitFileState = kv[1]
+		}
+	}
+	if status.ActiveState == "" {
+		return nil, errors.Errorf("unexpected output: %s", string(stdout))
+	}
+	return status, nil
+}
+
+// supervisordManager manages the services as supervisord programs named as
+// the units without the .service suffix, the programs are started on boot
+// by supervisord once their config is loaded
+type supervisordManager struct{}
+
+func programName(service string) string {
+	return strings.TrimSuffix(service, ".service")
+}
+
+func (supervisordManager) Name() string { return ServiceManagerSupervisord }
+
+// ctl runs the supervisorctl command for the program, it exits with 0 on
+// errors in some versions, so the output is checked too
+func (supervisordManager) ctl(e executor.TiOpsExecutor, cmd string, ignored ...string) ([]byte, []byte, error) {
+	// 100s just for avoid timeout now, like systemd
+	stdout, stderr, err := e.Execute(cmd, true, 100*time.Second)
+	for _, s := range ignored {
+		if bytes.Contains(stdout, []byte(s)) {
+			return stdout, stderr, nil
+		}
+	}
+	if err == nil && bytes.Contains(stdout, []byte("ERROR")) {
+		err = errors.Errorf("failed to execute %s: %s", cmd, strings.TrimSpace(string(stdout)))
+	}
+	return stdout, stderr, err
+}
+
+func (m supervisordManager) Start(e executor.TiOpsExecutor, service string) ([]byte, []byte, error) {
+	// load the program config first like daemon-reload, the program may be
+	// started by the update
+	program := programName(service)
+	cmd := fmt.Sprintf("supervisorctl reread >/dev/null && supervisorctl update %s >/dev/null; supervisorctl start %s", program, program)
+	return m.ctl(e, cmd, "already started")
+}
+
+func (m supervisordManager) Stop(e executor.TiOpsExecutor, service string) ([]byte, []byte, error) {
+	// the program not loaded is not running either
+	return m.ctl(e, fmt.Sprintf("supervisorctl stop %s", programName(service)), "not running", "no such process")
+}
+
+func (m supervisordManager) Restart(e executor.TiOpsExecutor, service string) ([]byte, []byte, error) {
+	program := programName(service)
+	cmd := fmt.Sprintf("supervisorctl reread >/dev/null && supervisorctl update %s >/dev/null; supervisorctl restart %s", program, program)
+	return m.ctl(e, cmd)
+}
+
+func (m supervisordManager) Reload(e executor.TiOpsExecutor, service string) ([]byte, []byte, error) {
+	stdout, stderr, err := m.ctl(e, fmt.Sprintf("supervisorctl signal HUP %s", programName(service)))
+	// the signal command is added in supervisor 3.2, restart the program
+	// with the older ones to apply the config anyway
+	if bytes.Contains(stdout, []byte("Unknown syntax")) {
+		return m.Restart(e, service)
+	}
+	return stdout, stderr, err
+}
+
+func (m supervisordManager) Enable(e executor.TiOpsExecutor, service string) ([]byte, []byte, error) {
+	// autostart is set in the program config, loading it is enough
+	return m.ctl(e, fmt.Sprintf("supervisorctl reread && supervisorctl update %s", programName(service)))
+}
+
+func (m supervisordManager) DaemonReload(e executor.TiOpsExecutor) ([]byte, []byte, error) {
+	// reread only loads the program configs, update would apply them
+	return m.ctl(e, "supervisorctl reread")
+}
+
+func (supervisordManager) Logs(e executor.TiOpsExecutor, service string, lines int) ([]byte, []byte, error) {
+	// tail of supervisorctl counts in bytes, take enough bytes for the lines,
+	// stdout and stderr are logged separately by supervisord
+	program := programName(service)
+	cmd := fmt.Sprintf("supervisorctl tail -%[1]d %[2]s | tail -n %[3]d; supervisorctl tail -%[1]d %[2]s stderr | tail -n %[3]d",
+		lines*1024, program, lines)
+	return e.Execute(cmd, true)
+}
+
+// supervisordStates maps the process states of supervisord to the ones of
+// systemd, see http://supervisord.org/subprocess.html#process-states
+var supervisordStates = map[string][2]string{
+	"RUNNING":  {"active", "running"},
+	"STARTING": {"activating", "start"},
+	"BACKOFF":  {"activating", "auto-restart"},
+	"STOPPING": {"deactivating", "stop"},
+	"STOPPED":  {"inactive", "dead"},
+	"EXITED":   {"inactive", "dead"},
+	"FATAL":    {"failed", "failed"},
+	"UNKNOWN":  {"failed", "failed"},
+}
+
+func (supervisordManager) Status(e executor.TiOpsExecutor, service string) (*ServiceStatus, error) {
+	// e.g. tikv-20160    RUNNING   pid 1234, uptime 0:01:02, it exits with
+	// non-zero if the program is not running
+	program := programName(service)
+	stdout, stderr, err := e.Execute(fmt.Sprintf("supervisorctl status %s", program), true)
+	if bytes.Contains(stdout, []byte("no such process")) {
+		return &ServiceStatus{LoadState: "not-found", ActiveState: "inactive", SubState: "dead"}, nil
+	}
+	fields := strings.Fields(string(stdout))
+	if len(fields) < 2 || fields[0] != program {
+		if err != nil {
+			return nil, errors.Annotatef(err, "failed to get status of %s: %s", service, strings.TrimSpace(string(stderr)))
+		}
+		return nil, errors.Errorf("unexpected output: %s", string(stdout))
+	}
+	state, ok := supervisordStates[fields[1]]
+	if !ok {
+		return nil, errors.Errorf("unexpected output: %s", string(stdout))
+	}
+	return &ServiceStatus{
+		LoadState:     "loaded",
+		ActiveState:   state[0],
+		SubState:      state[1],
+		UnitFileState: "enabled",
+	}, nil
+}
@@ -18,12 +18,13 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/pingcap-incubator/tiup-cluster/pkg/api"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
-	"github.com/pingcap-incubator/tiup-cluster/pkg/module"
 	"github.com/pingcap-incubator/tiup/pkg/set"
 	"github.com/pingcap/errors"
 	"golang.org/x/sync/errgroup"
@@ -48,6 +49,15 @@ func Start(
 			return errors.Annotatef(err, "failed to start %s", com.Name())
 		}
 		if clusterSpec := spec.GetClusterSpecification(); clusterSpec != nil {
+			// let the stores have leaders again if they are evicted on stop,
+			// the spec might be a part of the cluster without PD on scaling out
+			if com.Name() == meta.ComponentTiKV && len(clusterSpec.PDServers) > 0 {
+				for _, inst := range insts {
+					if err := RemoveEvictLeaderScheduler(clusterSpec.GetPDList(), inst); err != nil {
+						log.Warnf("%s", err)
+					}
+				}
+			}
 			for _, inst := range insts {
 				if !uniqueHosts.Exist(inst.GetHost()) {
 					uniqueHosts.Insert(inst.GetHost())
@@ -80,6 +90,26 @@ func Stop(
 
 	for _, com := range components {
 		insts := FilterInstance(com.Instances(), nodeFilter)
+		if clusterSpec := spec.GetClusterSpecification(); clusterSpec != nil &&
+			com.Name() == meta.ComponentTiKV && !options.SkipEvictLeader &&
+			len(insts) < len(com.Instances()) {
+			// the leaders can only be evicted if some stores are left running
+			for _, inst := range insts {
+				if err := EvictStoreLeader(clusterSpec.GetPDList(), inst, options.Timeout); err != nil {
+					log.Warnf("%s, stopping it anyway", err)
+				}
+			}
+		}
+		if clusterSpec := spec.GetClusterSpecification(); clusterSpec != nil &&
+			com.Name() == meta.ComponentPD && len(insts) < len(com.Instances()) {
+			// avoid the election stall caused by stopping the leader
+			if err := TransferPDLeader(clusterSpec.GetPDList(), insts, options.Timeout, options.TLSConfig); err != nil {
+				if !options.Force {
+					return errors.Annotate(err, "use --force to stop the PD leader anyway")
+				}
+				log.Warnf("%s, stopping it anyway", err)
+			}
+		}
 		err := StopComponent(getter, insts)
 		if err != nil {
 			return errors.Annotatef(err, "failed to stop %s", com.Name())
@@ -131,8 +161,15 @@ func DestroyTombstone(
 	return nil, nil
 }
 
+// tombstoneConcurrency is the max number of tombstone nodes destroyed at
+// the same time
+const tombstoneConcurrency = 8
+
 // DestroyClusterTombstone remove the tombstone node in spec and destroy them.
 // If returNodesOnly is true, it will only return the node id that can be destroy.
+// The tombstone state is queried from PD one by one, then the nodes are
+// destroyed in parallel. Only the destroyed nodes are removed from spec, the
+// failed ones are kept to be retried and reported together in the error.
 func DestroyClusterTombstone(
 	getter ExecutorGetter,
 	spec *meta.ClusterSpecification,
@@ -145,6 +182,8 @@ func DestroyClusterTombstone(
 		return nil, errors.AddStack(err)
 	}
 
+	// the instances of each tombstone node, in the order of nodes
+	var tombstones [][]meta.Instance
 	filterID := func(instance []meta.Instance, id string) (res []meta.Instance) {
 		for _, ins := range instance {
 			if ins.ID() == id {
@@ -154,10 +193,8 @@ func DestroyClusterTombstone(
 		return
 	}
 
-	var kvServers []meta.TiKVSpec
 	for _, s := range spec.TiKVServers {
 		if !s.Offline {
-			kvServers = append(kvServers, s)
 			continue
 		}
 
@@ -165,38 +202,18 @@ func DestroyClusterTombstone(
 
 		tombstone, err := pdClient.IsTombStone(id)
 		if err != nil {
-			return nil, errors.AddStack(err)
+			return nil, wrapPDError(err)
 		}
-
 		if !tombstone {
-			kvServers = append(kvServers, s)
 			continue
 		}
 
 		nodes = append(nodes, id)
-		if returNodesOnly {
-			continue
-		}
-
-		instances := (&meta.TiKVComponent{ClusterSpecification: spec}).Instances()
-		instances = filterID(instances, id)
-
-		err = StopComponent(getter, instances)
-		if err != nil {
-			return nil, errors.AddStack(err)
-		}
-
-		err = DestroyComponent(getter, instances)
-		if err != nil {
-			return nil, errors.AddStack(err)
-		}
-
+		tombstones = append(tombstones, filterID((&meta.TiKVComponent{ClusterSpecification: spec}).Instances(), id))
 	}
 
-	var pumpServers []meta.PumpSpec
 	for _, s := range spec.PumpServers {
 		if !s.Offline {
-			pumpServers = append(pumpServers, s)
 			continue
 		}
 
@@ -206,34 +223,16 @@ func DestroyClusterTombstone(
 		if err != nil {
 			return nil, errors.AddStack(err)
 		}
-
 		if !tombstone {
-			pumpServers = append(pumpServers, s)
-		}
-
-		nodes = append(nodes, id)
-		if returNodesOnly {
 			continue
 		}
 
-		instances := (&meta.PumpComponent{ClusterSpecification: spec}).Instances()
-		instances = filterID(instances, id)
-		err = StopComponent(getter, instances)
-		if err != nil {
-			return nil, errors.AddStack(err)
-		}
-
-		err = DestroyComponent(getter, instances)
-		if err != nil {
-			return nil, errors.AddStack(err)
-		}
-
+		nodes = append(nodes, id)
+		tombstones = append(tombstones, filterID((&meta.PumpComponent{ClusterSpecification: spec}).Instances(), id))
 	}
 
-	var drainerServers []meta.DrainerSpec
 	for _, s := range spec.Drainers {
 		if !s.Offline {
-			drainerServers = append(drainerServers, s)
 			continue
 		}
 
@@ -243,38 +242,76 @@ func DestroyClusterTombstone(
 		if err != nil {
 			return nil, errors.AddStack(err)
 		}
-
 		if !tombstone {
-			drainerServers = append(drainerServers, s)
+			continue
 		}
 
 		nodes = append(nodes, id)
-		if returNodesOnly {
-			continue
-		}
+		tombstones = append(tombstones, filterID((&meta.DrainerComponent{ClusterSpecification: spec}).Instances(), id))
+	}
 
-		instances := (&meta.DrainerComponent{ClusterSpecification: spec}).Instances()
-		instances = filterID(instances, id)
+	if returNodesOnly || len(nodes) == 0 {
+		return
+	}
 
-		err = StopComponent(getter, instances)
-		if err != nil {
-			return nil, errors.AddStack(err)
-		}
+	errs := make([]error, len(nodes))
+	sem := make(chan struct{}, tombstoneConcurrency)
+	wg := sync.WaitGroup{}
+	for i := range nodes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := StopComponent(getter, tombstones[i]); err != nil {
+				errs[i] = errors.Annotatef(err, "failed to stop %s", nodes[i])
+				return
+			}
+			if err := DestroyComponent(getter, tombstones[i]); err != nil {
+				errs[i] = errors.Annotatef(err, "failed to destroy %s", nodes[i])
+			}
+		}(i)
+	}
+	wg.Wait()
 
-		err = DestroyComponent(getter, instances)
-		if err != nil {
-			return nil, errors.AddStack(err)
+	destroyed := set.NewStringSet()
+	var failed []string
+	for i, node := range nodes {
+		if errs[i] != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", node, errs[i]))
+			continue
 		}
+		destroyed.Insert(node)
 	}
 
-	if returNodesOnly {
-		return
+	var kvServers []meta.TiKVSpec
+	for _, s := range spec.TiKVServers {
+		if !destroyed.Exist(s.Host + ":" + strconv.Itoa(s.Port)) {
+			kvServers = append(kvServers, s)
+		}
+	}
+	var pumpServers []meta.PumpSpec
+	for _, s := range spec.PumpServers {
+		if !destroyed.Exist(s.Host + ":" + strconv.Itoa(s.Port)) {
+			pumpServers = append(pumpServers, s)
+		}
+	}
+	var drainerServers []meta.DrainerSpec
+	for _, s := range spec.Drainers {
+		if !destroyed.Exist(s.Host + ":" + strconv.Itoa(s.Port)) {
+			drainerServers = append(drainerServers, s)
+		}
 	}
-
 	spec.TiKVServers = kvServers
 	spec.PumpServers = pumpServers
 	spec.Drainers = drainerServers
 
+	if len(failed) > 0 {
+		return nodes, errors.Errorf("failed to destroy %d of %d tombstone nodes:\n%s",
+			len(failed), len(nodes), strings.Join(failed, "\n"))
+	}
 	return
 }
 
@@ -307,13 +344,11 @@ func StartMonitored(getter ExecutorGetter, instance meta.Instance, options meta.
 	for _, comp := range []string{meta.ComponentNodeExporter, meta.ComponentBlackboxExporter} {
 		log.Infof("Starting component %s", comp)
 		log.Infof("\tStarting instance %s", instance.GetHost())
-		c := module.SystemdModuleConfig{
-			Unit:         fmt.Sprintf("%s-%d.service", comp, ports[comp]),
-			ReloadDaemon: true,
-			Action:       "start",
+		mgr, err := meta.InstanceServiceManager(e, instance)
+		if err != nil {
+			return err
 		}
-		systemd := module.NewSystemdModule(c)
-		stdout, stderr, err := systemd.Execute(e)
+		stdout, stderr, err := mgr.Start(e, fmt.Sprintf("%s-%d.service", comp, ports[comp]))
 
 		if len(stdout) > 0 {
 			fmt.Println(string(stdout))
@@ -352,14 +387,12 @@ func RestartComponent(getter ExecutorGetter, instances []meta.Instance) error {
 		e := getter.Get(ins.GetHost())
 		log.Infof("\tRestarting instance %s", ins.GetHost())
 
-		// Restart by systemd.
-		c := module.SystemdModuleConfig{
-			Unit:         ins.ServiceName(),
-			ReloadDaemon: true,
-			Action:       "restart",
+		// Restart by the service manager of the host.
+		mgr, err := meta.InstanceServiceManager(e, ins)
+		if err != nil {
+			return err
 		}
-		systemd := module.NewSystemdModule(c)
-		stdout, stderr, err := systemd.Execute(e)
+		stdout, stderr, err := mgr.Restart(e, ins.ServiceName())
 
 		if len(stdout) > 0 {
 			fmt.Println(string(stdout))
@@ -393,15 +426,18 @@ func startInstance(getter ExecutorGetter, ins meta.Instance) error {
 		ins.GetHost(),
 		ins.GetPort())
 
-	// Start by systemd.
-	c := module.SystemdModuleConfig{
-		Unit:         ins.ServiceName(),
-		ReloadDaemon: true,
-		Action:       "start",
-		Enabled:      true,
+	// Start by the service manager of the host, and enable it on boot.
+	mgr, err := meta.InstanceServiceManager(e, ins)
+	if err != nil {
+		return err
+	}
+	stdout, stderr, err := mgr.Start(e, ins.ServiceName())
+	if err == nil {
+		var enableOut, enableErr []byte
+		enableOut, enableErr, err = mgr.Enable(e, ins.ServiceName())
+		stdout = append(stdout, enableOut...)
+		stderr = append(stderr, enableErr...)
 	}
-	systemd := module.NewSystemdModule(c)
-	stdout, stderr, err := systemd.Execute(e)
 
 	if len(stdout) > 0 {
 		fmt.Println(string(stdout))
@@ -472,13 +508,11 @@ func StopMonitored(getter ExecutorGetter, instance meta.Instance, options meta.M
 	for _, comp := range []string{meta.ComponentNodeExporter, meta.ComponentBlackboxExporter} {
 		log.Infof("Stopping component %s", comp)
 
-		c := module.SystemdModuleConfig{
-			Unit:         fmt.Sprintf("%s-%d.service", comp, ports[comp]),
-			Action:       "stop",
-			ReloadDaemon: true,
+		mgr, err := meta.InstanceServiceManager(e, instance)
+		if err != nil {
+			return err
 		}
-		systemd := module.NewSystemdModule(c)
-		stdout, stderr, err := systemd.Execute(e)
+		stdout, stderr, err := mgr.Stop(e, fmt.Sprintf("%s-%d.service", comp, ports[comp]))
 
 		if len(stdout) > 0 {
 			fmt.Println(string(stdout))
@@ -521,14 +555,12 @@ func stopInstance(getter ExecutorGetter, ins meta.Instance) error {
 	e := getter.Get(ins.GetHost())
 	log.Infof("\tStopping instance %s", ins.GetHost())
 
-	// Stop by systemd.
-	c := module.SystemdModuleConfig{
-		Unit:         ins.ServiceName(),
-		Action:       "stop",
-		ReloadDaemon: true, // always reload before operate
+	// Stop by the service manager of the host.
+	mgr, err := meta.InstanceServiceManager(e, ins)
+	if err != nil {
+		return err
 	}
-	systemd := module.NewSystemdModule(c)
-	stdout, stderr, err := systemd.Execute(e)
+	stdout, stderr, err := mgr.Stop(e, ins.ServiceName())
 
 	if len(stdout) > 0 {
 		fmt.Println(string(stdout))
@@ -603,12 +635,12 @@ func PrintClusterStatus(getter ExecutorGetter, spec meta.Specification) (health
 
 			errg.Go(func() error {
 				e := getter.Get(ins.GetHost())
-				active, err := GetServiceStatus(e, ins.ServiceName())
+				status, err := GetInstanceStatus(e, ins)
 				if err != nil {
 					health = false
 					log.Errorf("\t%s\t%v", ins.GetHost(), err)
 				} else {
-					log.Infof("\t%s\t%s", ins.GetHost(), active)
+					log.Infof("\t%s\t%s", ins.GetHost(), status)
 				}
 				return nil
 			})
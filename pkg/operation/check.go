@@ -20,11 +20,11 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/AstroProfundis/sysinfo"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/clusterutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
-	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/module"
 	"github.com/pingcap/tidb-insight/collector/insight"
@@ -37,10 +37,46 @@ type CheckOptions struct {
 	EnableMem  bool
 	EnableDisk bool
 
+	// the minimum CPU threads and memory size in GB of the CPU and memory
+	// checks, the defaults are used if they are not set
+	MinCPUThreads int
+	MinMemSize    int
+	// the max clock skew relative to the control machine, DefaultMaxClockSkew
+	// is used if it's not set
+	MaxClockSkew time.Duration
+
 	// pre-defined goups of checks
 	//GroupMinimal bool // a minimal set of checks
 }
 
+// The minimum resources of a host required by default
+const (
+	DefaultMinCPUThreads = 16
+	DefaultMinMemSize    = 32 // in GB
+)
+
+func (opt *CheckOptions) minCPUThreads() int {
+	if opt.MinCPUThreads > 0 {
+		return opt.MinCPUThreads
+	}
+	return DefaultMinCPUThreads
+}
+
+// ClockSkewThreshold returns the max clock skew allowed by the options
+func (opt *CheckOptions) ClockSkewThreshold() time.Duration {
+	if opt.MaxClockSkew > 0 {
+		return opt.MaxClockSkew
+	}
+	return DefaultMaxClockSkew
+}
+
+func (opt *CheckOptions) minMemSize() int {
+	if opt.MinMemSize > 0 {
+		return opt.MinMemSize
+	}
+	return DefaultMinMemSize
+}
+
 // Names of checks
 var (
 	CheckNameGeneral     = "general" // errors that don't fit any specific check
@@ -59,6 +95,11 @@ var (
 	CheckNameSELinux     = "selinux"
 	CheckNameCommand     = "command"
 	CheckNameFio         = "fio"
+	CheckNameTHP         = "thp"
+	CheckNameClockSkew   = "clock-skew"
+	CheckNameNumaNode    = "numa-node"
+	CheckNameResLimits   = "resource-limits"
+	CheckNameLogRotation = "log-rotation"
 )
 
 // CheckResult is the result of a check
@@ -169,7 +210,9 @@ func checkNTP(ntpInfo *insight.TimeStat) *CheckResult {
 	}
 
 	if ntpInfo.Status == "none" {
-		log.Infof("The NTPd daemon may be not installed, skip.")
+		// the clock may drift without being noticed, it's not a pass
+		result.Err = fmt.Errorf("no NTP daemon found, the clock is not synchronized")
+		result.Warn = true
 		return result
 	}
 
@@ -183,10 +226,10 @@ func checkNTP(ntpInfo *insight.TimeStat) *CheckResult {
 
 func checkCPU(opt *CheckOptions, cpuInfo *sysinfo.CPU) []*CheckResult {
 	var results []*CheckResult
-	if opt.EnableCPU && cpuInfo.Threads < 16 {
+	if opt.EnableCPU && cpuInfo.Threads < uint(opt.minCPUThreads()) {
 		results = append(results, &CheckResult{
 			Name: CheckNameCPUThreads,
-			Err:  fmt.Errorf("CPU thread count %d too low, needs 16 or more", cpuInfo.Threads),
+			Err:  fmt.Errorf("CPU thread count %d too low, needs %d or more", cpuInfo.Threads, opt.minCPUThreads()),
 		})
 	} else {
 		results = append(results, &CheckResult{
@@ -215,11 +258,10 @@ func checkMem(opt *CheckOptions, memInfo *sysinfo.Memory) []*CheckResult {
 		})
 	}
 
-	// 32GB
-	if opt.EnableMem && memInfo.Size < 1024*32 {
+	if opt.EnableMem && memInfo.Size < uint(1024*opt.minMemSize()) {
 		results = append(results, &CheckResult{
 			Name: CheckNameMem,
-			Err:  fmt.Errorf("memory size %dMB too low, needs 32GB or more", memInfo.Size),
+			Err:  fmt.Errorf("memory size %dMB too low, needs %dGB or more", memInfo.Size, opt.minMemSize()),
 		})
 	} else {
 		results = append(results, &CheckResult{
@@ -377,6 +419,69 @@ func CheckKernelParameters(opt *CheckOptions, p []byte) []*CheckResult {
 	return results
 }
 
+// CheckResourceLimits checks the limit_nofile and tasks_max of the instances
+// on the host don't exceed the maxima of the kernel in the output of
+// `sysctl -a`, with which the service would fail to start
+func CheckResourceLimits(host string, topo *meta.TopologySpecification, p []byte) []*CheckResult {
+	maxima := map[string]int{}
+	for _, line := range strings.Split(string(p), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		switch fields[0] {
+		case "fs.nr_open", "kernel.pid_max", "kernel.threads-max":
+			maxima[fields[0]], _ = strconv.Atoi(fields[2])
+		}
+	}
+	// the tasks are limited by both of them
+	maxTasks := maxima["kernel.pid_max"]
+	if n := maxima["kernel.threads-max"]; n > 0 && (maxTasks == 0 || n < maxTasks) {
+		maxTasks = n
+	}
+
+	var results []*CheckResult
+	check := func(ins meta.Instance, name, value, param string, max int) {
+		n, err := strconv.Atoi(value)
+		if err != nil || max == 0 || n <= max {
+			// infinity and percentages are capped by the kernel
+			return
+		}
+		results = append(results, &CheckResult{
+			Name: CheckNameResLimits,
+			Err:  fmt.Errorf("%s %d of %s exceeds the %s %d of the host", name, n, ins.ID(), param, max),
+			Warn: true,
+		})
+	}
+	topo.IterInstance(func(ins meta.Instance) {
+		if ins.GetHost() != host {
+			return
+		}
+		rc := topo.InstanceResourceControl(ins)
+		check(ins, "limit_nofile", rc.LimitNOFILE, "fs.nr_open", maxima["fs.nr_open"])
+		check(ins, "tasks_max", rc.TasksMax, "max tasks", maxTasks)
+	})
+	return results
+}
+
+// CheckLogRotation checks the log dirs of the instances on the host are
+// rotated, otherwise the logs may grow until the disk is full
+func CheckLogRotation(host string, topo *meta.TopologySpecification) []*CheckResult {
+	var results []*CheckResult
+	topo.IterInstance(func(ins meta.Instance) {
+		if ins.GetHost() != host || topo.InstanceLogRotation(ins).Enabled() {
+			return
+		}
+		results = append(results, &CheckResult{
+			Name: CheckNameLogRotation,
+			Err:  fmt.Errorf("log dir %s of %s is not rotated", ins.LogDir(), ins.ID()),
+			Warn: true,
+			Msg:  "set log_rotation in global or the instance",
+		})
+	})
+	return results
+}
+
 // CheckServices checks if a service is running on the host
 func CheckServices(e executor.TiOpsExecutor, host, service string, disable bool) *CheckResult {
 	result := &CheckResult{
@@ -400,19 +505,20 @@ func CheckServices(e executor.TiOpsExecutor, host, service string, disable bool)
 		return result
 	}
 
-	active, err := GetServiceStatus(e, service+".service")
+	status, err := GetServiceStatus(e, service+".service")
 	if err != nil {
 		result.Err = err
+		return result
 	}
 
 	switch disable {
 	case false:
-		if !strings.Contains(active, "running") {
+		if !status.Running() {
 			result.Err = fmt.Errorf("service %s is not running", service)
 			result.Msg = fmt.Sprintf("start %s.service", service)
 		}
 	case true:
-		if strings.Contains(active, "running") {
+		if status.Running() {
 			result.Err = fmt.Errorf("service %s is running but should be stopped", service)
 			result.Msg = fmt.Sprintf("stop %s.service", service)
 		}
@@ -443,6 +549,27 @@ func CheckSELinux(e executor.TiOpsExecutor) *CheckResult {
 	return result
 }
 
+// CheckTHP checks if the transparent huge pages are disabled on the host
+func CheckTHP(e executor.TiOpsExecutor) *CheckResult {
+	result := &CheckResult{
+		Name: CheckNameTHP,
+	}
+	m := module.NewShellModule(module.ShellModuleConfig{
+		Command: "cat /sys/kernel/mm/transparent_hugepage/enabled",
+		Sudo:    false,
+	})
+	stdout, stderr, err := m.Execute(e)
+	if err != nil {
+		result.Err = fmt.Errorf("%w %s", err, stderr)
+		return result
+	}
+	// the selected mode is in brackets, e.g. "always madvise [never]"
+	if !strings.Contains(string(stdout), "[never]") {
+		result.Err = fmt.Errorf("THP is enabled, please disable it for best performance")
+	}
+	return result
+}
+
 // CheckListeningPort checks if the ports are already binded by some process on host
 func CheckListeningPort(opt *CheckOptions, host string, topo *meta.TopologySpecification, rawData []byte) []*CheckResult {
 	var results []*CheckResult
@@ -507,6 +634,11 @@ func CheckPartitions(opt *CheckOptions, host string, topo *meta.TopologySpecific
 		}
 
 		switch blk.Mount.FSType {
+		case "tmpfs":
+			results = append(results, &CheckResult{
+				Name: CheckNameDisks,
+				Err:  fmt.Errorf("data dir %s is on tmpfs %s, the data will be lost on reboot", dataDir, blk.Mount.MountPoint),
+			})
 		case "ext4":
 			if !strings.Contains(blk.Mount.Options, "nodelalloc") {
 				results = append(results, &CheckResult{
@@ -0,0 +1,53 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/AstroProfundis/sysinfo"
+	"github.com/pingcap/check"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type checkSuite struct{}
+
+var _ = check.Suite(&checkSuite{})
+
+func (s *checkSuite) TestCheckCPU(c *check.C) {
+	opt := &CheckOptions{EnableCPU: true, MinCPUThreads: 8}
+	results := checkCPU(opt, &sysinfo.CPU{Threads: 4, Governor: "performance"})
+	c.Assert(results, check.HasLen, 1)
+	c.Assert(results[0].Name, check.Equals, CheckNameCPUThreads)
+	c.Assert(results[0].Err, check.NotNil)
+
+	results = checkCPU(opt, &sysinfo.CPU{Threads: 8, Governor: "powersave"})
+	c.Assert(results, check.HasLen, 2)
+	c.Assert(results[0].Err, check.IsNil)
+	c.Assert(results[1].Name, check.Equals, CheckNameCPUGovernor)
+	c.Assert(results[1].Err, check.NotNil)
+
+	// the default minimum is used if it's not set
+	results = checkCPU(&CheckOptions{EnableCPU: true}, &sysinfo.CPU{Threads: DefaultMinCPUThreads - 1})
+	c.Assert(results[0].Err, check.NotNil)
+
+	// the thread count is only reported if the check is disabled
+	results = checkCPU(&CheckOptions{}, &sysinfo.CPU{Threads: 1})
+	c.Assert(results[0].Err, check.IsNil)
+}
+
+func (s *checkSuite) TestCheckMem(c *check.C) {
+	opt := &CheckOptions{EnableMem: true, MinMemSize: 16}
+	results := checkMem(opt, &sysinfo.Memory{Size: 8 * 1024})
+	c.Assert(results, check.HasLen, 1)
+	c.Assert(results[0].Name, check.Equals, CheckNameMem)
+	c.Assert(results[0].Err, check.NotNil)
+
+	results = checkMem(opt, &sysinfo.Memory{Size: 16 * 1024, Swap: 1024})
+	c.Assert(results, check.HasLen, 2)
+	c.Assert(results[0].Name, check.Equals, CheckNameSwap)
+	c.Assert(results[0].Err, check.NotNil)
+	c.Assert(results[1].Err, check.IsNil)
+
+	results = checkMem(&CheckOptions{EnableMem: true}, &sysinfo.Memory{Size: (DefaultMinMemSize - 1) * 1024})
+	c.Assert(results[0].Err, check.NotNil)
+}
@@ -0,0 +1,68 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
+	"github.com/pingcap/errors"
+)
+
+// DefaultMaxClockSkew is the max clock skew of a host relative to the
+// control machine allowed by default
+const DefaultMaxClockSkew = 500 * time.Millisecond
+
+// GetClockSkew returns how far the clock of the host is ahead of the control
+// machine, negative if it's behind. The remote time is compared with the
+// middle of the round trip, so the error is at most half of the round trip.
+func GetClockSkew(e executor.TiOpsExecutor) (time.Duration, error) {
+	start := time.Now()
+	stdout, stderr, err := e.Execute("date +%s.%N", false)
+	end := time.Now()
+	if err != nil {
+		return 0, errors.Annotatef(err, "failed to get time: %s", stderr)
+	}
+
+	secs, err := strconv.ParseFloat(strings.TrimSpace(string(stdout)), 64)
+	if err != nil {
+		return 0, errors.Annotatef(err, "unexpected output: %s", string(stdout))
+	}
+	remote := time.Unix(0, int64(secs*float64(time.Second)))
+	local := start.Add(end.Sub(start) / 2)
+	return remote.Sub(local), nil
+}
+
+// CheckClockSkew checks the clock skew of the host relative to the control
+// machine doesn't exceed the threshold
+func CheckClockSkew(e executor.TiOpsExecutor, threshold time.Duration) *CheckResult {
+	result := &CheckResult{
+		Name: CheckNameClockSkew,
+	}
+	skew, err := GetClockSkew(e)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	if skew > threshold || skew < -threshold {
+		result.Err = fmt.Errorf("clock skew %s relative to the control machine exceeds %s", skew.Round(time.Millisecond), threshold)
+		return result
+	}
+	result.Msg = fmt.Sprintf("clock skew relative to the control machine is %s", skew.Round(time.Millisecond))
+	return result
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/api"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap/errors"
+)
+
+// ClusterIDResult is the cluster ID reported by an instance
+type ClusterIDResult struct {
+	ID        string // the ID of the instance
+	Role      string
+	ClusterID uint64
+	Mismatch  bool // the instance belongs to a different cluster
+	Err       error
+}
+
+// VerifyClusterID checks that all PD and TiKV instances report the same
+// cluster ID. The expected ID is the one of the PD cluster, and the returned
+// error is not nil if any instance mismatches. Instances which fail to report
+// their cluster ID are not counted as mismatch.
+func VerifyClusterID(spec *meta.ClusterSpecification, tlsCfg *tls.Config) (uint64, []ClusterIDResult, error) {
+	expected, err := api.NewPDClient(spec.GetPDList(), 10*time.Second, tlsCfg).GetClusterID()
+	if err != nil {
+		return 0, nil, wrapPDError(err)
+	}
+
+	tikvStatusAddrs := map[string]string{}
+	for _, s := range spec.TiKVServers {
+		tikvStatusAddrs[fmt.Sprintf("%s:%d", s.Host, s.Port)] = fmt.Sprintf("%s:%d", s.Host, s.StatusPort)
+	}
+
+	var results []ClusterIDResult
+	spec.IterInstance(func(ins meta.Instance) {
+		var id uint64
+		var err error
+		addr := fmt.Sprintf("%s:%d", ins.GetHost(), ins.GetPort())
+		switch ins.ComponentName() {
+		case meta.ComponentPD:
+			id, err = api.NewPDClient([]string{addr}, 10*time.Second, tlsCfg).GetClusterID()
+		case meta.ComponentTiKV:
+			id, err = tikvClusterID(tikvStatusAddrs[addr], tlsCfg)
+		default:
+			return
+		}
+		results = append(results, ClusterIDResult{
+			ID:        ins.ID(),
+			Role:      ins.Role(),
+			ClusterID: id,
+			Mismatch:  err == nil && id != expected,
+			Err:       err,
+		})
+	})
+
+	var mismatch []string
+	for _, r := range results {
+		if r.Mismatch {
+			mismatch = append(mismatch, r.ID)
+		}
+	}
+	if len(mismatch) > 0 {
+		return expected, results, errors.Errorf("instances %s don't belong to cluster %d",
+			strings.Join(mismatch, ","), expected)
+	}
+	return expected, results, nil
+}
+
+// tikvClusterID returns the ID of the cluster which TiKV is connected to,
+// TiKV doesn't report it directly, so it's asked from the PD endpoints in
+// the running config of TiKV
+func tikvClusterID(statusAddr string, tlsCfg *tls.Config) (uint64, error) {
+	pdAddrs, err := api.NewTiKVClient([]string{statusAddr}, 10*time.Second, tlsCfg).GetPDEndpoints()
+	if err != nil {
+		return 0, errors.Annotatef(err, "failed to get the PD endpoints of TiKV %s", statusAddr)
+	}
+	return api.NewPDClient(pdAddrs, 10*time.Second, tlsCfg).GetClusterID()
+}
@@ -0,0 +1,36 @@
+package operator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/pingcap/check"
+)
+
+type clusterIDSuite struct{}
+
+var _ = check.Suite(&clusterIDSuite{})
+
+func (s *clusterIDSuite) TestTiKVClusterID(c *check.C) {
+	pd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.URL.Path, check.Equals, "/pd/api/v1/cluster")
+		fmt.Fprint(w, `{"id": 6812345}`)
+	}))
+	defer pd.Close()
+	tikv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.URL.Path, check.Equals, "/config")
+		fmt.Fprintf(w, `{"pd": {"endpoints": [%q]}}`, pd.URL)
+	}))
+	defer tikv.Close()
+
+	id, err := tikvClusterID(strings.TrimPrefix(tikv.URL, "http://"), nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(id, check.Equals, uint64(6812345))
+
+	// the TiKV is not reachable
+	tikv.Close()
+	_, err = tikvClusterID(strings.TrimPrefix(tikv.URL, "http://"), nil)
+	c.Assert(err, check.NotNil)
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/edit"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap/errors"
+)
+
+// ConfigDrift is the difference of a file between what InitConfig renders
+// from the topology and what is on the remote host
+type ConfigDrift struct {
+	Instance meta.Instance
+	Path     string // the path on the remote host
+	Missing  bool   // the file doesn't exist on the remote host
+	Diff     string // in unified format, from the remote file to the rendered one
+}
+
+// renderExecutor records the files InitConfig would push instead of touching
+// the remote host, the commands are not run at all
+type renderExecutor struct {
+	files map[string]string // remote path -> rendered local file
+}
+
+// Execute implements the TiOpsExecutor interface
+func (e *renderExecutor) Execute(cmd string, sudo bool, timeout ...time.Duration) ([]byte, []byte, error) {
+	// the systemd unit is uploaded to a temporary path and moved in place
+	if fields := strings.Fields(cmd); len(fields) == 3 && fields[0] == "mv" {
+		if src, ok := e.files[fields[1]]; ok {
+			delete(e.files, fields[1])
+			e.files[fields[2]] = src
+		}
+	}
+	return nil, nil, nil
+}
+
+// Transfer implements the TiOpsExecutor interface
+func (e *renderExecutor) Transfer(src string, dst string, download bool) error {
+	if download {
+		return errors.Errorf("cannot download %s while rendering config", src)
+	}
+	e.files[dst] = src
+	return nil
+}
+
+// normalizeEOL makes the content end with exactly one newline, as the output
+// of SSH commands is collected line by line
+func normalizeEOL(s string) string {
+	return strings.TrimRight(s, "\n") + "\n"
+}
+
+// CheckConfigDrift renders the config files of the instance the same way as
+// InitConfig does and compares them with the files on the remote host, only
+// the files differ are returned
+func CheckConfigDrift(
+	getter ExecutorGetter,
+	clusterName string,
+	metadata *meta.ClusterMeta,
+	ins meta.Instance,
+) ([]ConfigDrift, error) {
+	// render to a temporary dir to keep the config cache untouched
+	cacheDir, err := ioutil.TempDir("", "tiup-cluster-check-config-")
+	if err != nil {
+		return nil, errors.AddStack(err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	paths := instanceDirPaths(clusterName, metadata.User, ins)
+	paths.Cache = cacheDir
+	render := &renderExecutor{files: map[string]string{}}
+	if err := ins.InitConfig(render, clusterName, metadata.RoleVersion(ins.ComponentName()), metadata.User, paths); err != nil {
+		return nil, errors.Annotatef(err, "failed to render config of %s", ins.ID())
+	}
+
+	remotePaths := make([]string, 0, len(render.files))
+	for dst := range render.files {
+		remotePaths = append(remotePaths, dst)
+	}
+	sort.Strings(remotePaths)
+
+	e := getter.Get(ins.GetHost())
+	var drifts []ConfigDrift
+	for _, dst := range remotePaths {
+		expected, err := ioutil.ReadFile(render.files[dst])
+		if err != nil {
+			return nil, errors.AddStack(err)
+		}
+
+		drift := ConfigDrift{Instance: ins, Path: dst}
+		actual := ""
+		stdout, _, err := e.Execute(fmt.Sprintf("test -f %[1]s && cat %[1]s", dst), true)
+		if err != nil {
+			drift.Missing = true
+		} else {
+			actual = normalizeEOL(string(stdout))
+		}
+
+		remote := fmt.Sprintf("%s:%s", ins.GetHost(), dst)
+		drift.Diff = edit.UnifiedDiff(
+			actual,
+			normalizeEOL(string(expected)),
+			remote,
+			remote+" (expected)",
+		)
+		if drift.Missing || drift.Diff != "" {
+			drifts = append(drifts, drift)
+		}
+	}
+	return drifts, nil
+}
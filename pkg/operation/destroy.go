@@ -127,8 +127,8 @@ func DestroyMonitored(getter ExecutorGetter, inst meta.Instance, options meta.Mo
 			options.DeployDir, inst.InstanceName())
 	}
 
-	delPaths = append(delPaths, fmt.Sprintf("/etc/systemd/system/%s-%d.service", meta.ComponentNodeExporter, options.NodeExporterPort))
-	delPaths = append(delPaths, fmt.Sprintf("/etc/systemd/system/%s-%d.service", meta.ComponentBlackboxExporter, options.BlackboxExporterPort))
+	delPaths = append(delPaths, module.ServiceFiles(fmt.Sprintf("%s-%d.service", meta.ComponentNodeExporter, options.NodeExporterPort))...)
+	delPaths = append(delPaths, module.ServiceFiles(fmt.Sprintf("%s-%d.service", meta.ComponentBlackboxExporter, options.BlackboxExporterPort))...)
 
 	c := module.ShellModuleConfig{
 		Command:  fmt.Sprintf("rm -rf %s;", strings.Join(delPaths, " ")),
@@ -199,7 +199,8 @@ func DestroyComponent(getter ExecutorGetter, instances []meta.Instance) error {
 			log.Warnf("Deploy dir %s not deleted for TiDB-Ansible imported instance %s.",
 				ins.DeployDir(), ins.InstanceName())
 		}
-		delPaths = append(delPaths, fmt.Sprintf("/etc/systemd/system/%s", ins.ServiceName()))
+		delPaths = append(delPaths, module.ServiceFiles(ins.ServiceName())...)
+		delPaths = append(delPaths, meta.LogrotateFile(ins.ServiceName()))
 		log.Debugf("Deleting paths on %s: %s", ins.GetHost(), strings.Join(delPaths, " "))
 		c := module.ShellModuleConfig{
 			Command:  fmt.Sprintf("rm -rf %s;", strings.Join(delPaths, " ")),
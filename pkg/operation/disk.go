@@ -0,0 +1,55 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
+	"github.com/pingcap/errors"
+)
+
+// DiskUsage is the space of the filesystem a directory is on
+type DiskUsage struct {
+	Free  uint64 // bytes available to unprivileged users
+	Total uint64 // bytes
+}
+
+// GetDiskUsage reads the space of the filesystem dir is on with df
+func GetDiskUsage(e executor.TiOpsExecutor, dir string) (DiskUsage, error) {
+	// -P keeps the output of a filesystem in one line
+	cmd := fmt.Sprintf("df -P -k %s", dir)
+	stdout, stderr, err := e.Execute(cmd, false)
+	if err != nil {
+		return DiskUsage{}, errors.Annotatef(err, "failed to get disk usage of %s: %s", dir, stderr)
+	}
+
+	// Filesystem 1024-blocks Used Available Capacity Mounted on
+	lines := strings.Split(strings.TrimSpace(string(stdout)), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(lines) < 2 || len(fields) < 4 {
+		return DiskUsage{}, errors.Errorf("unexpected output of df: %s", string(stdout))
+	}
+	total, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return DiskUsage{}, errors.Annotatef(err, "unexpected output of df: %s", string(stdout))
+	}
+	free, err := strconv.ParseUint(fields[3], 10, 64)
+	if err != nil {
+		return DiskUsage{}, errors.Annotatef(err, "unexpected output of df: %s", string(stdout))
+	}
+	return DiskUsage{Free: free * 1024, Total: total * 1024}, nil
+}
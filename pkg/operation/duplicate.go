@@ -0,0 +1,150 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/clusterutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	tiuputils "github.com/pingcap-incubator/tiup/pkg/utils"
+	"github.com/pingcap/errors"
+)
+
+// DeploymentConflict is a port or directory used by instances of two clusters
+// on the same host
+type DeploymentConflict struct {
+	Host         string
+	Kind         string // port, deploy directory, data directory ...
+	Value        string // the conflicting port or directory
+	Instance     string // the instance of this cluster
+	ExistCluster string
+	ExistInst    string // the instance of the other cluster
+}
+
+// String implements the fmt.Stringer interface
+func (c DeploymentConflict) String() string {
+	return fmt.Sprintf("%s %s on %s is used by both %s and %s of cluster %s",
+		c.Kind, c.Value, c.Host, c.Instance, c.ExistInst, c.ExistCluster)
+}
+
+// deploymentEntry is a port or directory used by an instance
+type deploymentEntry struct {
+	cluster  string
+	host     string
+	kind     string
+	value    string
+	instance string
+}
+
+// deploymentEntries lists all ports and directories used by the topology
+func deploymentEntries(clusterName string, topo *meta.ClusterSpecification) []deploymentEntry {
+	var entries []deploymentEntry
+	abs := func(dir string) string {
+		if dir == "" {
+			return ""
+		}
+		return clusterutil.Abs(topo.GlobalOptions.User, dir)
+	}
+
+	topo.IterInstance(func(inst meta.Instance) {
+		add := func(kind, value string) {
+			if value == "" {
+				return
+			}
+			entries = append(entries, deploymentEntry{clusterName, inst.GetHost(), kind, value, inst.ID()})
+		}
+		for _, port := range inst.UsedPorts() {
+			add("port", strconv.Itoa(port))
+		}
+		add("deploy directory", abs(inst.DeployDir()))
+		add("data directory", abs(inst.DataDir()))
+		add("log directory", abs(inst.LogDir()))
+	})
+	monitored := topo.MonitoredOptions
+	topo.IterHost(func(inst meta.Instance) {
+		for kind, dir := range map[string]string{
+			"monitor deploy directory": monitored.DeployDir,
+			"monitor data directory":   monitored.DataDir,
+			"monitor log directory":    monitored.LogDir,
+		} {
+			if dir = abs(dir); dir != "" {
+				entries = append(entries, deploymentEntry{clusterName, inst.GetHost(), kind, dir, inst.GetHost()})
+			}
+		}
+	})
+	return entries
+}
+
+// FindDuplicateDeployment reports all ports and directories of topo which
+// are also used by other registered clusters on the same host
+func FindDuplicateDeployment(clusterName string, topo *meta.ClusterSpecification) ([]DeploymentConflict, error) {
+	fileInfos, err := ioutil.ReadDir(meta.ProfilePath(meta.TiOpsClusterDir))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.AddStack(err)
+	}
+
+	existing := make(map[string]deploymentEntry)
+	for _, fi := range fileInfos {
+		if fi.Name() == clusterName || tiuputils.IsNotExist(meta.ClusterPath(fi.Name(), meta.MetaFileName)) {
+			continue
+		}
+		metadata, err := meta.ClusterMetadata(fi.Name())
+		if err != nil {
+			log.Warnf("Skip checking cluster %s as its meta can't be read: %s", fi.Name(), err)
+			continue
+		}
+		for _, e := range deploymentEntries(fi.Name(), metadata.Topology) {
+			// directories of different kinds conflict as well
+			key := e.host + "|" + e.value
+			if e.kind == "port" {
+				key = e.host + "|port|" + e.value
+			}
+			existing[key] = e
+		}
+	}
+
+	var conflicts []DeploymentConflict
+	for _, e := range deploymentEntries(clusterName, topo) {
+		key := e.host + "|" + e.value
+		if e.kind == "port" {
+			key = e.host + "|port|" + e.value
+		}
+		if exist, ok := existing[key]; ok {
+			conflicts = append(conflicts, DeploymentConflict{
+				Host:         e.host,
+				Kind:         e.kind,
+				Value:        e.value,
+				Instance:     e.instance,
+				ExistCluster: exist.cluster,
+				ExistInst:    exist.instance,
+			})
+		}
+	}
+	return conflicts, nil
+}
+
+// CheckDuplicateDeployment reports all ports and directories of the cluster
+// which are also used by other registered clusters on the same host
+func CheckDuplicateDeployment(clusterName string) ([]DeploymentConflict, error) {
+	metadata, err := meta.ClusterMetadata(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return FindDuplicateDeployment(clusterName, metadata.Topology)
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/api"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
+	"github.com/pingcap-incubator/tiup/pkg/set"
+	"github.com/pingcap/errors"
+)
+
+// EvictStoreLeader adds the evict-leader-scheduler of the TiKV store through
+// PD and waits until no region leader is left on it, timeout is in seconds
+// and the default of PD client is used if it's not positive.
+func EvictStoreLeader(pdList []string, ins meta.Instance, timeout int64) error {
+	var retryOpt *utils.RetryOption
+	if timeout > 0 {
+		retryOpt = &utils.RetryOption{
+			Timeout: time.Second * time.Duration(timeout),
+			Delay:   time.Second * 2,
+		}
+	}
+
+	pdClient := api.NewPDClient(pdList, 5*time.Second, nil)
+	if err := pdClient.EvictStoreLeader(addr(ins), retryOpt); err != nil {
+		return errors.Annotatef(err, "failed to evict store leader of %s", ins.ID())
+	}
+	return nil
+}
+
+// TransferPDLeader transfers the PD leader to a healthy member which is not
+// going to be stopped if the leader is one of the stopping instances, and
+// waits until the new leader is elected, timeout is in seconds.
+func TransferPDLeader(pdList []string, stopping []meta.Instance, timeout int64, tlsCfg *tls.Config) error {
+	pdClient := api.NewPDClient(pdList, 5*time.Second, tlsCfg)
+	leader, err := pdClient.GetLeader()
+	if err != nil {
+		return errors.Annotate(err, "failed to get PD leader")
+	}
+
+	names := set.NewStringSet()
+	for _, ins := range stopping {
+		names.Insert(ins.(*meta.PDInstance).Name)
+	}
+	if !names.Exist(leader.Name) {
+		return nil
+	}
+
+	healths, err := pdClient.GetHealth()
+	if err != nil {
+		return errors.Annotate(err, "failed to get the health of PD members")
+	}
+	target := ""
+	for _, h := range healths.Healths {
+		if h.Health && !names.Exist(h.Name) {
+			target = h.Name
+			break
+		}
+	}
+	if target == "" {
+		return errors.Errorf("no other healthy PD member to transfer the leader %s to", leader.Name)
+	}
+
+	var retryOpt *utils.RetryOption
+	if timeout > 0 {
+		retryOpt = &utils.RetryOption{
+			Timeout: time.Second * time.Duration(timeout),
+			Delay:   time.Second * 2,
+		}
+	}
+	log.Infof("\tTransferring PD leader from %s to %s", leader.Name, target)
+	if err := pdClient.TransferPDLeader(target, retryOpt); err != nil {
+		return errors.Annotatef(err, "failed to transfer PD leader %s", leader.Name)
+	}
+	return nil
+}
+
+// RemoveEvictLeaderScheduler removes the evict-leader-scheduler added by
+// EvictStoreLeader, so that the store can have leaders again
+func RemoveEvictLeaderScheduler(pdList []string, ins meta.Instance) error {
+	pdClient := api.NewPDClient(pdList, 5*time.Second, nil)
+	if err := pdClient.RemoveStoreEvict(addr(ins)); err != nil {
+		return errors.Annotatef(err, "failed to remove evict leader scheduler of %s", ins.ID())
+	}
+	return nil
+}
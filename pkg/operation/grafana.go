@@ -0,0 +1,103 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/api"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap/errors"
+)
+
+func grafanaClient(spec meta.GrafanaSpec) *api.GrafanaClient {
+	user, password := spec.Username, spec.Password
+	if user == "" {
+		user, password = "admin", "admin"
+	}
+	return api.NewGrafanaClient(fmt.Sprintf("%s:%d", spec.Host, spec.Port), user, password, 10*time.Second)
+}
+
+// ExportGrafanaDashboards exports all dashboards of the Grafana server in the
+// cluster to localDir, each dashboard is saved as <uid>.json
+func ExportGrafanaDashboards(topo *meta.ClusterSpecification, localDir string) error {
+	if len(topo.Grafana) == 0 {
+		return errors.New("no Grafana server found in the cluster")
+	}
+	if err := os.MkdirAll(localDir, 0755); err != nil {
+		return errors.AddStack(err)
+	}
+
+	// dashboards are provisioned identically, so exporting from one is enough
+	spec := topo.Grafana[0]
+	client := grafanaClient(spec)
+	refs, err := client.SearchDashboards()
+	if err != nil {
+		return errors.Annotatef(err, "failed to list dashboards of %s:%d", spec.Host, spec.Port)
+	}
+
+	for _, ref := range refs {
+		model, err := client.GetDashboard(ref.UID)
+		if err != nil {
+			return errors.Annotatef(err, "failed to export dashboard %s", ref.Title)
+		}
+		fp := filepath.Join(localDir, ref.UID+".json")
+		if err := ioutil.WriteFile(fp, model, 0644); err != nil {
+			return errors.AddStack(err)
+		}
+		log.Infof("\tExported dashboard %s to %s", ref.Title, fp)
+	}
+
+	log.Infof("Exported %d dashboards from %s:%d", len(refs), spec.Host, spec.Port)
+	return nil
+}
+
+// ImportGrafanaDashboards imports the dashboards exported by
+// ExportGrafanaDashboards into all Grafana servers in the cluster, existing
+// dashboards with the same uid are overwritten
+func ImportGrafanaDashboards(topo *meta.ClusterSpecification, localDir string) error {
+	if len(topo.Grafana) == 0 {
+		return errors.New("no Grafana server found in the cluster")
+	}
+
+	files, err := ioutil.ReadDir(localDir)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	for _, spec := range topo.Grafana {
+		client := grafanaClient(spec)
+		for _, f := range files {
+			if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+				continue
+			}
+			model, err := ioutil.ReadFile(filepath.Join(localDir, f.Name()))
+			if err != nil {
+				return errors.AddStack(err)
+			}
+			if err := client.ImportDashboard(model); err != nil {
+				return errors.Annotatef(err, "failed to import %s to %s:%d", f.Name(), spec.Host, spec.Port)
+			}
+		}
+		log.Infof("Imported dashboards to %s:%d", spec.Host, spec.Port)
+	}
+
+	return nil
+}
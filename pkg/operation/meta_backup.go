@@ -0,0 +1,228 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap/errors"
+)
+
+// BackupMeta snapshots the whole meta dir of the cluster, including the meta
+// file, SSH keys and certificates, to the tar.gz file dest
+func BackupMeta(clusterName, dest string) (err error) {
+	src := meta.ClusterPath(clusterName)
+	if _, err := os.Stat(filepath.Join(src, meta.MetaFileName)); err != nil {
+		return errors.Annotatef(err, "failed to backup meta of cluster %s", clusterName)
+	}
+
+	f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = errors.AddStack(cerr)
+		}
+		if err != nil {
+			_ = os.Remove(dest)
+		}
+	}()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	err = filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		r, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		_, err = io.Copy(tw, r)
+		return err
+	})
+	if err != nil {
+		return errors.Annotatef(err, "failed to backup meta of cluster %s", clusterName)
+	}
+	if err := tw.Close(); err != nil {
+		return errors.AddStack(err)
+	}
+	if err := gw.Close(); err != nil {
+		return errors.AddStack(err)
+	}
+
+	log.Infof("Backed up meta of cluster %s to %s", clusterName, dest)
+	return nil
+}
+
+// RestoreMeta replaces the meta dir of the cluster with the snapshot created
+// by BackupMeta. The snapshot is extracted and validated before the live meta
+// dir is touched, the live one is kept if anything goes wrong.
+func RestoreMeta(clusterName, src string) error {
+	live := meta.ClusterPath(clusterName)
+	if err := os.MkdirAll(filepath.Dir(live), 0755); err != nil {
+		return errors.AddStack(err)
+	}
+
+	tmp, err := ioutil.TempDir(filepath.Dir(live), clusterName+".restore-")
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := extractMetaSnapshot(src, tmp); err != nil {
+		return errors.Annotatef(err, "failed to extract %s", src)
+	}
+	if err := validateMetaSnapshot(tmp); err != nil {
+		return errors.Annotatef(err, "invalid meta snapshot %s", src)
+	}
+
+	// move the live meta aside so that it can be put back on failure
+	old := fmt.Sprintf("%s.old-%d", live, time.Now().Unix())
+	hasLive := true
+	if err := os.Rename(live, old); err != nil {
+		if !os.IsNotExist(err) {
+			return errors.AddStack(err)
+		}
+		hasLive = false
+	}
+	if err := os.Rename(tmp, live); err != nil {
+		if hasLive {
+			_ = os.Rename(old, live)
+		}
+		return errors.AddStack(err)
+	}
+	if hasLive {
+		if err := os.RemoveAll(old); err != nil {
+			log.Warnf("Failed to remove the replaced meta dir %s: %s", old, err)
+		}
+	}
+
+	log.Infof("Restored meta of cluster %s from %s", clusterName, src)
+	return nil
+}
+
+// extractMetaSnapshot extracts the tar.gz file src into dir
+func extractMetaSnapshot(src, dir string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// reject the entries pointing out of dir
+		name := filepath.Clean(filepath.FromSlash(hdr.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return errors.Errorf("illegal path %s in snapshot", hdr.Name)
+		}
+		target := filepath.Join(dir, name)
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, os.FileMode(hdr.Mode).Perm()); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			w, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode).Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(w, tr)
+			if cerr := w.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return err
+			}
+		default:
+			log.Debugf("Skip %s of type %c in meta snapshot", hdr.Name, hdr.Typeflag)
+		}
+	}
+}
+
+// validateMetaSnapshot checks the extracted snapshot contains a valid meta
+// file and the SSH keys
+func validateMetaSnapshot(dir string) error {
+	data, err := ioutil.ReadFile(filepath.Join(dir, meta.MetaFileName))
+	if err != nil {
+		return err
+	}
+
+	// the meta of an older schema is migrated once loaded
+	cm, _, err := meta.ParseClusterMeta(data, meta.MetaFileName)
+	if err != nil {
+		return err
+	}
+	if cm.Topology == nil {
+		return errors.New("no topology in the meta file")
+	}
+	if err := cm.Topology.Validate(); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"id_rsa", "id_rsa.pub"} {
+		if _, err := os.Stat(filepath.Join(dir, "ssh", key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap/errors"
+)
+
+// verifyCopy compares the content of the files in src and dst by checksums,
+// the dry run of rsync lists every file which differs or is missing in dst,
+// and the extra files in dst with --delete, so no output means identical
+func verifyCopy(e executor.TiOpsExecutor, src, dst string) error {
+	cmd := fmt.Sprintf("rsync -a --checksum --delete --dry-run --itemize-changes %s/ %s/", src, dst)
+	stdout, stderr, err := e.Execute(cmd, true)
+	if err != nil {
+		return errors.Annotatef(err, "failed to verify the copy of %s: %s", src, stderr)
+	}
+	if diff := strings.TrimSpace(string(stdout)); diff != "" {
+		lines := strings.Split(diff, "\n")
+		if len(lines) > 10 {
+			lines = append(lines[:10], fmt.Sprintf("... and %d more", len(lines)-10))
+		}
+		return errors.Errorf("%s differs from %s after copy:\n%s", dst, src, strings.Join(lines, "\n"))
+	}
+	return nil
+}
+
+// isSubDir returns if dir is the same as or inside parent, both are cleaned
+// absolute paths
+func isSubDir(dir, parent string) bool {
+	return dir == parent || strings.HasPrefix(dir, strings.TrimSuffix(parent, "/")+"/")
+}
+
+// MigrateDataDir moves the data of an instance to newDir, e.g. when the disk
+// is failing. The instance is stopped, its data copied with rsync and the
+// copy verified by checksums, then the config is refreshed and the instance
+// is started and waited to be ready. The old data dir is never removed, it's
+// left for the user to remove after checking the instance works with the new
+// one. The topology in metadata is updated in place, and it's up to the
+// caller to save it.
+func MigrateDataDir(
+	getter ExecutorGetter,
+	clusterName string,
+	metadata *meta.ClusterMeta,
+	ins meta.Instance,
+	newDir string,
+) error {
+	if ins.DataDir() == "" {
+		return errors.Errorf("instance %s has no data dir", ins.ID())
+	}
+	if !filepath.IsAbs(newDir) {
+		return errors.Errorf("the new data dir %s must be an absolute path", newDir)
+	}
+	newDir = filepath.Clean(newDir)
+
+	topo := metadata.Topology
+	version := metadata.RoleVersion(ins.ComponentName())
+	oldPaths := instanceDirPaths(clusterName, metadata.User, ins)
+	oldDir := filepath.Clean(oldPaths.Data)
+	if oldDir == newDir {
+		return errors.Errorf("instance %s is already using data dir %s", ins.ID(), newDir)
+	}
+	// rsync would copy the dir into itself, and the data would be mixed up
+	if isSubDir(newDir, oldDir) || isSubDir(oldDir, newDir) {
+		return errors.Errorf("the new data dir %s must not be inside the current data dir %s of %s or contain it",
+			newDir, oldDir, ins.ID())
+	}
+	e := getter.Get(ins.GetHost())
+
+	log.Infof("Migrating data dir of %s from %s to %s", ins.ID(), oldPaths.Data, newDir)
+	if err := stopInstance(getter, ins); err != nil {
+		return errors.Annotatef(err, "failed to stop %s", ins.ID())
+	}
+
+	// restore starts the instance with the old data dir if the migration fails
+	restore := func(cause error) error {
+		log.Errorf("Failed to migrate data dir of %s, restoring: %s", ins.ID(), cause)
+		_, _ = topo.SetInstanceDataDir(ins, oldPaths.Data)
+		if err := ins.InitConfig(e, clusterName, version, metadata.User, oldPaths); err != nil {
+			log.Errorf("Failed to restore config of %s: %s", ins.ID(), err)
+		} else if err := startInstance(getter, ins); err != nil {
+			log.Errorf("Failed to restart %s: %s", ins.ID(), err)
+		}
+		return cause
+	}
+
+	cmds := []string{
+		fmt.Sprintf("mkdir -p %s", newDir),
+		fmt.Sprintf("chown %s:%s %s", metadata.User, metadata.User, newDir),
+		fmt.Sprintf("rsync -a %s/ %s/", oldDir, newDir),
+	}
+	for _, cmd := range cmds {
+		if _, stderr, err := e.Execute(cmd, true); err != nil {
+			return restore(errors.Annotatef(err, "failed to run `%s`: %s", cmd, stderr))
+		}
+	}
+
+	// verify the copy before touching anything else
+	if err := verifyCopy(e, oldDir, newDir); err != nil {
+		return restore(err)
+	}
+
+	if _, err := topo.SetInstanceDataDir(ins, newDir); err != nil {
+		return restore(err)
+	}
+	migrated := rebuildInstance(topo, ins)
+	if err := migrated.InitConfig(e, clusterName, version, metadata.User,
+		instanceDirPaths(clusterName, metadata.User, migrated)); err != nil {
+		return restore(errors.Annotatef(err, "failed to init config of %s", ins.ID()))
+	}
+	if err := startInstance(getter, migrated); err != nil {
+		if err := stopInstance(getter, migrated); err != nil {
+			log.Errorf("Failed to stop %s: %s", ins.ID(), err)
+		}
+		return restore(errors.Annotatef(err, "failed to start %s", ins.ID()))
+	}
+
+	log.Infof("Migrate data dir of %s success", ins.ID())
+	log.Warnf("The old data dir %s on %s is kept, remove it after checking %s works with the new one",
+		oldDir, ins.GetHost(), ins.ID())
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
+	"github.com/pingcap/errors"
+)
+
+// parseNodeList parses a list of NUMA nodes in the format of numactl and
+// /sys/devices/system/node/online, e.g. 0,2-3
+func parseNodeList(list string) ([]int, error) {
+	var nodes []int
+	for _, part := range strings.Split(strings.TrimSpace(list), ",") {
+		bounds := strings.SplitN(part, "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, errors.Errorf("invalid NUMA node list %q", list)
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.Atoi(bounds[1]); err != nil || last < first {
+				return nil, errors.Errorf("invalid NUMA node list %q", list)
+			}
+		}
+		for n := first; n <= last; n++ {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes, nil
+}
+
+// CheckNumaNodes checks the NUMA nodes the instances on the host are bound to
+// exist and are online, otherwise numactl refuses to start them
+func CheckNumaNodes(e executor.TiOpsExecutor, numaNodes []string) *CheckResult {
+	result := &CheckResult{
+		Name: CheckNameNumaNode,
+	}
+	stdout, stderr, err := e.Execute("cat /sys/devices/system/node/online", false)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to get the online NUMA nodes, %s", strings.Trim(string(stderr), "\n"))
+		return result
+	}
+	online, err := parseNodeList(string(stdout))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	available := make(map[int]struct{}, len(online))
+	for _, n := range online {
+		available[n] = struct{}{}
+	}
+
+	for _, numaNode := range numaNodes {
+		nodes, err := parseNodeList(numaNode)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		for _, n := range nodes {
+			if _, ok := available[n]; !ok {
+				result.Err = fmt.Errorf("NUMA node %d of numa_node %s is not online, the online nodes are %s",
+					n, numaNode, strings.TrimSpace(string(stdout)))
+				return result
+			}
+		}
+	}
+	result.Msg = fmt.Sprintf("NUMA nodes %s are online", strings.Join(numaNodes, ", "))
+	return result
+}
@@ -14,19 +14,46 @@
 package operator
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net/url"
 
 	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	"github.com/pingcap-incubator/tiup/pkg/set"
+	"github.com/pingcap/errors"
 )
 
+// ErrPDUnreachable is returned when none of the PD servers can be connected,
+// it can be matched with errors.Is
+var ErrPDUnreachable = errors.New("PD unreachable")
+
+// wrapPDError marks the error returned by PD API as ErrPDUnreachable if it
+// is caused by connection failures
+func wrapPDError(err error) error {
+	if _, ok := errors.Cause(err).(*url.Error); ok {
+		return fmt.Errorf("%w: %v", ErrPDUnreachable, err)
+	}
+	return errors.AddStack(err)
+}
+
 // Options represents the operation options
 type Options struct {
 	Roles   []string
 	Nodes   []string
-	Force   bool  // Option for upgrade subcommand
+	Force   bool  // Option for upgrade subcommand, and to stop the PD leader without transferring it
 	Timeout int64 // timeout in seconds for operations that support it, not to confuse with SSH timeout
+
+	// the hosts of the nodes are down, scale-in skips the steps on the hosts
+	HostDown bool
+	// stop TiKV instances without evicting their region leaders first
+	SkipEvictLeader bool
+	// the TLS config to talk to PD during rolling restart, nil if TLS is
+	// not enabled for the cluster
+	TLSConfig *tls.Config
+	// the max number of instances to push the config to at the same time,
+	// 0 means no limit
+	Concurrency int
 }
 
 // Operation represents the type of cluster operation
@@ -42,6 +69,9 @@ const (
 	ScaleInOperation
 	ScaleOutOperation
 	DestroyTombstoneOperation
+	RollbackUpgradeOperation
+	RollingRestartOperation
+	ReloadOperation
 )
 
 var opStringify = [...]string{
@@ -53,10 +83,13 @@ var opStringify = [...]string{
 	"ScaleInOperation",
 	"ScaleOutOperation",
 	"DestroyTombstoneOperation",
+	"RollbackUpgradeOperation",
+	"RollingRestartOperation",
+	"ReloadOperation",
 }
 
 func (op Operation) String() string {
-	if op <= DestroyTombstoneOperation {
+	if int(op) < len(opStringify) {
 		return opStringify[op]
 	}
 	return fmt.Sprintf("unknonw-op(%d)", op)
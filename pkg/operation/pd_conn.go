@@ -0,0 +1,109 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap-incubator/tiup/pkg/set"
+	"github.com/pingcap/errors"
+)
+
+// PDConnections is the client connections of a PD instance
+type PDConnections struct {
+	ID     string         // the ID of the PD instance
+	Total  int            // the number of established connections
+	ByRole map[string]int // the number of connections by the role of clients
+	Err    error
+}
+
+// GetPDConnections counts the established connections to the client port of
+// each PD instance, the clients are classified by the roles deployed on their
+// hosts. Clients from hosts out of the cluster are counted as "other", and
+// hosts with multiple roles are counted as the roles joined by "/".
+func GetPDConnections(getter ExecutorGetter, spec *meta.ClusterSpecification) []PDConnections {
+	// the roles deployed on each host
+	hostRoles := map[string]set.StringSet{}
+	spec.IterInstance(func(inst meta.Instance) {
+		if _, ok := hostRoles[inst.GetHost()]; !ok {
+			hostRoles[inst.GetHost()] = set.NewStringSet()
+		}
+		hostRoles[inst.GetHost()].Insert(inst.Role())
+	})
+	roleOf := func(host string) string {
+		roles, ok := hostRoles[host]
+		if !ok {
+			// the address may be resolved differently from the topology
+			if ips, err := net.LookupHost(host); err == nil {
+				for h, r := range hostRoles {
+					for _, ip := range ips {
+						if h == ip {
+							roles, ok = r, true
+						}
+					}
+				}
+			}
+		}
+		if !ok {
+			return "other"
+		}
+		var s []string
+		for r := range roles {
+			s = append(s, r)
+		}
+		sort.Strings(s)
+		return strings.Join(s, "/")
+	}
+
+	var result []PDConnections
+	for _, ins := range (&meta.PDComponent{ClusterSpecification: spec}).Instances() {
+		conns := PDConnections{ID: ins.ID(), ByRole: map[string]int{}}
+		peers, err := establishedPeers(getter, ins.GetHost(), ins.GetPort())
+		if err != nil {
+			conns.Err = err
+		}
+		for _, peer := range peers {
+			conns.Total++
+			conns.ByRole[roleOf(peer)]++
+		}
+		result = append(result, conns)
+	}
+	return result
+}
+
+// establishedPeers lists the peer hosts of established TCP connections to the
+// local port on host
+func establishedPeers(getter ExecutorGetter, host string, port int) ([]string, error) {
+	// the output of ss with the state filter has no state column:
+	// Recv-Q Send-Q Local Address:Port Peer Address:Port
+	cmd := fmt.Sprintf(`ss -tn state established '( sport = :%d )' | tail -n +2 | awk '{print $4}'`, port)
+	stdout, stderr, err := getter.Get(host).Execute(cmd, false)
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to list connections on %s: %s", host, stderr)
+	}
+
+	var peers []string
+	for _, addr := range strings.Fields(string(stdout)) {
+		h, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			continue
+		}
+		peers = append(peers, strings.TrimPrefix(h, "::ffff:"))
+	}
+	return peers, nil
+}
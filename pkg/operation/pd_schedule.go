@@ -0,0 +1,79 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/api"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap/errors"
+)
+
+// ParseScheduleConfig parses the items in format of key=value to the
+// scheduling config of PD, numbers and booleans are kept as is
+func ParseScheduleConfig(items []string) (map[string]interface{}, error) {
+	config := make(map[string]interface{})
+	for _, item := range items {
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errors.Errorf("invalid schedule config %s, must be key=value", item)
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			config[key] = n
+		} else if b, err := strconv.ParseBool(value); err == nil {
+			config[key] = b
+		} else {
+			config[key] = value
+		}
+	}
+	return config, nil
+}
+
+// SetScheduleConfig updates the scheduling config of PD, e.g. raising the
+// region-schedule-limit during off-peak hours. Only existing config items
+// can be set, and the values before and after are logged.
+func SetScheduleConfig(spec *meta.ClusterSpecification, config map[string]interface{}) error {
+	if len(config) == 0 {
+		return errors.New("no schedule config to set")
+	}
+
+	pdClient := api.NewPDClient(spec.GetPDList(), 10*time.Second, nil)
+	current, err := pdClient.GetScheduleConfig()
+	if err != nil {
+		return wrapPDError(err)
+	}
+
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		if _, ok := current[key]; !ok {
+			return errors.Errorf("unknown schedule config %s", key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		log.Infof("Setting schedule config %s: %v -> %v", key, current[key], config[key])
+	}
+
+	if err := pdClient.UpdateScheduleConfig(config); err != nil {
+		return wrapPDError(err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap/errors"
+)
+
+const (
+	// a host is considered slow if its latency is slowLatencyFactor times
+	// of the median and not less than minSlowLatency
+	slowLatencyFactor = 3
+	minSlowLatency    = 100 * time.Millisecond
+)
+
+// PingResult is the SSH round-trip latency of a host
+type PingResult struct {
+	Host    string
+	Latency time.Duration
+	Slow    bool // the latency is unusually high compared to other hosts
+	Err     error
+}
+
+// Ping runs a trivial command on every host of the cluster and measures the
+// round-trip latency, the results are sorted by host
+func Ping(getter ExecutorGetter, spec meta.Specification) []PingResult {
+	var hosts []string
+	spec.IterHost(func(inst meta.Instance) {
+		hosts = append(hosts, inst.GetHost())
+	})
+
+	results := make([]PingResult, len(hosts))
+	wg := sync.WaitGroup{}
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+			results[i] = PingResult{Host: host}
+			start := time.Now()
+			_, _, err := getter.Get(host).Execute("true", false)
+			results[i].Latency = time.Since(start)
+			if err != nil {
+				results[i].Err = errors.Annotatef(err, "failed to ping %s", host)
+			}
+		}(i, host)
+	}
+	wg.Wait()
+
+	var latencies []time.Duration
+	for _, r := range results {
+		if r.Err == nil {
+			latencies = append(latencies, r.Latency)
+		}
+	}
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		threshold := latencies[len(latencies)/2] * slowLatencyFactor
+		if threshold < minSlowLatency {
+			threshold = minSlowLatency
+		}
+		for i := range results {
+			results[i].Slow = results[i].Err == nil && results[i].Latency > threshold
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Host < results[j].Host })
+	return results
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"strings"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap-incubator/tiup/pkg/set"
+	"github.com/pingcap/errors"
+)
+
+// reloadableComponents are the components reloading the config file on
+// SIGHUP. TiDB and PD are not among them: SIGHUP makes TiDB exit gracefully,
+// and PD only changes its config online via the API item by item.
+var reloadableComponents = set.NewStringSet(
+	meta.ComponentPrometheus,
+	meta.ComponentAlertManager,
+)
+
+// ReloadSupported returns if the instances of the component can apply the
+// new config without being restarted
+func ReloadSupported(comp string) bool {
+	return reloadableComponents.Exist(comp)
+}
+
+// Reload signals the instances selected by the options to reload the config,
+// the ones not supporting it are skipped
+func Reload(
+	getter ExecutorGetter,
+	spec meta.Specification,
+	options Options,
+) error {
+	roleFilter := set.NewStringSet(options.Roles...)
+	nodeFilter := set.NewStringSet(options.Nodes...)
+	for _, comp := range FilterComponent(spec.ComponentsByStartOrder(), roleFilter) {
+		if !ReloadSupported(comp.Name()) {
+			continue
+		}
+		for _, ins := range FilterInstance(comp.Instances(), nodeFilter) {
+			if err := reloadInstance(getter, ins); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// reloadInstance sends SIGHUP to the main process of the service through the
+// service manager of the host, the run scripts exec the binaries so that it's
+// the component itself
+func reloadInstance(getter ExecutorGetter, ins meta.Instance) error {
+	e := getter.Get(ins.GetHost())
+	log.Infof("\tReloading instance %s", ins.ID())
+
+	mgr, err := meta.InstanceServiceManager(e, ins)
+	if err != nil {
+		return err
+	}
+	_, stderr, err := mgr.Reload(e, ins.ServiceName())
+	if err != nil {
+		return errors.Annotatef(err, "failed to reload %s: %s", ins.ID(), strings.TrimSpace(string(stderr)))
+	}
+
+	// a config the component refuses is only reported in its log, make
+	// sure it's still serving at least
+	if err := ins.Ready(e); err != nil {
+		return errors.Annotatef(err, "%s is not ready after reloading, please check the log of the instance", ins.ID())
+	}
+
+	log.Infof("\tReload %s success", ins.ID())
+	return nil
+}
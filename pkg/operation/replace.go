@@ -0,0 +1,65 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap/errors"
+)
+
+// ReplaceNode scales in all instances on oldHost, which is usually dead, and
+// returns the topology of their equivalents on newHost with the labels and
+// configs preserved, it's up to the caller to scale out the returned topology.
+// If oldHost is unreachable, the instances are only removed from PD and
+// binlog, the steps on the host itself are skipped. The IDs of the instances
+// to be removed from the meta are returned.
+func ReplaceNode(
+	getter ExecutorGetter,
+	spec *meta.ClusterSpecification,
+	oldHost, newHost string,
+	options Options,
+) (*meta.TopologySpecification, []string, error) {
+	if oldHost == newHost {
+		return nil, nil, errors.Errorf("the new host must be different from %s", oldHost)
+	}
+
+	newPart := spec.CopyHostInstances(oldHost, newHost)
+	var nodes []string
+	spec.IterInstance(func(ins meta.Instance) {
+		if ins.GetHost() == oldHost {
+			nodes = append(nodes, ins.ID())
+		}
+	})
+	if len(nodes) == 0 {
+		return nil, nil, errors.Errorf("no instance found on host %s", oldHost)
+	}
+
+	if _, _, err := getter.Get(oldHost).Execute("true", false); err != nil {
+		log.Warnf("Host %s is unreachable, skip stopping and destroying the instances on it: %s", oldHost, err)
+		options.HostDown = true
+	}
+
+	log.Infof("Replacing host %s with %s, scaling in %v", oldHost, newHost, nodes)
+	options.Nodes = nodes
+	options.Force = false
+	if err := ScaleInCluster(getter, spec, options); err != nil {
+		return nil, nil, errors.Annotatef(err, "failed to scale in instances on %s", oldHost)
+	}
+	if options.HostDown {
+		return newPart, nodes, nil
+	}
+	// the instances offline asynchronously are kept until they become tombstone
+	return newPart, AsyncNodes(spec, nodes, false), nil
+}
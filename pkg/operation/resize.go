@@ -0,0 +1,83 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"github.com/pingcap-incubator/tiup-cluster/pkg/clusterutil"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap/errors"
+)
+
+// rebuildInstance returns the instance built from the current topology, it
+// reflects the changes made to the topology after ins was built
+func rebuildInstance(topo *meta.ClusterSpecification, ins meta.Instance) meta.Instance {
+	var rebuilt meta.Instance
+	topo.IterInstance(func(inst meta.Instance) {
+		if inst.ComponentName() == ins.ComponentName() && inst.ID() == ins.ID() {
+			rebuilt = inst
+		}
+	})
+	return rebuilt
+}
+
+// instanceDirPaths returns the absolute paths of the instance directories
+func instanceDirPaths(clusterName, deployUser string, ins meta.Instance) meta.DirPaths {
+	return meta.DirPaths{
+		Deploy: clusterutil.Abs(deployUser, ins.DeployDir()),
+		Data:   clusterutil.Abs(deployUser, ins.DataDir()),
+		Log:    clusterutil.Abs(deployUser, ins.LogDir()),
+		Cache:  meta.ClusterPath(clusterName, "config"),
+	}
+}
+
+// ResizeInstance replaces the instance level config of a single instance,
+// refreshes its config files and restarts it. The restart waits for the
+// instance to be ready. The topology in metadata is updated in place, and
+// it's up to the caller to save it after a successful resize.
+func ResizeInstance(
+	getter ExecutorGetter,
+	clusterName string,
+	metadata *meta.ClusterMeta,
+	ins meta.Instance,
+	config map[string]interface{},
+) error {
+	topo := metadata.Topology
+	before, err := topo.SetInstanceConfig(ins, config)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	// rebuild the instance so that it picks up the new config
+	resized := rebuildInstance(topo, ins)
+
+	log.Infof("Resizing instance %s", ins.ID())
+	log.Infof("\tbefore: %v", before)
+	log.Infof("\tafter:  %v", config)
+
+	e := getter.Get(resized.GetHost())
+	paths := instanceDirPaths(clusterName, metadata.User, resized)
+	if err := resized.InitConfig(e, clusterName, metadata.RoleVersion(resized.ComponentName()), metadata.User, paths); err != nil {
+		// keep the topology consistent with what is deployed
+		_, _ = topo.SetInstanceConfig(ins, before)
+		return errors.Annotatef(err, "failed to init config of %s", ins.ID())
+	}
+
+	if err := RestartComponent(getter, []meta.Instance{resized}); err != nil {
+		return errors.Annotatef(err, "failed to restart %s", ins.ID())
+	}
+
+	log.Infof("Resize instance %s success", ins.ID())
+	return nil
+}
@@ -63,24 +63,10 @@ func AsyncNodes(spec *meta.ClusterSpecification, nodes []string, async bool) []s
 	return notAsyncNodes
 }
 
-// ScaleIn scales in the cluster
-func ScaleIn(
-	getter ExecutorGetter,
-	spec meta.Specification,
-	options Options,
-) error {
-	if clusterSpec := spec.GetClusterSpecification(); clusterSpec != nil {
-		return ScaleInCluster(getter, clusterSpec, options)
-	}
-	return nil
-}
-
-// ScaleInCluster scales in the cluster
-func ScaleInCluster(
-	getter ExecutorGetter,
-	spec *meta.ClusterSpecification,
-	options Options,
-) error {
+// ScaleInInstances returns the instances of the nodes to scale in, in the
+// component starting order. It fails if any node is not in the topology or
+// all PD or TiKV servers would be deleted.
+func ScaleInInstances(spec *meta.ClusterSpecification, nodes []string) ([]meta.Instance, error) {
 	// instances by uuid
 	instances := map[string]meta.Instance{}
 
@@ -91,26 +77,65 @@ func ScaleInCluster(
 		}
 	}
 
-	// Clean components
 	deletedDiff := map[string][]meta.Instance{}
-	deletedNodes := set.NewStringSet(options.Nodes...)
+	deletedNodes := set.NewStringSet(nodes...)
 	for nodeID := range deletedNodes {
 		inst, found := instances[nodeID]
 		if !found {
-			return errors.Errorf("cannot find node id '%s' in topology", nodeID)
+			return nil, errors.Errorf("cannot find node id '%s' in topology", nodeID)
 		}
 		deletedDiff[inst.ComponentName()] = append(deletedDiff[inst.ComponentName()], inst)
 	}
 
 	// Cannot delete all PD servers
 	if len(deletedDiff[meta.ComponentPD]) == len(spec.PDServers) {
-		return errors.New("cannot delete all PD servers")
+		return nil, errors.New("cannot delete all PD servers")
 	}
 
 	// Cannot delete all TiKV servers
 	if len(deletedDiff[meta.ComponentTiKV]) == len(spec.TiKVServers) {
-		return errors.New("cannot delete all TiKV servers")
+		return nil, errors.New("cannot delete all TiKV servers")
+	}
+
+	var deleted []meta.Instance
+	for _, component := range spec.ComponentsByStartOrder() {
+		for _, instance := range component.Instances() {
+			if deletedNodes.Exist(instance.ID()) {
+				deleted = append(deleted, instance)
+			}
+		}
 	}
+	return deleted, nil
+}
+
+// IsAsyncOffline returns if the instance of the component goes offline
+// asynchronously, i.e. it's removed once it becomes tombstone
+func IsAsyncOffline(comp string) bool {
+	return asyncOfflineComps.Exist(comp)
+}
+
+// ScaleIn scales in the cluster
+func ScaleIn(
+	getter ExecutorGetter,
+	spec meta.Specification,
+	options Options,
+) error {
+	if clusterSpec := spec.GetClusterSpecification(); clusterSpec != nil {
+		return ScaleInCluster(getter, clusterSpec, options)
+	}
+	return nil
+}
+
+// ScaleInCluster scales in the cluster
+func ScaleInCluster(
+	getter ExecutorGetter,
+	spec *meta.ClusterSpecification,
+	options Options,
+) error {
+	if _, err := ScaleInInstances(spec, options.Nodes); err != nil {
+		return err
+	}
+	deletedNodes := set.NewStringSet(options.Nodes...)
 
 	if options.Force {
 		for _, component := range spec.ComponentsByStartOrder() {
@@ -189,7 +214,9 @@ func ScaleInCluster(
 				}
 			}
 
-			if !asyncOfflineComps.Exist(instance.ComponentName()) {
+			if options.HostDown {
+				log.Warnf("Skip stopping and destroying %s as its host is down", instance.ID())
+			} else if !asyncOfflineComps.Exist(instance.ComponentName()) {
 				if err := StopComponent(getter, []meta.Instance{instance}); err != nil {
 					return errors.Annotatef(err, "failed to stop %s", component.Name())
 				}
@@ -0,0 +1,69 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/api"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap/errors"
+)
+
+// StoreStates are the states which can be set to a store manually
+var StoreStates = []string{"Up", "Offline", "Tombstone"}
+
+// NormalizeStoreState returns the one of StoreStates matching the state
+// case-insensitively, or an error if none matches
+func NormalizeStoreState(state string) (string, error) {
+	for _, s := range StoreStates {
+		if strings.EqualFold(s, state) {
+			return s, nil
+		}
+	}
+	return "", errors.Errorf("invalid store state %s, must be one of %s", state, strings.Join(StoreStates, ","))
+}
+
+// SetStoreState sets the state of the TiKV or TiFlash store manually through
+// PD, it's meant for emergency recovery only. Setting a store to Tombstone
+// abandons the data on it, and the replicas are not guaranteed to be safe.
+func SetStoreState(spec *meta.ClusterSpecification, store, state string, tlsCfg *tls.Config) error {
+	normalized, err := NormalizeStoreState(state)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, comp := range []meta.Component{&meta.TiKVComponent{ClusterSpecification: spec}, &meta.TiFlashComponent{ClusterSpecification: spec}} {
+		for _, ins := range comp.Instances() {
+			if fmt.Sprintf("%s:%d", ins.GetHost(), ins.GetPort()) == store {
+				found = true
+			}
+		}
+	}
+	if !found {
+		return errors.Errorf("store %s is not a TiKV or TiFlash instance of the cluster", store)
+	}
+
+	log.Warnf("Setting state of store %s to %s manually", store, normalized)
+	pdClient := api.NewPDClient(spec.GetPDList(), 10*time.Second, tlsCfg)
+	if err := pdClient.SetStoreState(store, normalized); err != nil {
+		return wrapPDError(err)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package operator
+
+import (
+	"github.com/pingcap/check"
+)
+
+type storeSuite struct{}
+
+var _ = check.Suite(&storeSuite{})
+
+func (s *storeSuite) TestNormalizeStoreState(c *check.C) {
+	state, err := NormalizeStoreState("tombstone")
+	c.Assert(err, check.IsNil)
+	c.Assert(state, check.Equals, "Tombstone")
+	state, err = NormalizeStoreState("Up")
+	c.Assert(err, check.IsNil)
+	c.Assert(state, check.Equals, "Up")
+
+	_, err = NormalizeStoreState("Down")
+	c.Assert(err, check.ErrorMatches, "invalid store state Down.*")
+}
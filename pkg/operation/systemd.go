@@ -14,41 +14,205 @@
 package operator
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/module"
 	"github.com/pingcap/errors"
 )
 
-// GetServiceStatus return the Acitive line of status.
-/*
-[tidb@ip-172-16-5-70 deploy]$ sudo systemctl status drainer-8249.service
-● drainer-8249.service - drainer-8249 service
-   Loaded: loaded (/etc/systemd/system/drainer-8249.service; disabled; vendor preset: disabled)
-   Active: active (running) since Mon 2020-03-09 13:56:19 CST; 1 weeks 3 days ago
- Main PID: 36718 (drainer)
-   CGroup: /system.slice/drainer-8249.service
-           └─36718 bin/drainer --addr=172.16.5.70:8249 --pd-urls=http://172.16.5.70:2379 --data-dir=/data1/deploy/data.drainer --log-file=/data1/deploy/log/drainer.log --config=conf/drainer.toml --initial-commit-ts=408375872006389761
-
-Mar 09 13:56:19 ip-172-16-5-70 systemd[1]: Started drainer-8249 service.
-*/
-func GetServiceStatus(e executor.TiOpsExecutor, name string) (active string, err error) {
+// GetServiceStatus returns the state of the systemd service, e.g. the system
+// services checked. A service not installed has LoadState not-found.
+func GetServiceStatus(e executor.TiOpsExecutor, name string) (*module.ServiceStatus, error) {
+	mgr, err := module.NewServiceManager(e, "", module.ServiceManagerSystemd)
+	if err != nil {
+		return nil, err
+	}
+	return mgr.Status(e, name)
+}
+
+// GetInstanceStatus returns the state of the service of the instance through
+// the service manager of its host
+func GetInstanceStatus(e executor.TiOpsExecutor, ins meta.Instance) (*module.ServiceStatus, error) {
+	mgr, err := meta.InstanceServiceManager(e, ins)
+	if err != nil {
+		return nil, err
+	}
+	return mgr.Status(e, ins.ServiceName())
+}
+
+// GetServiceExecStart returns the ExecStart command of the service, it reads
+// the unit with drop-ins applied so that manual edits are also reflected.
+func GetServiceExecStart(e executor.TiOpsExecutor, name string) (string, error) {
 	c := module.SystemdModuleConfig{
 		Unit:   name,
-		Action: "status",
+		Action: "cat",
 	}
 	systemd := module.NewSystemdModule(c)
 	stdout, _, err := systemd.Execute(e)
+	if err != nil {
+		return "", errors.Annotatef(err, "failed to read unit %s", name)
+	}
+
+	// the last ExecStart takes effect if it is overridden by drop-ins
+	execStart := ""
+	for _, line := range strings.Split(string(stdout), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "ExecStart=") {
+			execStart = strings.TrimPrefix(line, "ExecStart=")
+		}
+	}
+	if execStart == "" {
+		return "", errors.Errorf("no ExecStart found in unit %s", name)
+	}
+	return execStart, nil
+}
+
+// GetServiceUptime returns how long the service has been running, it's
+// computed from the monotonic timestamp the service entered the active state
+// and the uptime of the host, so that the timezone of the host doesn't matter.
+func GetServiceUptime(e executor.TiOpsExecutor, name string) (time.Duration, error) {
+	cmd := fmt.Sprintf("systemctl show -p ActiveState -p ActiveEnterTimestampMonotonic %s && cat /proc/uptime", name)
+	stdout, stderr, err := e.Execute(cmd, false)
+	if err != nil {
+		return 0, errors.Annotatef(err, "failed to get uptime of %s: %s", name, stderr)
+	}
+
+	var state string
+	var enterMicros, hostSecs float64
+	for _, line := range strings.Split(string(stdout), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "ActiveState="):
+			state = strings.TrimPrefix(line, "ActiveState=")
+		case strings.HasPrefix(line, "ActiveEnterTimestampMonotonic="):
+			enterMicros, err = strconv.ParseFloat(strings.TrimPrefix(line, "ActiveEnterTimestampMonotonic="), 64)
+		case line != "" && !strings.Contains(line, "="):
+			// the content of /proc/uptime, e.g. "350735.47 234388.90"
+			hostSecs, err = strconv.ParseFloat(strings.Fields(line)[0], 64)
+		}
+		if err != nil {
+			return 0, errors.Annotatef(err, "unexpected output: %s", string(stdout))
+		}
+	}
+	if state != "active" {
+		return 0, errors.Errorf("service %s is %s", name, state)
+	}
 
-	lines := strings.Split(string(stdout), "\n")
-	if len(lines) >= 3 {
-		return lines[2], nil
+	uptime := time.Duration((hostSecs - enterMicros/1e6) * float64(time.Second))
+	if uptime < 0 {
+		return 0, errors.Errorf("unexpected output: %s", string(stdout))
 	}
+	return uptime, nil
+}
+
+// GetServiceLimitNOFILE returns the open files limit the service is running
+// with, which is the one in effect since the service is loaded
+func GetServiceLimitNOFILE(e executor.TiOpsExecutor, name string) (string, error) {
+	cmd := fmt.Sprintf("systemctl show -p LimitNOFILE %s", name)
+	stdout, stderr, err := e.Execute(cmd, false)
+	if err != nil {
+		return "", errors.Annotatef(err, "failed to get LimitNOFILE of %s: %s", name, stderr)
+	}
+	line := strings.TrimSpace(string(stdout))
+	if !strings.HasPrefix(line, "LimitNOFILE=") {
+		return "", errors.Errorf("unexpected output: %s", line)
+	}
+	return strings.TrimPrefix(line, "LimitNOFILE="), nil
+}
+
+// GetLogIdleTime returns how long ago the newest log file in the dir was
+// written, it's compared with the clock of the host so that the timezone and
+// the clock skew of the host don't matter.
+func GetLogIdleTime(e executor.TiOpsExecutor, logDir string) (time.Duration, error) {
+	cmd := fmt.Sprintf(`f=$(ls -t %s/*.log 2>/dev/null | head -n 1); [ -n "$f" ] && stat -c %%Y "$f" && date +%%s`, logDir)
+	stdout, stderr, err := e.Execute(cmd, false)
+	if err != nil {
+		return 0, errors.Annotatef(err, "failed to get the log files in %s: %s", logDir, stderr)
+	}
+
+	fields := strings.Fields(string(stdout))
+	if len(fields) != 2 {
+		return 0, errors.Errorf("unexpected output: %s", string(stdout))
+	}
+	var secs [2]int64
+	for i, field := range fields {
+		if secs[i], err = strconv.ParseInt(field, 10, 64); err != nil {
+			return 0, errors.Annotatef(err, "unexpected output: %s", string(stdout))
+		}
+	}
+	idle := time.Duration(secs[1]-secs[0]) * time.Second
+	if idle < 0 {
+		idle = 0
+	}
+	return idle, nil
+}
+
+// ServiceUsage is the resource usage of a systemd service
+type ServiceUsage struct {
+	Memory    uint64  // bytes
+	CPU       float64 // percent of one CPU core
+	HasMemory bool    // MemoryAccounting is enabled for the service
+	HasCPU    bool    // CPUAccounting is enabled for the service
+}
+
+// cpuSampleInterval is the interval between the two samples of CPU time
+const cpuSampleInterval = 1
 
+// GetServicesUsage reads the current memory and CPU usage of the services on
+// the same host from their cgroup stats through systemd, the CPU usage is
+// averaged over one second. Services are read in a batch with one command.
+func GetServicesUsage(e executor.TiOpsExecutor, services []string) (map[string]ServiceUsage, error) {
+	if len(services) == 0 {
+		return nil, nil
+	}
+
+	show := fmt.Sprintf("for s in %s; do echo \"$s $(systemctl show -p MemoryCurrent -p CPUUsageNSec $s | tr '\\n' ' ')\"; done",
+		strings.Join(services, " "))
+	cmd := fmt.Sprintf("%s; sleep %d; %s", show, cpuSampleInterval, show)
+	stdout, stderr, err := e.Execute(cmd, false)
 	if err != nil {
-		return
+		return nil, errors.Annotatef(err, "failed to read usage of services: %s", stderr)
 	}
 
-	return "", errors.Errorf("unexpected output: %s", string(stdout))
+	// the output has two samples of each service, e.g.
+	// tikv-20160.service MemoryCurrent=1024 CPUUsageNSec=10000000
+	usages := make(map[string]ServiceUsage)
+	cpuStart := make(map[string]uint64)
+	for _, line := range strings.Split(string(stdout), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		name := fields[0]
+		usage := usages[name]
+		for _, f := range fields[1:] {
+			kv := strings.SplitN(f, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			// the value is "[not set]" or the max uint64 if accounting is disabled
+			v, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil || v == ^uint64(0) {
+				continue
+			}
+			switch kv[0] {
+			case "MemoryCurrent":
+				usage.Memory, usage.HasMemory = v, true
+			case "CPUUsageNSec":
+				if start, ok := cpuStart[name]; ok {
+					usage.CPU = float64(v-start) / float64(cpuSampleInterval*1e9) * 100
+					usage.HasCPU = true
+				} else {
+					cpuStart[name] = v
+				}
+			}
+		}
+		usages[name] = usage
+	}
+	return usages, nil
 }
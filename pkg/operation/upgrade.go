@@ -23,6 +23,8 @@ import (
 	"github.com/pingcap-incubator/tiup-cluster/pkg/utils"
 	"github.com/pingcap-incubator/tiup/pkg/set"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/metapb"
+	pdserverapi "github.com/pingcap/pd/v4/server/api"
 )
 
 // Upgrade the cluster.
@@ -58,22 +60,8 @@ func Upgrade(
 					log.Infof("Restarting component %s", component.Name())
 
 					for _, instance := range instances {
-						leader, err := pdClient.GetLeader()
-						if err != nil {
-							return errors.Annotatef(err, "failed to get PD leader %s", instance.GetHost())
-						}
-
-						if len(clusterSpec.PDServers) > 1 && leader.Name == instance.(*meta.PDInstance).Name {
-							if err := pdClient.EvictPDLeader(timeoutOpt); err != nil {
-								return errors.Annotatef(err, "failed to evict PD leader %s", instance.GetHost())
-							}
-						}
-
-						if err := stopInstance(getter, instance); err != nil {
-							return errors.Annotatef(err, "failed to stop %s", instance.GetHost())
-						}
-						if err := startInstance(getter, instance); err != nil {
-							return errors.Annotatef(err, "failed to start %s", instance.GetHost())
+						if err := restartPDInstance(getter, pdClient, clusterSpec, instance, timeoutOpt); err != nil {
+							return err
 						}
 					}
 
@@ -89,23 +77,8 @@ func Upgrade(
 					}
 
 					for _, instance := range instances {
-						if err := pdClient.EvictStoreLeader(addr(instance), timeoutOpt); err != nil {
-							if utils.IsTimeoutOrMaxRetry(err) {
-								log.Warnf("Ignore evicting store leader from %s, %v", instance.ID(), err)
-							} else {
-								return errors.Annotatef(err, "failed to evict store leader %s", instance.GetHost())
-							}
-						}
-
-						if err := stopInstance(getter, instance); err != nil {
-							return errors.Annotatef(err, "failed to stop %s", instance.GetHost())
-						}
-						if err := startInstance(getter, instance); err != nil {
-							return errors.Annotatef(err, "failed to start %s", instance.GetHost())
-						}
-						// remove store leader evict scheduler after restart
-						if err := pdClient.RemoveStoreEvict(addr(instance)); err != nil {
-							return errors.Annotatef(err, "failed to remove evict store scheduler for %s", instance.GetHost())
+						if err := restartTiKVInstance(getter, pdClient, instance, timeoutOpt); err != nil {
+							return err
 						}
 					}
 				}
@@ -121,6 +94,205 @@ func Upgrade(
 	return nil
 }
 
+// restartPDInstance restarts the PD instance, the leader is transferred to
+// other instances first if it's the leader
+func restartPDInstance(
+	getter ExecutorGetter,
+	pdClient *api.PDClient,
+	clusterSpec *meta.ClusterSpecification,
+	instance meta.Instance,
+	timeoutOpt *utils.RetryOption,
+) error {
+	leader, err := pdClient.GetLeader()
+	if err != nil {
+		return errors.Annotatef(err, "failed to get PD leader %s", instance.GetHost())
+	}
+
+	if len(clusterSpec.PDServers) > 1 && leader.Name == instance.(*meta.PDInstance).Name {
+		if err := pdClient.EvictPDLeader(timeoutOpt); err != nil {
+			return errors.Annotatef(err, "failed to evict PD leader %s", instance.GetHost())
+		}
+	}
+
+	if err := stopInstance(getter, instance); err != nil {
+		return errors.Annotatef(err, "failed to stop %s", instance.GetHost())
+	}
+	if err := startInstance(getter, instance); err != nil {
+		return errors.Annotatef(err, "failed to start %s", instance.GetHost())
+	}
+	return nil
+}
+
+// restartTiKVInstance restarts the TiKV instance with the region leaders
+// evicted from it, and waits for the store to be up again
+func restartTiKVInstance(
+	getter ExecutorGetter,
+	pdClient *api.PDClient,
+	instance meta.Instance,
+	timeoutOpt *utils.RetryOption,
+) error {
+	if err := pdClient.EvictStoreLeader(addr(instance), timeoutOpt); err != nil {
+		if utils.IsTimeoutOrMaxRetry(err) {
+			log.Warnf("Ignore evicting store leader from %s, %v", instance.ID(), err)
+		} else {
+			return errors.Annotatef(err, "failed to evict store leader %s", instance.GetHost())
+		}
+	}
+
+	if err := stopInstance(getter, instance); err != nil {
+		return errors.Annotatef(err, "failed to stop %s", instance.GetHost())
+	}
+	if err := startInstance(getter, instance); err != nil {
+		return errors.Annotatef(err, "failed to start %s", instance.GetHost())
+	}
+	// remove store leader evict scheduler after restart
+	if err := pdClient.RemoveStoreEvict(addr(instance)); err != nil {
+		return errors.Annotatef(err, "failed to remove evict store scheduler for %s", instance.GetHost())
+	}
+	return waitStoreUp(pdClient, addr(instance), timeoutOpt)
+}
+
+// waitStoreUp waits until the store on the address is reported as Up by PD
+func waitStoreUp(pdClient *api.PDClient, address string, timeoutOpt *utils.RetryOption) error {
+	err := utils.Retry(func() error {
+		stores, err := pdClient.GetStores()
+		if err != nil {
+			return err
+		}
+		// the latest store of the address is the live one
+		var latest *pdserverapi.StoreInfo
+		for _, store := range stores.Stores {
+			if store.Store.Address == address && (latest == nil || store.Store.Id > latest.Store.Id) {
+				latest = store
+			}
+		}
+		if latest == nil {
+			return errors.Errorf("store %s not found", address)
+		}
+		if latest.Store.StateName != metapb.StoreState_name[int32(metapb.StoreState_Up)] {
+			return errors.Errorf("store %s is %s", address, latest.Store.StateName)
+		}
+		return nil
+	}, *timeoutOpt)
+	if err != nil {
+		return errors.Annotatef(err, "failed to wait store %s to be up", address)
+	}
+	return nil
+}
+
+// RollingRestart restarts the instances one by one, the PD leader and the
+// region leaders of TiKV are transferred away before the instance is stopped,
+// and every instance is checked to be up before moving on.
+func RollingRestart(
+	getter ExecutorGetter,
+	spec meta.Specification,
+	options Options,
+) error {
+	clusterSpec := spec.GetClusterSpecification()
+	if clusterSpec == nil {
+		return errors.New("rolling restart is only supported by TiDB clusters")
+	}
+
+	timeoutOpt := &utils.RetryOption{
+		Timeout: time.Second * time.Duration(options.Timeout),
+		Delay:   time.Second * 2,
+	}
+	pdClient := api.NewPDClient(clusterSpec.GetPDList(), 5*time.Second, options.TLSConfig)
+
+	roleFilter := set.NewStringSet(options.Roles...)
+	nodeFilter := set.NewStringSet(options.Nodes...)
+	for _, component := range FilterComponent(spec.ComponentsByStartOrder(), roleFilter) {
+		instances := FilterInstance(component.Instances(), nodeFilter)
+		if len(instances) < 1 {
+			continue
+		}
+
+		log.Infof("Rolling restarting component %s", component.Name())
+		for _, instance := range instances {
+			var err error
+			switch component.Name() {
+			case meta.ComponentPD:
+				err = restartPDInstance(getter, pdClient, clusterSpec, instance, timeoutOpt)
+			case meta.ComponentTiKV:
+				// PD might not serve right away after its restart
+				if err = pdClient.WaitLeader(timeoutOpt); err == nil {
+					err = restartTiKVInstance(getter, pdClient, instance, timeoutOpt)
+				}
+			default:
+				err = RestartComponent(getter, []meta.Instance{instance})
+			}
+			if err != nil {
+				return errors.Annotatef(err, "rolling restart is interrupted at %s", instance.ID())
+			}
+
+			status, err := GetInstanceStatus(getter.Get(instance.GetHost()), instance)
+			if err != nil {
+				return errors.Annotatef(err, "failed to get status of %s", instance.ID())
+			}
+			if !status.Running() {
+				return errors.Errorf("%s is not running after restart: %s", instance.ID(), status)
+			}
+		}
+	}
+
+	return nil
+}
+
+// WaitInstancesUp waits until the services of the instances are running, and
+// the stores of the TiKV instances are up in PD. The timeout is in seconds
+// and applies to each instance.
+func WaitInstancesUp(
+	getter ExecutorGetter,
+	spec *meta.ClusterSpecification,
+	instances []meta.Instance,
+	timeout int64,
+) error {
+	timeoutOpt := &utils.RetryOption{
+		Timeout: time.Second * time.Duration(timeout),
+		Delay:   time.Second * 2,
+	}
+	pdClient := api.NewPDClient(spec.GetPDList(), 5*time.Second, nil)
+
+	for _, instance := range instances {
+		err := utils.Retry(func() error {
+			status, err := GetInstanceStatus(getter.Get(instance.GetHost()), instance)
+			if err != nil {
+				return err
+			}
+			if !status.Running() {
+				return errors.Errorf("%s is not running: %s", instance.ID(), status)
+			}
+			return nil
+		}, *timeoutOpt)
+		if err != nil {
+			return errors.Annotatef(err, "failed to wait %s to be running", instance.ID())
+		}
+
+		if instance.ComponentName() == meta.ComponentTiKV {
+			if err := waitStoreUp(pdClient, addr(instance), timeoutOpt); err != nil {
+				return err
+			}
+		}
+		log.Infof("\t%s is up", instance.ID())
+	}
+	return nil
+}
+
+// RollbackUpgrade restarts the cluster after the binaries of the previous
+// version are put back, it uses the same rolling restart as Upgrade so that
+// leaders are evicted and instances are waited to be ready one by one.
+func RollbackUpgrade(
+	getter ExecutorGetter,
+	spec meta.Specification,
+	options Options,
+) error {
+	log.Warnf("Rolling back the upgrade, instances are restarted with the previous version")
+	if err := Upgrade(getter, spec, options); err != nil {
+		return errors.Annotate(err, "rollback is interrupted, it's safe to run it again")
+	}
+	return nil
+}
+
 func addr(ins meta.Instance) string {
 	if ins.GetPort() == 0 || ins.GetPort() == 80 {
 		panic(ins)
@@ -55,6 +55,23 @@ func (c *ClusterOperate) Execute(ctx *Context) error {
 			return errors.Annotate(err, "failed to upgrade")
 		}
 		operator.PrintClusterStatus(ctx, c.spec)
+	case operator.RollbackUpgradeOperation:
+		err := operator.RollbackUpgrade(ctx, c.spec, c.options)
+		if err != nil {
+			return errors.Annotate(err, "failed to rollback upgrade")
+		}
+		operator.PrintClusterStatus(ctx, c.spec)
+	case operator.RollingRestartOperation:
+		err := operator.RollingRestart(ctx, c.spec, c.options)
+		if err != nil {
+			return errors.Annotate(err, "failed to rolling restart")
+		}
+		operator.PrintClusterStatus(ctx, c.spec)
+	case operator.ReloadOperation:
+		err := operator.Reload(ctx, c.spec, c.options)
+		if err != nil {
+			return errors.Annotate(err, "failed to reload")
+		}
 	case operator.DestroyOperation:
 		err := operator.Destroy(ctx, c.spec)
 		if err != nil {
@@ -0,0 +1,57 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap/errors"
+)
+
+// DetectArch detects the CPU architecture of the host by `uname -m` and sets
+// it to the instances on the host in the topology, so that the packages of
+// the arch are deployed to them
+type DetectArch struct {
+	host string
+	topo *meta.TopologySpecification
+}
+
+// Execute implements the Task interface
+func (d *DetectArch) Execute(ctx *Context) error {
+	e, ok := ctx.GetExecutor(d.host)
+	if !ok {
+		return ErrNoExecutor
+	}
+	stdout, stderr, err := e.Execute("uname -m", false)
+	if err != nil {
+		return errors.Annotatef(err, "failed to detect the arch of host %s: %s", d.host, strings.TrimSpace(string(stderr)))
+	}
+	arch, err := meta.NormalizeArch(string(stdout))
+	if err != nil {
+		return errors.Annotatef(err, "host %s", d.host)
+	}
+	return d.topo.SetHostArch(d.host, arch)
+}
+
+// Rollback implements the Task interface
+func (d *DetectArch) Rollback(ctx *Context) error {
+	return ErrUnsupportedRollback
+}
+
+// String implements the fmt.Stringer interface
+func (d *DetectArch) String() string {
+	return fmt.Sprintf("DetectArch: host=%s", d.host)
+}
@@ -109,18 +109,20 @@ func (b *Builder) CopyFile(src, dst, server string, download bool) *Builder {
 }
 
 // Download appends a Downloader task to the current task collection
-func (b *Builder) Download(component string, version repository.Version) *Builder {
+func (b *Builder) Download(component, arch string, version repository.Version) *Builder {
 	b.tasks = append(b.tasks, &Downloader{
 		component: component,
+		arch:      arch,
 		version:   version,
 	})
 	return b
 }
 
 // CopyComponent appends a CopyComponent task to the current task collection
-func (b *Builder) CopyComponent(component string, version repository.Version, dstHost, dstDir string) *Builder {
+func (b *Builder) CopyComponent(component, arch string, version repository.Version, dstHost, dstDir string) *Builder {
 	b.tasks = append(b.tasks, &CopyComponent{
 		component: component,
+		arch:      arch,
 		version:   version,
 		host:      dstHost,
 		dstDir:    dstDir,
@@ -175,15 +177,24 @@ func (b *Builder) ScaleConfig(clusterName, clusterVersion string, base *meta.Top
 }
 
 // MonitoredConfig appends a CopyComponent task to the current task collection
-func (b *Builder) MonitoredConfig(name, comp, host string, globResCtl meta.ResourceControl, options meta.MonitoredOptions, deployUser string, paths meta.DirPaths) *Builder {
+func (b *Builder) MonitoredConfig(name, comp, host string, globalOptions meta.GlobalOptions, options meta.MonitoredOptions, deployUser string, paths meta.DirPaths) *Builder {
 	b.tasks = append(b.tasks, &MonitoredConfig{
-		name:       name,
-		component:  comp,
-		host:       host,
-		globResCtl: globResCtl,
-		options:    options,
-		deployUser: deployUser,
-		paths:      paths,
+		name:          name,
+		component:     comp,
+		host:          host,
+		globalOptions: globalOptions,
+		options:       options,
+		deployUser:    deployUser,
+		paths:         paths,
+	})
+	return b
+}
+
+// DetectArch appends a DetectArch task to the current task collection
+func (b *Builder) DetectArch(host string, topo *meta.TopologySpecification) *Builder {
+	b.tasks = append(b.tasks, &DetectArch{
+		host: host,
+		topo: topo,
 	})
 	return b
 }
@@ -309,6 +320,13 @@ func (b *Builder) Parallel(tasks ...Task) *Builder {
 	return b
 }
 
+// ParallelWithConcurrency appends a parallel task executing at most concurrency
+// of the tasks at the same time, there is no limit if concurrency is 0
+func (b *Builder) ParallelWithConcurrency(concurrency int, tasks ...Task) *Builder {
+	b.tasks = append(b.tasks, &Parallel{inner: tasks, concurrency: concurrency})
+	return b
+}
+
 // Serial appends the tasks to the tail of queue
 func (b *Builder) Serial(tasks ...Task) *Builder {
 	b.tasks = append(b.tasks, tasks...)
@@ -333,7 +351,14 @@ func (b *Builder) Step(prefix string, inner Task) *Builder {
 // ParallelStep appends a new ParallelStepDisplay task, which will print multi line progress in parallel
 // for inner tasks. Inner tasks must be a StepDisplay task.
 func (b *Builder) ParallelStep(prefix string, tasks ...*StepDisplay) *Builder {
-	b.tasks = append(b.tasks, newParallelStepDisplay(prefix, tasks...))
+	b.tasks = append(b.tasks, newParallelStepDisplay(prefix, 0, tasks...))
+	return b
+}
+
+// ParallelStepWithConcurrency is ParallelStep executing at most concurrency of
+// the inner tasks at the same time, there is no limit if concurrency is 0
+func (b *Builder) ParallelStepWithConcurrency(prefix string, concurrency int, tasks ...*StepDisplay) *Builder {
+	b.tasks = append(b.tasks, newParallelStepDisplay(prefix, concurrency, tasks...))
 	return b
 }
 
@@ -34,6 +34,7 @@ var (
 	CheckTypePackage      = "package"
 	CheckTypePartitions   = "partitions"
 	CheckTypeFIO          = "fio"
+	CheckTypeClock        = "clock"
 )
 
 // place the check utilities are stored
@@ -64,6 +65,8 @@ func (c *CheckSys) Execute(ctx *Context) error {
 		ctx.SetCheckResults(c.host, operator.CheckSysLimits(c.opt, c.topo.GlobalOptions.User, stdout))
 	case CheckTypeSystemConfig:
 		results := operator.CheckKernelParameters(c.opt, stdout)
+		results = append(results, operator.CheckResourceLimits(c.host, c.topo, stdout)...)
+		results = append(results, operator.CheckLogRotation(c.host, c.topo)...)
 		e, ok := ctx.GetExecutor(c.host)
 		if !ok {
 			return ErrNoExecutor
@@ -71,6 +74,7 @@ func (c *CheckSys) Execute(ctx *Context) error {
 		results = append(
 			results,
 			operator.CheckSELinux(e),
+			operator.CheckTHP(e),
 		)
 		ctx.SetCheckResults(c.host, results)
 	case CheckTypePort:
@@ -111,7 +115,32 @@ func (c *CheckSys) Execute(ctx *Context) error {
 				Msg:  "numactl: " + strings.Split(string(stdout), "\n")[0],
 			})
 		}
+
+		// check if the NUMA nodes the instances are bound to exist
+		var numaNodes []string
+		found := map[string]struct{}{}
+		c.topo.IterInstance(func(ins meta.Instance) {
+			numaNode := meta.InstanceNumaNode(ins)
+			if ins.GetHost() != c.host || numaNode == "" {
+				return
+			}
+			if _, ok := found[numaNode]; !ok {
+				found[numaNode] = struct{}{}
+				numaNodes = append(numaNodes, numaNode)
+			}
+		})
+		if len(numaNodes) > 0 {
+			results = append(results, operator.CheckNumaNodes(e, numaNodes))
+		}
 		ctx.SetCheckResults(c.host, results)
+	case CheckTypeClock:
+		e, ok := ctx.GetExecutor(c.host)
+		if !ok {
+			return ErrNoExecutor
+		}
+		ctx.SetCheckResults(c.host, []*operator.CheckResult{
+			operator.CheckClockSkew(e, c.opt.ClockSkewThreshold()),
+		})
 	case CheckTypePartitions:
 		// check partition mount options for data_dir
 		ctx.SetCheckResults(c.host, operator.CheckPartitions(c.opt, c.host, c.topo, stdout))
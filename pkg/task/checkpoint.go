@@ -0,0 +1,202 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"go.uber.org/zap"
+)
+
+// resumable is implemented by the tasks which are safe to skip once finished
+// when resuming a failed execution, they must only change the remote hosts
+// or the local files rather than the Context. The key identifies the task
+// across executions.
+type resumable interface {
+	resumeKey() string
+}
+
+// Checkpoint records the resumable tasks finished in a file, so that they
+// are skipped when the execution is resumed after a failure
+type Checkpoint struct {
+	mu       sync.Mutex
+	file     string
+	finished map[string]struct{}
+}
+
+// NewCheckpoint returns the checkpoint saved in the file, the finished tasks
+// recorded by the last execution are loaded if resume is true, otherwise the
+// file is cleared
+func NewCheckpoint(file string, resume bool) (*Checkpoint, error) {
+	cp := &Checkpoint{
+		file:     file,
+		finished: make(map[string]struct{}),
+	}
+	if !resume {
+		return cp, cp.Clear()
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cp, nil
+		}
+		return nil, errors.AddStack(err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var key string
+		// the last line may be partially written if the process is killed
+		if err := json.Unmarshal(scanner.Bytes(), &key); err != nil {
+			continue
+		}
+		cp.finished[key] = struct{}{}
+	}
+	return cp, errors.AddStack(scanner.Err())
+}
+
+// Len returns the number of the finished tasks
+func (cp *Checkpoint) Len() int {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return len(cp.finished)
+}
+
+// finishedBefore returns if the task is resumable and finished in the last
+// execution
+func (cp *Checkpoint) finishedBefore(t Task) bool {
+	r, ok := t.(resumable)
+	if !ok {
+		return false
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	_, ok = cp.finished[r.resumeKey()]
+	return ok
+}
+
+// record appends the task to the file if it's resumable
+func (cp *Checkpoint) record(t Task) error {
+	r, ok := t.(resumable)
+	if !ok {
+		return nil
+	}
+	key := r.resumeKey()
+	data, err := json.Marshal(key)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	f, err := os.OpenFile(cp.file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	if _, err := fmt.Fprintln(f, string(data)); err != nil {
+		f.Close()
+		return errors.AddStack(err)
+	}
+	cp.finished[key] = struct{}{}
+	return errors.AddStack(f.Close())
+}
+
+// forget removes the task and its inner tasks from the checkpoint, so that
+// they're executed again when resuming after being rolled back
+func (cp *Checkpoint) forget(t Task) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	changed := false
+	for _, leaf := range leafTasks(t) {
+		if r, ok := leaf.(resumable); ok {
+			if _, found := cp.finished[r.resumeKey()]; found {
+				delete(cp.finished, r.resumeKey())
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for key := range cp.finished {
+		data, err := json.Marshal(key)
+		if err != nil {
+			return errors.AddStack(err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return errors.AddStack(ioutil.WriteFile(cp.file, buf.Bytes(), 0644))
+}
+
+// leafTasks returns the tasks inside the task lists and the steps
+func leafTasks(t Task) []Task {
+	var inner []Task
+	switch t := t.(type) {
+	case *Serial:
+		inner = t.inner
+	case *Parallel:
+		inner = t.inner
+	case *StepDisplay:
+		inner = []Task{t.inner}
+	case *ParallelStepDisplay:
+		inner = []Task{t.inner}
+	default:
+		return []Task{t}
+	}
+
+	var leaves []Task
+	for _, t := range inner {
+		leaves = append(leaves, leafTasks(t)...)
+	}
+	return leaves
+}
+
+// Clear removes the file, it should be called once the execution succeeds
+func (cp *Checkpoint) Clear() error {
+	if err := os.Remove(cp.file); err != nil && !os.IsNotExist(err) {
+		return errors.AddStack(err)
+	}
+	return nil
+}
+
+// executeResumable executes the task unless it's finished in the last
+// execution, and records it in the checkpoint of the context once finished
+func executeResumable(ctx *Context, t Task) error {
+	cp := ctx.checkpoint
+	if cp == nil {
+		return t.Execute(ctx)
+	}
+	if cp.finishedBefore(t) {
+		zap.L().Debug("Skip the task finished before", zap.String("task", t.String()))
+		return nil
+	}
+	if err := t.Execute(ctx); err != nil {
+		return err
+	}
+	return cp.record(t)
+}
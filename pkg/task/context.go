@@ -18,7 +18,6 @@ import (
 
 	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
-	"github.com/pingcap/errors"
 )
 
 // SetSSHKeySet set ssh key set.
@@ -29,21 +28,11 @@ func (ctx *Context) SetSSHKeySet(privateKeyPath string, publicKeyPath string) er
 }
 
 // SetClusterSSH set cluster user ssh executor in context.
+// The password of the deploy user is used if the private key is missing.
 func (ctx *Context) SetClusterSSH(topo meta.Specification, deployUser string, sshTimeout int64) error {
-	if len(ctx.PrivateKeyPath) == 0 {
-		return errors.Errorf("context has no PrivateKeyPath")
-	}
-
 	for _, com := range topo.ComponentsByStartOrder() {
 		for _, in := range com.Instances() {
-			cf := executor.SSHConfig{
-				Host:    in.GetHost(),
-				Port:    in.GetSSHPort(),
-				KeyFile: ctx.PrivateKeyPath,
-				User:    deployUser,
-				Timeout: time.Second * time.Duration(sshTimeout),
-			}
-
+			cf := userSSHConfig(ctx, in.GetHost(), in.GetSSHPort(), deployUser, sshTimeout)
 			e := executor.NewSSHExecutor(cf, false /* sudo */)
 			ctx.SetExecutor(in.GetHost(), e)
 		}
@@ -51,3 +40,15 @@ func (ctx *Context) SetClusterSSH(topo meta.Specification, deployUser string, ss
 
 	return nil
 }
+
+// SetExecuteTimeout sets the timeout of the commands run by the SSH executors
+// in context whose caller doesn't specify one.
+func (ctx *Context) SetExecuteTimeout(timeout time.Duration) {
+	ctx.exec.Lock()
+	defer ctx.exec.Unlock()
+	for _, e := range ctx.exec.executors {
+		if se, ok := e.(*executor.SSHExecutor); ok {
+			se.ExecuteTimeout = timeout
+		}
+	}
+}
@@ -18,12 +18,14 @@ import (
 
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	"github.com/pingcap-incubator/tiup/pkg/repository"
+	"github.com/pingcap/errors"
 )
 
 // CopyComponent is used to copy all files related the specific version a component
 // to the target directory of path
 type CopyComponent struct {
 	component string
+	arch      string
 	version   repository.Version
 	host      string
 	dstDir    string
@@ -32,17 +34,26 @@ type CopyComponent struct {
 // Execute implements the Task interface
 func (c *CopyComponent) Execute(ctx *Context) error {
 	// Copy to remote server
-	resName := fmt.Sprintf("%s-%s", c.component, c.version)
-	fileName := fmt.Sprintf("%s-linux-amd64.tar.gz", resName)
+	d := &Downloader{component: c.component, arch: c.arch, version: c.version}
+	fileName, _ := d.fileNames()
 	srcPath := meta.ProfilePath(meta.TiOpsPackageCacheDir, fileName)
 
+	digest, err := d.packageDigest(ctx)
+	if err != nil {
+		return errors.Annotatef(err, "failed to checksum component '%s:%s' for linux/%s", c.component, c.version, c.arch)
+	}
+
 	install := &InstallPackage{
 		srcPath: srcPath,
 		host:    c.host,
 		dstDir:  c.dstDir,
+		sha256:  digest,
 	}
 
-	return install.Execute(ctx)
+	if err := install.Execute(ctx); err != nil {
+		return errors.Annotatef(err, "failed to copy component '%s:%s' to %s", c.component, c.version, c.host)
+	}
+	return nil
 }
 
 // Rollback implements the Task interface
@@ -52,5 +63,10 @@ func (c *CopyComponent) Rollback(ctx *Context) error {
 
 // String implements the fmt.Stringer interface
 func (c *CopyComponent) String() string {
-	return fmt.Sprintf("CopyComponent: component=%s, version=%s, remote=%s:%s", c.component, c.version, c.host, c.dstDir)
+	return fmt.Sprintf("CopyComponent: component=%s, version=%s, arch=%s, remote=%s:%s", c.component, c.version, c.arch, c.host, c.dstDir)
+}
+
+// resumeKey implements the resumable interface
+func (c *CopyComponent) resumeKey() string {
+	return c.String()
 }
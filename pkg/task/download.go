@@ -14,10 +14,14 @@
 package task
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 
+	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	tiupmeta "github.com/pingcap-incubator/tiup/pkg/meta"
 	"github.com/pingcap-incubator/tiup/pkg/repository"
@@ -25,10 +29,30 @@ import (
 	"github.com/pingcap/errors"
 )
 
+// mirrorAddr is the mirror to download the components from, the one of tiup
+// is used if it's empty
+var mirrorAddr string
+
+// SetMirror sets the mirror to download the components from, it's either a
+// local directory or an HTTP endpoint with the layout of the tiup repository.
+// It takes precedence over $TIUP_MIRRORS.
+func SetMirror(addr string) {
+	mirrorAddr = addr
+}
+
+// Mirror returns the mirror to download the components from
+func Mirror() string {
+	if mirrorAddr != "" {
+		return mirrorAddr
+	}
+	return tiupmeta.Mirror()
+}
+
 // Downloader is used to download the specific version of a component from
 // the repository, there is nothing to do if the specified version exists.
 type Downloader struct {
 	component string
+	arch      string
 	version   repository.Version
 }
 
@@ -41,10 +65,9 @@ func (d *Downloader) Execute(_ *Context) error {
 		return errors.Errorf("version not specified for component '%s'", d.component)
 	}
 
-	resName := fmt.Sprintf("%s-%s", d.component, d.version)
-	fileName := fmt.Sprintf("%s-linux-amd64.tar.gz", resName)
-	sha1File := fmt.Sprintf("%s-linux-amd64.sha1", resName)
+	fileName, sha1File := d.fileNames()
 	srcPath := meta.ProfilePath(meta.TiOpsPackageCacheDir, fileName)
+	shaPath := meta.ProfilePath(meta.TiOpsPackageCacheDir, sha1File)
 
 	if err := os.MkdirAll(meta.ProfilePath(meta.TiOpsPackageCacheDir), 0755); err != nil {
 		return err
@@ -55,7 +78,7 @@ func (d *Downloader) Execute(_ *Context) error {
 		options := repository.MirrorOptions{
 			Progress: repository.DisableProgress{},
 		}
-		mirror := repository.NewMirror(tiupmeta.Mirror(), options)
+		mirror := repository.NewMirror(Mirror(), options)
 		if err := mirror.Open(); err != nil {
 			return errors.Trace(err)
 		}
@@ -63,7 +86,7 @@ func (d *Downloader) Execute(_ *Context) error {
 
 		repo, err := repository.NewRepository(mirror, repository.Options{
 			GOOS:              "linux",
-			GOARCH:            "amd64",
+			GOARCH:            d.arch,
 			DisableDecompress: true,
 		})
 		if err != nil {
@@ -80,36 +103,118 @@ func (d *Downloader) Execute(_ *Context) error {
 
 		err = repo.Mirror().Download(fileName, meta.ProfilePath(meta.TiOpsPackageCacheDir))
 		if err != nil {
-			return errors.AddStack(err)
+			// the components are not all released for every arch
+			return errors.Annotatef(err, "failed to download component '%s:%s' for linux/%s, it may have no package for the arch",
+				d.component, d.version, d.arch)
 		}
 
 		err = repo.Mirror().Download(sha1File, meta.ProfilePath(meta.TiOpsPackageCacheDir))
 		if err != nil {
 			return errors.AddStack(err)
 		}
+	}
 
-		shaPath := meta.ProfilePath(meta.TiOpsPackageCacheDir, sha1File)
-		sha, err := ioutil.ReadFile(shaPath)
-		if err != nil {
-			return errors.Trace(err)
-		}
+	// the cached package is verified as well, it may be staged by hand or
+	// left broken by an interrupted download
+	return d.verify(srcPath, shaPath)
+}
 
-		file, err := os.Open(srcPath)
-		if err != nil {
-			return errors.Trace(err)
-		}
+// fileNames returns the names of the package and its checksum in the mirror
+func (d *Downloader) fileNames() (string, string) {
+	resName := fmt.Sprintf("%s-%s", d.component, d.version)
+	return fmt.Sprintf("%s-linux-%s.tar.gz", resName, d.arch), fmt.Sprintf("%s-linux-%s.sha1", resName, d.arch)
+}
 
-		err = utils.CheckSHA(file, string(sha))
-		_ = file.Close()
+// verify checks the package against the checksum from the mirror, both are
+// removed if they mismatch so that they are downloaded again next time. The
+// SHA256 of the verified package is saved next to it for CopyComponent to
+// verify the copies on the target hosts, the mirror only publishes SHA1.
+func (d *Downloader) verify(srcPath, shaPath string) error {
+	sha, err := ioutil.ReadFile(shaPath)
+	if err != nil {
+		return errors.Annotatef(err, "failed to read the checksum of component '%s:%s'", d.component, d.version)
+	}
 
-		if err != nil {
-			_ = os.Remove(srcPath)
-			_ = os.Remove(shaPath)
-			return err
+	file, err := os.Open(srcPath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// compute the SHA256 in the same pass as the package may be large
+	h := sha256.New()
+	err = utils.CheckSHA(io.TeeReader(file, h), string(sha))
+	_ = file.Close()
+
+	digestPath := packageDigestPath(srcPath)
+	if err != nil {
+		_ = os.Remove(srcPath)
+		_ = os.Remove(shaPath)
+		_ = os.Remove(digestPath)
+		return errors.Annotatef(err, "checksum of component '%s:%s' for linux/%s mismatches the mirror %s",
+			d.component, d.version, d.arch, Mirror())
+	}
+	fi, err := os.Stat(srcPath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return ioutil.WriteFile(digestPath, []byte(packageDigestRecord(fi, fmt.Sprintf("%x", h.Sum(nil)))), 0644)
+}
+
+// packageDigestPath returns the path of the SHA256 of the verified package
+func packageDigestPath(srcPath string) string {
+	return strings.TrimSuffix(srcPath, ".tar.gz") + ".sha256"
+}
+
+// packageDigestRecord is the content of the digest file, the size and the
+// modification time of the package are recorded with the SHA256 to tell if
+// the digest still belongs to the package, e.g. it's not replaced by hand
+func packageDigestRecord(fi os.FileInfo, digest string) string {
+	return fmt.Sprintf("%s %d %d", digest, fi.Size(), fi.ModTime().UnixNano())
+}
+
+// packageDigest returns the SHA256 of the package saved by verify. If it's not
+// recorded for the package as is, e.g. the package is replaced by hand, the
+// package is verified against the mirror again, and downloaded again if it
+// doesn't match, a digest computed from the package alone is never trusted.
+func (d *Downloader) packageDigest(ctx *Context) (string, error) {
+	fileName, sha1File := d.fileNames()
+	srcPath := meta.ProfilePath(meta.TiOpsPackageCacheDir, fileName)
+	shaPath := meta.ProfilePath(meta.TiOpsPackageCacheDir, sha1File)
+	if digest, ok := savedPackageDigest(srcPath); ok {
+		return digest, nil
+	}
+
+	log.Debugf("The digest of %s is outdated, verifying it against the mirror again", srcPath)
+	if err := d.verify(srcPath, shaPath); err != nil {
+		log.Warnf("Failed to verify %s, downloading it again: %s", srcPath, err)
+		// the package is kept by Execute if the checksum is missing
+		_ = os.Remove(srcPath)
+		if err := d.Execute(ctx); err != nil {
+			return "", err
 		}
 	}
+	if digest, ok := savedPackageDigest(srcPath); ok {
+		return digest, nil
+	}
+	return "", errors.Errorf("no verified digest of %s", srcPath)
+}
 
-	return nil
+// savedPackageDigest returns the SHA256 saved by verify if it's recorded for
+// the package as is
+func savedPackageDigest(srcPath string) (string, bool) {
+	fi, err := os.Stat(srcPath)
+	if err != nil {
+		return "", false
+	}
+	data, err := ioutil.ReadFile(packageDigestPath(srcPath))
+	if err != nil {
+		return "", false
+	}
+	record := strings.TrimSpace(string(data))
+	if fields := strings.Fields(record); len(fields) == 3 && record == packageDigestRecord(fi, fields[0]) {
+		return fields[0], true
+	}
+	return "", false
 }
 
 // Rollback implements the Task interface
@@ -120,5 +225,5 @@ func (d *Downloader) Rollback(ctx *Context) error {
 
 // String implements the fmt.Stringer interface
 func (d *Downloader) String() string {
-	return fmt.Sprintf("Download: component=%s, version=%s", d.component, d.version)
+	return fmt.Sprintf("Download: component=%s, version=%s, arch=%s", d.component, d.version, d.arch)
 }
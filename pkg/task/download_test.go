@@ -0,0 +1,56 @@
+package task
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	tiuplocaldata "github.com/pingcap-incubator/tiup/pkg/localdata"
+	"github.com/pingcap/check"
+)
+
+func (s *taskSuite) TestPackageDigest(c *check.C) {
+	os.Setenv(tiuplocaldata.EnvNameComponentDataDir, c.MkDir())
+	defer os.Unsetenv(tiuplocaldata.EnvNameComponentDataDir)
+	c.Assert(meta.Initialize("cluster"), check.IsNil)
+	c.Assert(os.MkdirAll(meta.ProfilePath(meta.TiOpsPackageCacheDir), 0755), check.IsNil)
+	// nothing can be downloaded from the empty mirror
+	SetMirror(c.MkDir())
+	defer SetMirror("")
+
+	d := &Downloader{component: "tidb", arch: "amd64", version: "v4.0.0"}
+	fileName, sha1File := d.fileNames()
+	srcPath := meta.ProfilePath(meta.TiOpsPackageCacheDir, fileName)
+	shaPath := meta.ProfilePath(meta.TiOpsPackageCacheDir, sha1File)
+	pkg := []byte("package")
+	c.Assert(ioutil.WriteFile(srcPath, pkg, 0644), check.IsNil)
+	c.Assert(ioutil.WriteFile(shaPath, []byte(fmt.Sprintf("%x", sha1.Sum(pkg))), 0644), check.IsNil)
+	expected := fmt.Sprintf("%x", sha256.Sum256(pkg))
+
+	// verified against the checksum of the mirror
+	digest, err := d.packageDigest(NewContext())
+	c.Assert(err, check.IsNil)
+	c.Assert(digest, check.Equals, expected)
+	_, ok := savedPackageDigest(srcPath)
+	c.Assert(ok, check.IsTrue)
+
+	// copied again as is, verified again
+	later := time.Now().Add(time.Hour)
+	c.Assert(os.Chtimes(srcPath, later, later), check.IsNil)
+	_, ok = savedPackageDigest(srcPath)
+	c.Assert(ok, check.IsFalse)
+	digest, err = d.packageDigest(NewContext())
+	c.Assert(err, check.IsNil)
+	c.Assert(digest, check.Equals, expected)
+
+	// replaced by another package, the digest of it is not trusted
+	c.Assert(ioutil.WriteFile(srcPath, []byte("replaced"), 0644), check.IsNil)
+	_, err = d.packageDigest(NewContext())
+	c.Assert(err, check.NotNil)
+	_, ok = savedPackageDigest(srcPath)
+	c.Assert(ok, check.IsFalse)
+}
@@ -121,3 +121,8 @@ func (e *EnvInit) Rollback(ctx *Context) error {
 func (e *EnvInit) String() string {
 	return fmt.Sprintf("EnvInit: user=%s, host=%s", e.deployUser, e.host)
 }
+
+// resumeKey implements the resumable interface
+func (e *EnvInit) resumeKey() string {
+	return e.String()
+}
@@ -14,13 +14,31 @@
 package task
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/module"
+	"github.com/pingcap/errors"
 )
 
+// initConfigDirs are the dirs InitConfig writes to, relative to the deploy
+// dir, the service definition is written to one of module.ServiceFiles
+var initConfigDirs = []string{"conf", "scripts"}
+
+var (
+	// ErrInitConfigFailed means that the config of an instance can't be pushed,
+	// the ID of the instance is in the message
+	ErrInitConfigFailed = errNS.NewType("init_config_failed")
+)
+
+const configBackupName = ".config.bak"
+
 // InitConfig is used to copy all configurations to the target directory of path
 type InitConfig struct {
 	clusterName    string
@@ -28,10 +46,20 @@ type InitConfig struct {
 	instance       meta.Instance
 	deployUser     string
 	paths          meta.DirPaths
+	backupDir      string // the snapshot of the config before Execute on the target host
 }
 
 // Execute implements the Task interface
 func (c *InitConfig) Execute(ctx *Context) error {
+	// the failure of each instance is reported with its ID, as the config of
+	// the instances are pushed in parallel
+	if err := c.execute(ctx); err != nil {
+		return ErrInitConfigFailed.Wrap(err, "Failed to init config of %s", c.instance.ID())
+	}
+	return nil
+}
+
+func (c *InitConfig) execute(ctx *Context) error {
 	// Copy to remote server
 	exec, found := ctx.GetExecutor(c.instance.GetHost())
 	if !found {
@@ -42,12 +70,101 @@ func (c *InitConfig) Execute(ctx *Context) error {
 		return err
 	}
 
-	return c.instance.InitConfig(exec, c.clusterName, c.clusterVersion, c.deployUser, c.paths)
+	// snapshot the current config so that it can be restored by Rollback
+	backupDir := filepath.Join(c.paths.Deploy, configBackupName)
+	cmds := []string{fmt.Sprintf("rm -rf %[1]s && mkdir -p %[1]s", backupDir)}
+	for _, dir := range initConfigDirs {
+		src := filepath.Join(c.paths.Deploy, dir)
+		cmds = append(cmds, fmt.Sprintf("if [ -d %s ]; then cp -a %s %s/; fi", src, src, backupDir))
+	}
+	for _, file := range module.ServiceFiles(c.instance.ServiceName()) {
+		cmds = append(cmds, fmt.Sprintf("if [ -f %s ]; then cp -a %s %s/; fi", file, file, backupDir))
+	}
+	if _, stderr, err := exec.Execute(strings.Join(cmds, " && "), true); err != nil {
+		return errors.Annotatef(err, "failed to backup config: %s", stderr)
+	}
+	c.backupDir = backupDir
+
+	checked := &checksumExecutor{TiOpsExecutor: exec}
+	if err := c.instance.InitConfig(checked, c.clusterName, c.clusterVersion, c.deployUser, c.paths); err != nil {
+		return err
+	}
+
+	// keep the digests of what is pushed for later verification
+	fp := filepath.Join(c.paths.Cache, fmt.Sprintf("%s-%s.sha256", c.instance.GetHost(), c.instance.ServiceName()))
+	return ioutil.WriteFile(fp, []byte(strings.Join(checked.digests, "")), 0644)
+}
+
+// checksumExecutor verifies the files uploaded by Transfer with the SHA256
+// digests, so that a truncated copy fails loudly instead of being used
+type checksumExecutor struct {
+	executor.TiOpsExecutor
+	digests []string // in the format of sha256sum
 }
 
-// Rollback implements the Task interface
+// Transfer implements the TiOpsExecutor interface
+func (e *checksumExecutor) Transfer(src string, dst string, download bool) error {
+	if err := e.TiOpsExecutor.Transfer(src, dst, download); err != nil || download {
+		return err
+	}
+
+	data, err := ioutil.ReadFile(src)
+	if err != nil {
+		return errors.AddStack(err)
+	}
+	expected := fmt.Sprintf("%x", sha256.Sum256(data))
+
+	stdout, stderr, err := e.Execute(fmt.Sprintf("sha256sum %s", dst), false)
+	if err != nil {
+		return errors.Annotatef(err, "failed to checksum %s: %s", dst, stderr)
+	}
+	fields := strings.Fields(string(stdout))
+	if len(fields) == 0 || fields[0] != expected {
+		actual := "<none>"
+		if len(fields) > 0 {
+			actual = fields[0]
+		}
+		return errors.Errorf("checksum mismatch of %s copied from %s, expected %s but got %s", dst, src, expected, actual)
+	}
+
+	e.digests = append(e.digests, fmt.Sprintf("%s  %s\n", expected, dst))
+	return nil
+}
+
+// Rollback implements the Task interface, it restores the config snapshotted
+// by Execute
 func (c *InitConfig) Rollback(ctx *Context) error {
-	return ErrUnsupportedRollback
+	if c.backupDir == "" {
+		// not executed yet, nothing is changed
+		return nil
+	}
+	exec, found := ctx.GetExecutor(c.instance.GetHost())
+	if !found {
+		return ErrNoExecutor
+	}
+
+	var cmds []string
+	for _, dir := range initConfigDirs {
+		backup := filepath.Join(c.backupDir, dir)
+		dst := filepath.Join(c.paths.Deploy, dir)
+		cmds = append(cmds, fmt.Sprintf("if [ -d %s ]; then rm -rf %s && cp -a %s %s; fi", backup, dst, backup, dst))
+	}
+	for _, file := range module.ServiceFiles(c.instance.ServiceName()) {
+		backup := filepath.Join(c.backupDir, filepath.Base(file))
+		cmds = append(cmds, fmt.Sprintf("if [ -f %s ]; then cp -a %s %s/; fi", backup, backup, filepath.Dir(file)))
+	}
+	if _, stderr, err := exec.Execute(strings.Join(cmds, " && "), true); err != nil {
+		return errors.Annotatef(err, "failed to restore config of %s: %s", c.instance.ID(), stderr)
+	}
+
+	manager, err := meta.InstanceServiceManager(exec, c.instance)
+	if err != nil {
+		return err
+	}
+	if _, stderr, err := manager.DaemonReload(exec); err != nil {
+		return errors.Annotatef(err, "failed to reload the service definitions on %s: %s", c.instance.GetHost(), stderr)
+	}
+	return nil
 }
 
 // String implements the fmt.Stringer interface
@@ -56,3 +173,8 @@ func (c *InitConfig) String() string {
 		c.clusterName, c.deployUser, c.instance.GetHost(),
 		filepath.Join(meta.ClusterPath(c.clusterName, "config", c.instance.ServiceName())), c.paths)
 }
+
+// resumeKey implements the resumable interface
+func (c *InitConfig) resumeKey() string {
+	return c.String()
+}
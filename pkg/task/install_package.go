@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/pingcap/errors"
 )
@@ -27,6 +28,7 @@ type InstallPackage struct {
 	srcPath string
 	host    string
 	dstDir  string
+	sha256  string // the digest to verify the copy before unpacking, not verified if empty
 }
 
 // Execute implements the Task interface
@@ -45,6 +47,23 @@ func (c *InstallPackage) Execute(ctx *Context) error {
 		return errors.Trace(err)
 	}
 
+	if c.sha256 != "" {
+		stdout, stderr, err := exec.Execute(fmt.Sprintf("sha256sum %s", dstPath), false)
+		if err != nil {
+			return errors.Annotatef(err, "failed to checksum %s: %s", dstPath, stderr)
+		}
+		fields := strings.Fields(string(stdout))
+		if len(fields) == 0 || fields[0] != c.sha256 {
+			// never leave a broken package to be unpacked by hand
+			_, _, _ = exec.Execute(fmt.Sprintf("rm -f %s", dstPath), false)
+			actual := "<none>"
+			if len(fields) > 0 {
+				actual = fields[0]
+			}
+			return errors.Errorf("checksum mismatch of %s copied from %s, expected %s but got %s", dstPath, c.srcPath, c.sha256, actual)
+		}
+	}
+
 	cmd := fmt.Sprintf(`tar -xzf %s -C %s && rm %s`, dstPath, dstDir, dstPath)
 
 	_, stderr, err := exec.Execute(cmd, false)
@@ -63,3 +82,8 @@ func (c *InstallPackage) Rollback(ctx *Context) error {
 func (c *InstallPackage) String() string {
 	return fmt.Sprintf("InstallPackage: srcPath=%s, remote=%s:%s", c.srcPath, c.host, c.dstDir)
 }
+
+// resumeKey implements the resumable interface
+func (c *InstallPackage) resumeKey() string {
+	return c.String()
+}
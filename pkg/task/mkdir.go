@@ -56,3 +56,8 @@ func (m *Mkdir) Rollback(ctx *Context) error {
 func (m *Mkdir) String() string {
 	return fmt.Sprintf("Mkdir: host=%s, directories='%s'", m.host, strings.Join(m.dirs, "','"))
 }
+
+// resumeKey implements the resumable interface
+func (m *Mkdir) resumeKey() string {
+	return m.String()
+}
@@ -18,9 +18,7 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/google/uuid"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
-	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/meta"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/template"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/template/config"
@@ -30,13 +28,13 @@ import (
 
 // MonitoredConfig is used to generate the monitor node configuration
 type MonitoredConfig struct {
-	name       string
-	component  string
-	host       string
-	globResCtl meta.ResourceControl
-	options    meta.MonitoredOptions
-	deployUser string
-	paths      meta.DirPaths
+	name          string
+	component     string
+	host          string
+	globalOptions meta.GlobalOptions
+	options       meta.MonitoredOptions
+	deployUser    string
+	paths         meta.DirPaths
 }
 
 // Execute implements the Task interface
@@ -86,30 +84,15 @@ func (m *MonitoredConfig) Execute(ctx *Context) error {
 func (m *MonitoredConfig) syncMonitoredSystemConfig(exec executor.TiOpsExecutor, comp string, port int) error {
 	sysCfg := filepath.Join(m.paths.Cache, fmt.Sprintf("%s-%s-%d.service", comp, m.host, port))
 
-	resource := meta.MergeResourceControl(m.globResCtl, m.options.ResourceControl)
+	resource := meta.MergeResourceControl(m.globalOptions.ResourceControl, m.options.ResourceControl)
 	systemCfg := system.NewConfig(comp, m.deployUser, m.paths.Deploy).
 		WithMemoryLimit(resource.MemoryLimit).
 		WithCPUQuota(resource.CPUQuota).
 		WithIOReadBandwidthMax(resource.IOReadBandwidthMax).
 		WithIOWriteBandwidthMax(resource.IOWriteBandwidthMax)
 
-	if err := systemCfg.ConfigToFile(sysCfg); err != nil {
-		return err
-	}
-	tgt := filepath.Join("/tmp", comp+"_"+uuid.New().String()+".service")
-	if err := exec.Transfer(sysCfg, tgt, false); err != nil {
-		return err
-	}
-	if outp, errp, err := exec.Execute(fmt.Sprintf("mv %s /etc/systemd/system/%s-%d.service", tgt, comp, port), true); err != nil {
-		if len(outp) > 0 {
-			fmt.Println(string(outp))
-		}
-		if len(errp) > 0 {
-			log.Errorf(string(errp))
-		}
-		return err
-	}
-	return nil
+	service := fmt.Sprintf("%s-%d.service", comp, port)
+	return meta.InstallService(exec, m.host, m.globalOptions.ServiceManager, systemCfg, service, sysCfg)
 }
 
 func (m *MonitoredConfig) syncMonitoredScript(exec executor.TiOpsExecutor, comp string, cfg template.ConfigGenerator) error {
@@ -150,3 +133,8 @@ func (m *MonitoredConfig) String() string {
 	return fmt.Sprintf("MonitoredConfig: cluster=%s, user=%s, node_exporter_port=%d, blackbox_exporter_port=%d, %v",
 		m.name, m.deployUser, m.options.NodeExporterPort, m.options.BlackboxExporterPort, m.paths)
 }
+
+// resumeKey implements the resumable interface
+func (m *MonitoredConfig) resumeKey() string {
+	return fmt.Sprintf("MonitoredConfig: component=%s, host=%s, %v", m.component, m.host, m.paths)
+}
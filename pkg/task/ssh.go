@@ -15,16 +15,69 @@ package task
 
 import (
 	"fmt"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/joomcode/errorx"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
+	"github.com/pingcap-incubator/tiup/pkg/utils"
 )
 
 var (
 	errNS = errorx.NewNamespace("task")
 )
 
+// EnvNameSSHPassword is the env var of the SSH password of the deploy user
+const EnvNameSSHPassword = "TIUP_CLUSTER_SSH_PASSWORD"
+
+// userPassword is the SSH password of the deploy user, it's only read once
+// and shared by all UserSSH tasks
+var userPassword struct {
+	sync.Once
+	password string
+	forced   bool
+}
+
+// EnableUserSSHPassword makes UserSSH login with the password of the deploy
+// user even if the private key of the cluster exists, both are tried then
+func EnableUserSSHPassword() {
+	userPassword.forced = true
+}
+
+// getUserSSHPassword returns the SSH password of the deploy user from the env
+// var, it's prompted on the first call if the env var is not set
+func getUserSSHPassword(user string) string {
+	userPassword.Do(func() {
+		userPassword.password = os.Getenv(EnvNameSSHPassword)
+		if userPassword.password == "" {
+			userPassword.password = cliutil.PromptForPassword("Input SSH password of user %s: ", user)
+		}
+	})
+	return userPassword.password
+}
+
+// userSSHConfig returns the SSH config to login the host as the deploy user,
+// by the private key of the cluster in the context, or the password if the
+// key is missing or the password authentication is enabled
+func userSSHConfig(ctx *Context, host string, port int, deployUser string, timeout int64) executor.SSHConfig {
+	config := executor.SSHConfig{
+		Host:    host,
+		Port:    port,
+		User:    deployUser,
+		Timeout: time.Second * time.Duration(timeout),
+	}
+	hasKey := ctx.PrivateKeyPath != "" && utils.IsExist(ctx.PrivateKeyPath)
+	if hasKey {
+		config.KeyFile = ctx.PrivateKeyPath
+	}
+	if !hasKey || userPassword.forced {
+		config.Password = getUserSSHPassword(deployUser)
+	}
+	return config
+}
+
 // RootSSH is used to establish a SSH connection to the target host with specific key
 type RootSSH struct {
 	host       string // hostname of the SSH server
@@ -78,13 +131,8 @@ type UserSSH struct {
 
 // Execute implements the Task interface
 func (s *UserSSH) Execute(ctx *Context) error {
-	e := executor.NewSSHExecutor(executor.SSHConfig{
-		Host:    s.host,
-		Port:    s.port,
-		KeyFile: ctx.PrivateKeyPath,
-		User:    s.deployUser,
-		Timeout: time.Second * time.Duration(s.timeout),
-	}, false) // not using sudo by default
+	config := userSSHConfig(ctx, s.host, s.port, s.deployUser, s.timeout)
+	e := executor.NewSSHExecutor(config, false) // not using sudo by default
 
 	ctx.SetExecutor(s.host, e)
 	return nil
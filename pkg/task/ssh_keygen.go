@@ -30,7 +30,8 @@ import (
 
 // SSHKeyGen is used to generate SSH key
 type SSHKeyGen struct {
-	keypath string
+	keypath   string
+	generated bool // the key is generated rather than an existing one
 }
 
 // Execute implements the Task interface
@@ -74,6 +75,7 @@ func (s *SSHKeyGen) Execute(ctx *Context) error {
 		return errors.Trace(err)
 	}
 
+	s.generated = true
 	ctx.PublicKeyPath = savePublicFileTo
 	ctx.PrivateKeyPath = savePrivateFileTo
 	return nil
@@ -133,6 +135,9 @@ func (s *SSHKeyGen) writeKeyToFile(keyBytes []byte, saveFileTo string) error {
 
 // Rollback implements the Task interface
 func (s *SSHKeyGen) Rollback(ctx *Context) error {
+	if !s.generated {
+		return nil
+	}
 	return os.Remove(s.keypath)
 }
 
@@ -14,9 +14,11 @@
 package task
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/pingcap-incubator/tiup-cluster/pkg/cliutil/progress"
+	"go.uber.org/atomic"
 )
 
 // StepDisplay is a task that will display a progress bar for inner task.
@@ -25,6 +27,11 @@ type StepDisplay struct {
 	prefix      string
 	children    map[Task]struct{}
 	progressBar progress.Bar
+
+	// the number of the inner tasks that do the real work, and how many of
+	// them are finished, for the progress shown in the bar
+	total    int
+	finished atomic.Int32
 }
 
 func addChildren(m map[Task]struct{}, task Task) {
@@ -52,11 +59,18 @@ func addChildren(m map[Task]struct{}, task Task) {
 func newStepDisplay(prefix string, inner Task) *StepDisplay {
 	children := make(map[Task]struct{})
 	addChildren(children, inner)
+	total := 0
+	for t := range children {
+		if !isDisplayTask(t) {
+			total++
+		}
+	}
 	return &StepDisplay{
 		inner:       inner,
 		prefix:      prefix,
 		children:    children,
 		progressBar: progress.NewSingleBar(prefix),
+		total:       total,
 	}
 }
 
@@ -71,7 +85,9 @@ func (s *StepDisplay) Execute(ctx *Context) error {
 	}
 	ctx.ev.Subscribe(EventTaskBegin, s.handleTaskBegin)
 	ctx.ev.Subscribe(EventTaskProgress, s.handleTaskProgress)
+	ctx.ev.Subscribe(EventTaskFinish, s.handleTaskFinish)
 	err := s.inner.Execute(ctx)
+	ctx.ev.Unsubscribe(EventTaskFinish, s.handleTaskFinish)
 	ctx.ev.Unsubscribe(EventTaskProgress, s.handleTaskProgress)
 	ctx.ev.Unsubscribe(EventTaskBegin, s.handleTaskBegin)
 	if err != nil {
@@ -107,7 +123,7 @@ func (s *StepDisplay) handleTaskBegin(task Task) {
 	}
 	s.progressBar.UpdateDisplay(&progress.DisplayProps{
 		Prefix: s.prefix,
-		Suffix: strings.Split(task.String(), "\n")[0],
+		Suffix: s.withCount(strings.Split(task.String(), "\n")[0]),
 	})
 }
 
@@ -117,10 +133,26 @@ func (s *StepDisplay) handleTaskProgress(task Task, p string) {
 	}
 	s.progressBar.UpdateDisplay(&progress.DisplayProps{
 		Prefix: s.prefix,
-		Suffix: strings.Split(p, "\n")[0],
+		Suffix: s.withCount(strings.Split(p, "\n")[0]),
 	})
 }
 
+func (s *StepDisplay) handleTaskFinish(task Task, err error) {
+	if _, ok := s.children[task]; !ok || isDisplayTask(task) {
+		return
+	}
+	s.finished.Inc()
+}
+
+// withCount prefixes the suffix with the count of finished inner tasks, it's
+// omitted if there is only one inner task
+func (s *StepDisplay) withCount(suffix string) string {
+	if s.total <= 1 {
+		return suffix
+	}
+	return fmt.Sprintf("(%d/%d) %s", s.finished.Load(), s.total, suffix)
+}
+
 // ParallelStepDisplay is a task that will display multiple progress bars in parallel for inner tasks.
 // Inner tasks will be executed in parallel.
 type ParallelStepDisplay struct {
@@ -129,7 +161,7 @@ type ParallelStepDisplay struct {
 	progressBar *progress.MultiBar
 }
 
-func newParallelStepDisplay(prefix string, sdTasks ...*StepDisplay) *ParallelStepDisplay {
+func newParallelStepDisplay(prefix string, concurrency int, sdTasks ...*StepDisplay) *ParallelStepDisplay {
 	bar := progress.NewMultiBar(prefix)
 	tasks := make([]Task, 0, len(sdTasks))
 	for _, t := range sdTasks {
@@ -137,7 +169,7 @@ func newParallelStepDisplay(prefix string, sdTasks ...*StepDisplay) *ParallelSte
 		tasks = append(tasks, t)
 	}
 	return &ParallelStepDisplay{
-		inner:       &Parallel{inner: tasks},
+		inner:       &Parallel{inner: tasks, concurrency: concurrency},
 		prefix:      prefix,
 		progressBar: bar,
 	}
@@ -14,15 +14,19 @@
 package task
 
 import (
+	"context"
 	stderrors "errors"
 	"fmt"
 	"strings"
 	"sync"
 
+	"github.com/joomcode/errorx"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/errutil"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/executor"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/log"
 	"github.com/pingcap-incubator/tiup-cluster/pkg/operation"
 	"github.com/pingcap-incubator/tiup/pkg/repository"
+	"go.uber.org/atomic"
 )
 
 var (
@@ -32,8 +36,28 @@ var (
 	ErrNoExecutor = stderrors.New("no executor")
 	// ErrNoOutput means not being able to get the output of host.
 	ErrNoOutput = stderrors.New("no outputs available")
+	// ErrCancelled means the execution is cancelled, e.g. by Ctrl+C.
+	ErrCancelled = errutil.ErrCancelled
 )
 
+// baseContext is the parent of the cancellation of all task contexts
+var baseContext = context.Background()
+
+// running is the number of the executing Serial and Parallel tasks
+var running atomic.Int32
+
+// Running returns if any task is being executed
+func Running() bool {
+	return running.Load() > 0
+}
+
+// SetBaseContext makes the task contexts created after cancelled when c is
+// done, the running tasks are finished, the remaining ones are not started,
+// and the finished ones are rolled back
+func SetBaseContext(c context.Context) {
+	baseContext = c
+}
+
 type (
 	// Task represents a operation while TiOps execution
 	Task interface {
@@ -51,7 +75,9 @@ type (
 	// We should use mutex to prevent concurrent R/W for some fields
 	// because of the same context can be shared in parallel tasks.
 	Context struct {
-		ev EventBus
+		ev         EventBus
+		cancel     context.Context
+		checkpoint *Checkpoint
 
 		exec struct {
 			sync.RWMutex
@@ -78,13 +104,15 @@ type (
 	Parallel struct {
 		hideDetailDisplay bool
 		inner             []Task
+		concurrency       int // the max number of inner tasks executed at the same time, 0 means no limit
 	}
 )
 
 // NewContext create a context instance.
 func NewContext() *Context {
 	return &Context{
-		ev: NewEventBus(),
+		ev:     NewEventBus(),
+		cancel: baseContext,
 		exec: struct {
 			sync.RWMutex
 			executors    map[string]executor.TiOpsExecutor
@@ -103,6 +131,17 @@ func NewContext() *Context {
 	}
 }
 
+// Cancelled returns if the execution is cancelled
+func (ctx *Context) Cancelled() bool {
+	return ctx.cancel.Err() != nil
+}
+
+// SetCheckpoint makes the resumable tasks recorded in the checkpoint once
+// finished, and the ones already recorded skipped
+func (ctx *Context) SetCheckpoint(cp *Checkpoint) {
+	ctx.checkpoint = cp
+}
+
 // Get implements operation ExecutorGetter interface.
 func (ctx *Context) Get(host string) (e executor.TiOpsExecutor) {
 	ctx.exec.Lock()
@@ -199,16 +238,26 @@ func isDisplayTask(t Task) bool {
 
 // Execute implements the Task interface
 func (s *Serial) Execute(ctx *Context) error {
-	for _, t := range s.inner {
+	running.Inc()
+	defer running.Dec()
+
+	for i, t := range s.inner {
+		if ctx.Cancelled() {
+			rollbackFinished(ctx, s.inner[:i])
+			return ErrCancelled
+		}
 		if !isDisplayTask(t) {
 			if !s.hideDetailDisplay {
 				log.Infof("+ [ Serial ] - %s", t.String())
 			}
 		}
 		ctx.ev.PublishTaskBegin(t)
-		err := t.Execute(ctx)
+		err := executeResumable(ctx, t)
 		ctx.ev.PublishTaskFinish(t, err)
 		if err != nil {
+			if ctx.Cancelled() {
+				rollbackFinished(ctx, s.inner[:i])
+			}
 			return err
 		}
 	}
@@ -220,13 +269,28 @@ func (s *Serial) Rollback(ctx *Context) error {
 	// Rollback in reverse order
 	for i := len(s.inner) - 1; i >= 0; i-- {
 		err := s.inner[i].Rollback(ctx)
-		if err != nil {
+		if err != nil && err != ErrUnsupportedRollback {
 			return err
 		}
 	}
 	return nil
 }
 
+// rollbackFinished rolls back the finished tasks in reverse order once the
+// execution is cancelled, the tasks not supporting rollback are skipped
+func rollbackFinished(ctx *Context, tasks []Task) {
+	for i := len(tasks) - 1; i >= 0; i-- {
+		if err := tasks[i].Rollback(ctx); err != nil && err != ErrUnsupportedRollback {
+			log.Warnf("Failed to rollback %s: %s", strings.Split(tasks[i].String(), "\n")[0], err)
+		}
+		if ctx.checkpoint != nil {
+			if err := ctx.checkpoint.forget(tasks[i]); err != nil {
+				log.Warnf("Failed to update the checkpoint: %s", err)
+			}
+		}
+	}
+}
+
 // String implements the fmt.Stringer interface
 func (s *Serial) String() string {
 	var ss []string
@@ -238,32 +302,73 @@ func (s *Serial) String() string {
 
 // Execute implements the Task interface
 func (pt *Parallel) Execute(ctx *Context) error {
-	var firstError error
+	running.Inc()
+	defer running.Dec()
+
+	var errs []error
 	var mu sync.Mutex
+	var finished []Task
+	var sem chan struct{}
+	if pt.concurrency > 0 {
+		sem = make(chan struct{}, pt.concurrency)
+	}
 	wg := sync.WaitGroup{}
 	for _, t := range pt.inner {
+		if sem != nil {
+			sem <- struct{}{}
+		}
+		if ctx.Cancelled() {
+			mu.Lock()
+			errs = append(errs, ErrCancelled)
+			mu.Unlock()
+			break
+		}
 		wg.Add(1)
 		go func(t Task) {
 			defer wg.Done()
+			if sem != nil {
+				defer func() { <-sem }()
+			}
 			if !isDisplayTask(t) {
 				if !pt.hideDetailDisplay {
 					log.Infof("+ [Parallel] - %s", t.String())
 				}
 			}
 			ctx.ev.PublishTaskBegin(t)
-			err := t.Execute(ctx)
+			err := executeResumable(ctx, t)
 			ctx.ev.PublishTaskFinish(t, err)
+			mu.Lock()
 			if err != nil {
-				mu.Lock()
-				if firstError == nil {
-					firstError = err
-				}
-				mu.Unlock()
+				errs = append(errs, err)
+			} else {
+				finished = append(finished, t)
 			}
+			mu.Unlock()
 		}(t)
 	}
 	wg.Wait()
-	return firstError
+	if len(errs) > 0 && ctx.Cancelled() {
+		rollbackFinished(ctx, finished)
+	}
+	return aggregateErrors(errs, len(pt.inner))
+}
+
+// aggregateErrors returns the only error as is, or the first one decorated
+// with the count of failures while the others are logged, so that none of
+// the failed hosts is hidden. The first error is kept as the cause rather
+// than merged with the others, as merging the errors of different types
+// drops their traits and so the exit code.
+func aggregateErrors(errs []error, total int) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	for _, err := range errs[1:] {
+		log.Errorf("%s", err)
+	}
+	return errorx.Decorate(errs[0], "%d of %d tasks failed, the others are logged above", len(errs), total)
 }
 
 // Rollback implements the Task interface
@@ -276,7 +381,7 @@ func (pt *Parallel) Rollback(ctx *Context) error {
 		go func(t Task) {
 			defer wg.Done()
 			err := t.Rollback(ctx)
-			if err != nil {
+			if err != nil && err != ErrUnsupportedRollback {
 				mu.Lock()
 				if firstError == nil {
 					firstError = err
@@ -0,0 +1,146 @@
+package task
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joomcode/errorx"
+	"github.com/pingcap-incubator/tiup-cluster/pkg/errutil"
+	"github.com/pingcap/check"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type taskSuite struct{}
+
+var _ = check.Suite(&taskSuite{})
+
+// fakeTask counts its executions and fails with err, it's resumable by name
+type fakeTask struct {
+	name    string
+	err     error
+	mu      sync.Mutex
+	execute int
+	before  func()
+}
+
+func (t *fakeTask) Execute(ctx *Context) error {
+	if t.before != nil {
+		t.before()
+	}
+	t.mu.Lock()
+	t.execute++
+	t.mu.Unlock()
+	return t.err
+}
+
+func (t *fakeTask) Rollback(ctx *Context) error { return nil }
+
+func (t *fakeTask) String() string { return t.name }
+
+func (t *fakeTask) resumeKey() string { return t.name }
+
+func (s *taskSuite) TestAggregateErrors(c *check.C) {
+	c.Assert(aggregateErrors(nil, 3), check.IsNil)
+
+	err := errors.New("failed")
+	c.Assert(aggregateErrors([]error{err}, 3), check.Equals, err)
+
+	// the trait of the first error is kept even if the others are of
+	// different types
+	ns := errorx.NewNamespace("test")
+	sshFailed := ns.NewType("ssh_failed", errutil.ErrTraitSSHFailed)
+	other := ns.NewType("other")
+	agg := aggregateErrors([]error{sshFailed.New("connect failed"), other.New("failed"), err}, 3)
+	c.Assert(agg, check.NotNil)
+	c.Assert(errutil.ExitCode(agg), check.Equals, errutil.ExitCodeSSHFailed)
+	c.Assert(agg.Error(), check.Matches, "3 of 3 tasks failed.*connect failed.*")
+}
+
+func (s *taskSuite) TestParallelConcurrency(c *check.C) {
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	before := func() {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}
+
+	var tasks []Task
+	var fakes []*fakeTask
+	for i := 0; i < 10; i++ {
+		t := &fakeTask{name: string(rune('a' + i)), before: before}
+		tasks = append(tasks, t)
+		fakes = append(fakes, t)
+	}
+	p := &Parallel{inner: tasks, concurrency: 3, hideDetailDisplay: true}
+	c.Assert(p.Execute(NewContext()), check.IsNil)
+	c.Assert(maxRunning <= 3, check.IsTrue)
+	for _, t := range fakes {
+		c.Assert(t.execute, check.Equals, 1)
+	}
+
+	// all failures are counted
+	failed := &Parallel{inner: []Task{
+		&fakeTask{name: "a", err: errors.New("failed a")},
+		&fakeTask{name: "b"},
+		&fakeTask{name: "c", err: errors.New("failed c")},
+	}, concurrency: 1, hideDetailDisplay: true}
+	err := failed.Execute(NewContext())
+	c.Assert(err, check.NotNil)
+	c.Assert(err.Error(), check.Matches, "2 of 3 tasks failed.*")
+}
+
+func (s *taskSuite) TestCheckpoint(c *check.C) {
+	file := filepath.Join(c.MkDir(), "checkpoint")
+	first := &fakeTask{name: "first"}
+	second := &fakeTask{name: "second", err: errors.New("failed")}
+
+	cp, err := NewCheckpoint(file, false)
+	c.Assert(err, check.IsNil)
+	ctx := NewContext()
+	ctx.SetCheckpoint(cp)
+	serial := &Serial{inner: []Task{first, second}, hideDetailDisplay: true}
+	c.Assert(serial.Execute(ctx), check.NotNil)
+	c.Assert(cp.Len(), check.Equals, 1)
+
+	// the finished task is skipped when resuming
+	cp, err = NewCheckpoint(file, true)
+	c.Assert(err, check.IsNil)
+	c.Assert(cp.Len(), check.Equals, 1)
+	c.Assert(cp.finishedBefore(first), check.IsTrue)
+	c.Assert(cp.finishedBefore(second), check.IsFalse)
+	second.err = nil
+	ctx = NewContext()
+	ctx.SetCheckpoint(cp)
+	c.Assert(serial.Execute(ctx), check.IsNil)
+	c.Assert(first.execute, check.Equals, 1)
+	c.Assert(second.execute, check.Equals, 2)
+	c.Assert(cp.Len(), check.Equals, 2)
+
+	// the forgotten tasks are executed again
+	c.Assert(cp.forget(&Serial{inner: []Task{first}}), check.IsNil)
+	cp, err = NewCheckpoint(file, true)
+	c.Assert(err, check.IsNil)
+	c.Assert(cp.Len(), check.Equals, 1)
+	c.Assert(cp.finishedBefore(first), check.IsFalse)
+	c.Assert(cp.finishedBefore(second), check.IsTrue)
+
+	// the checkpoint is cleared if not resuming
+	cp, err = NewCheckpoint(file, false)
+	c.Assert(err, check.IsNil)
+	c.Assert(cp.Len(), check.Equals, 0)
+	cp, err = NewCheckpoint(file, true)
+	c.Assert(err, check.IsNil)
+	c.Assert(cp.Len(), check.Equals, 0)
+}
@@ -40,6 +40,14 @@ func (u *UpdateMeta) Execute(ctx *Context) error {
 	}
 
 	deleted := set.NewStringSet(u.deletedNodesID...)
+	if u.metadata.Instances != nil {
+		newMeta.Instances = make(map[string]*meta.InstanceMeta)
+		for id, im := range u.metadata.Instances {
+			if !deleted.Exist(id) {
+				newMeta.Instances[id] = im
+			}
+		}
+	}
 	topo := u.metadata.Topology
 	for i, instance := range (&meta.TiDBComponent{ClusterSpecification: topo}).Instances() {
 		if deleted.Exist(instance.ID()) {
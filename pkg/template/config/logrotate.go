@@ -0,0 +1,93 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path"
+	"text/template"
+
+	"github.com/pingcap-incubator/tiup-cluster/pkg/embed"
+)
+
+// LogrotateConfig represent the data to generate the logrotate config of the
+// log dir of an instance
+type LogrotateConfig struct {
+	LogDir   string
+	User     string
+	MaxSize  string
+	MaxFiles int
+	Compress bool
+}
+
+// NewLogrotateConfig returns a LogrotateConfig
+func NewLogrotateConfig(logDir, user string) *LogrotateConfig {
+	return &LogrotateConfig{
+		LogDir: logDir,
+		User:   user,
+	}
+}
+
+// WithMaxSize set the MaxSize field of LogrotateConfig
+func (c *LogrotateConfig) WithMaxSize(size string) *LogrotateConfig {
+	c.MaxSize = size
+	return c
+}
+
+// WithMaxFiles set the MaxFiles field of LogrotateConfig
+func (c *LogrotateConfig) WithMaxFiles(n int) *LogrotateConfig {
+	c.MaxFiles = n
+	return c
+}
+
+// WithCompress set the Compress field of LogrotateConfig
+func (c *LogrotateConfig) WithCompress(compress bool) *LogrotateConfig {
+	c.Compress = compress
+	return c
+}
+
+// Config generate the config file data.
+func (c *LogrotateConfig) Config() ([]byte, error) {
+	fp := path.Join("/templates", "config", "logrotate.conf.tpl")
+	tpl, err := embed.ReadFile(fp)
+	if err != nil {
+		return nil, err
+	}
+	return c.ConfigWithTemplate(string(tpl))
+}
+
+// ConfigToFile write config content to specific path
+func (c *LogrotateConfig) ConfigToFile(file string) error {
+	config, err := c.Config()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, config, 0644)
+}
+
+// ConfigWithTemplate generate the logrotate config content by tpl
+func (c *LogrotateConfig) ConfigWithTemplate(tpl string) ([]byte, error) {
+	tmpl, err := template.New("logrotate").Parse(tpl)
+	if err != nil {
+		return nil, err
+	}
+
+	content := bytes.NewBufferString("")
+	if err := tmpl.Execute(content, c); err != nil {
+		return nil, err
+	}
+
+	return content.Bytes(), nil
+}
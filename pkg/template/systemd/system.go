@@ -30,6 +30,8 @@ type Config struct {
 	CPUQuota            string
 	IOReadBandwidthMax  string
 	IOWriteBandwidthMax string
+	LimitNOFILE         string
+	TasksMax            string
 	DeployDir           string
 	DisableSendSigkill  bool
 	// Takes one of no, on-success, on-failure, on-abnormal, on-watchdog, on-abort, or always.
@@ -70,6 +72,18 @@ func (c *Config) WithIOWriteBandwidthMax(io string) *Config {
 	return c
 }
 
+// WithLimitNOFILE set the LimitNOFILE field of Config
+func (c *Config) WithLimitNOFILE(n string) *Config {
+	c.LimitNOFILE = n
+	return c
+}
+
+// WithTasksMax set the TasksMax field of Config
+func (c *Config) WithTasksMax(n string) *Config {
+	c.TasksMax = n
+	return c
+}
+
 // ConfigToFile write config content to specific path
 func (c *Config) ConfigToFile(file string) error {
 	config, err := c.Config()
@@ -103,3 +117,40 @@ func (c *Config) ConfigWithTemplate(tpl string) ([]byte, error) {
 
 	return content.Bytes(), nil
 }
+
+// supervisordTemplate is the template of the supervisord program config, the
+// resource control of systemd is not supported by supervisord
+const supervisordTemplate = `[program:{{.Program}}]
+command={{.DeployDir}}/scripts/run_{{.ServiceName}}.sh
+user={{.User}}
+autostart=true
+{{- if eq .Restart "on-failure"}}
+autorestart=unexpected
+{{- else}}
+autorestart=true
+{{- end}}
+startsecs=15
+stopwaitsecs=90
+stopasgroup=true
+killasgroup=true
+`
+
+// SupervisordConfig generates the supervisord program config of the service
+// as the program, which is used on the hosts without systemd
+func (c *Config) SupervisordConfig(program string) ([]byte, error) {
+	tmpl, err := template.New("supervisord").Parse(supervisordTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	content := bytes.NewBufferString("")
+	data := struct {
+		*Config
+		Program string
+	}{c, program}
+	if err := tmpl.Execute(content, data); err != nil {
+		return nil, err
+	}
+
+	return content.Bytes(), nil
+}
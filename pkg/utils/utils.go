@@ -36,6 +36,28 @@ func JoinInt(nums []int, delim string) string {
 	return strings.TrimSuffix(result, delim)
 }
 
+// ParseDuration parses the duration string like time.ParseDuration, but also
+// accepts days with the unit "d", e.g. "30d" and "1d12h"
+func ParseDuration(s string) (time.Duration, error) {
+	var days time.Duration
+	if i := strings.Index(s, "d"); i >= 0 {
+		n, err := strconv.Atoi(s[:i])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %s", s)
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		if s = s[i+1:]; s == "" {
+			return days, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return days + d, nil
+}
+
 // RetryOption is options for Retry()
 type RetryOption struct {
 	Attempts int64
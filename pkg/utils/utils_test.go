@@ -0,0 +1,43 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"time"
+
+	"github.com/pingcap/check"
+)
+
+type utilsSuite struct{}
+
+var _ = check.Suite(&utilsSuite{})
+
+func (s *utilsSuite) TestParseDuration(c *check.C) {
+	cases := map[string]time.Duration{
+		"30d":   30 * 24 * time.Hour,
+		"12h":   12 * time.Hour,
+		"1d12h": 36 * time.Hour,
+		"90m":   90 * time.Minute,
+	}
+	for in, expected := range cases {
+		d, err := ParseDuration(in)
+		c.Assert(err, check.IsNil)
+		c.Assert(d, check.Equals, expected)
+	}
+
+	for _, in := range []string{"", "d", "-1d", "xd", "1d2x"} {
+		_, err := ParseDuration(in)
+		c.Assert(err, check.NotNil)
+	}
+}
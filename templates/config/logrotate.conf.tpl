@@ -0,0 +1,16 @@
+{{.LogDir}}/*.log {
+{{- if .MaxSize}}
+    size {{.MaxSize}}
+{{- else}}
+    daily
+{{- end}}
+    rotate {{if .MaxFiles}}{{.MaxFiles}}{{else}}10{{end}}
+    missingok
+    notifempty
+    copytruncate
+{{- if .Compress}}
+    compress
+    delaycompress
+{{- end}}
+    su {{.User}} {{.User}}
+}
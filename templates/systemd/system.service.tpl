@@ -15,7 +15,14 @@ IOReadBandwidthMax={{.IOReadBandwidthMax}}
 {{- if .IOWriteBandwidthMax}}
 IOWriteBandwidthMax={{.IOWriteBandwidthMax}}
 {{- end}}
+{{- if .TasksMax}}
+TasksMax={{.TasksMax}}
+{{- end}}
+{{- if .LimitNOFILE}}
+LimitNOFILE={{.LimitNOFILE}}
+{{- else}}
 LimitNOFILE=1000000
+{{- end}}
 #LimitCORE=infinity
 LimitSTACK=10485760
 